@@ -0,0 +1,19 @@
+# Reboot a VM on demand: terraform apply -invoke=action.prodata_vm_reboot.web
+action "prodata_vm_reboot" "web" {
+  config {
+    vm_id = prodata_vm.web.id
+  }
+}
+
+# Or reboot it whenever its attached volume is replaced.
+resource "prodata_volume_attachment" "data" {
+  vm_id     = prodata_vm.web.id
+  volume_id = prodata_volume.data.id
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.prodata_vm_reboot.web]
+    }
+  }
+}
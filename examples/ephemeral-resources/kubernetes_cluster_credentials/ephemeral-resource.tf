@@ -0,0 +1,11 @@
+# Credentials for the kubernetes provider that are never written to state.
+ephemeral "prodata_kubernetes_cluster_credentials" "main" {
+  id = prodata_kubernetes_cluster.main.id
+}
+
+provider "kubernetes" {
+  host                   = ephemeral.prodata_kubernetes_cluster_credentials.main.host
+  cluster_ca_certificate = base64decode(ephemeral.prodata_kubernetes_cluster_credentials.main.cluster_ca_certificate)
+  client_certificate     = base64decode(ephemeral.prodata_kubernetes_cluster_credentials.main.client_certificate)
+  client_key             = base64decode(ephemeral.prodata_kubernetes_cluster_credentials.main.client_key)
+}
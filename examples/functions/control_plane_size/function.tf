@@ -0,0 +1,9 @@
+data "prodata_kubernetes_flavors" "all" {}
+
+# The control-plane size of an existing cluster's master flavor.
+output "control_plane_size" {
+  value = provider::prodata::control_plane_size(
+    data.prodata_kubernetes_flavors.all.flavors,
+    prodata_kubernetes_cluster.main.master_flavor_id,
+  )
+}
@@ -0,0 +1,13 @@
+# Check a node IP range in a module variable before it reaches the cluster.
+variable "node_ip_range" {
+  type = string
+
+  validation {
+    condition     = provider::prodata::node_ip_range(var.node_ip_range).size >= 8
+    error_message = "node_ip_range must span at least 8 addresses."
+  }
+}
+
+output "first_node_ip" {
+  value = provider::prodata::node_ip_range(var.node_ip_range).start
+}
@@ -0,0 +1,12 @@
+# Configure the kubernetes provider from a kubeconfig held outside the cluster
+# resource, e.g. one read from a secrets store.
+locals {
+  kube = provider::prodata::parse_kubeconfig(var.kubeconfig)
+}
+
+provider "kubernetes" {
+  host                   = local.kube.host
+  cluster_ca_certificate = base64decode(local.kube.cluster_ca_certificate)
+  client_certificate     = base64decode(local.kube.client_certificate)
+  client_key             = base64decode(local.kube.client_key)
+}
@@ -0,0 +1,12 @@
+list "prodata_kubernetes_cluster" "all" {
+  provider = prodata
+}
+
+list "prodata_kubernetes_cluster" "staging" {
+  provider = prodata
+
+  config {
+    region      = "UZ-5"
+    project_tag = "staging"
+  }
+}
@@ -0,0 +1,12 @@
+list "prodata_lb" "all" {
+  provider = prodata
+}
+
+list "prodata_lb" "staging" {
+  provider = prodata
+
+  config {
+    region      = "UZ-5"
+    project_tag = "staging"
+  }
+}
@@ -0,0 +1,12 @@
+list "prodata_local_network" "all" {
+  provider = prodata
+}
+
+list "prodata_local_network" "staging" {
+  provider = prodata
+
+  config {
+    region      = "UZ-5"
+    project_tag = "staging"
+  }
+}
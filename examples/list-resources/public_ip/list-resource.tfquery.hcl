@@ -0,0 +1,12 @@
+list "prodata_public_ip" "all" {
+  provider = prodata
+}
+
+list "prodata_public_ip" "staging" {
+  provider = prodata
+
+  config {
+    region      = "UZ-5"
+    project_tag = "staging"
+  }
+}
@@ -0,0 +1,12 @@
+list "prodata_s3_bucket" "all" {
+  provider = prodata
+}
+
+list "prodata_s3_bucket" "staging" {
+  provider = prodata
+
+  config {
+    region      = "UZ-5"
+    project_tag = "staging"
+  }
+}
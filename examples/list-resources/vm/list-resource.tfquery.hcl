@@ -0,0 +1,12 @@
+list "prodata_vm" "all" {
+  provider = prodata
+}
+
+list "prodata_vm" "staging" {
+  provider = prodata
+
+  config {
+    region      = "UZ-5"
+    project_tag = "staging"
+  }
+}
@@ -0,0 +1,12 @@
+list "prodata_volume" "all" {
+  provider = prodata
+}
+
+list "prodata_volume" "staging" {
+  provider = prodata
+
+  config {
+    region      = "UZ-5"
+    project_tag = "staging"
+  }
+}
@@ -21,3 +21,8 @@ provider "prodata" {
 # PRODATA_API_SECRET_KEY
 # PRODATA_REGION
 # PRODATA_PROJECT_TAG
+#
+# Or name a profile from ~/.prodata/credentials:
+# provider "prodata" {
+#   profile = "staging"
+# }
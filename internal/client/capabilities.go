@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// Features the panel may report in its capabilities. A panel-main release adds
+// its feature here when the provider needs to know whether it is deployed.
+const (
+	// FeatureNodeIPRangeAllocation: the panel allocates a Kubernetes cluster's
+	// node_ip_range itself when the request omits it.
+	FeatureNodeIPRangeAllocation = "kubernetes.node_ip_range_allocation"
+	// FeatureCCMLoadBalancer: load balancers can target a Kubernetes node pool
+	// through the cloud controller manager endpoints.
+	FeatureCCMLoadBalancer = "loadbalancer.ccm"
+	// FeatureObjectLock: buckets support object locking.
+	FeatureObjectLock = "storage.object_lock"
+	// FeatureIdempotencyKeys: the panel honours the Idempotency-Key header on
+	// mutating requests.
+	FeatureIdempotencyKeys = "api.idempotency_keys"
+)
+
+// Capabilities is what the panel reports about its own deploy.
+type Capabilities struct {
+	Version  string   `json:"version"`
+	Features []string `json:"features"`
+}
+
+// Support is whether the panel supports a feature, as far as the provider knows.
+type Support int
+
+const (
+	// SupportUnknown: the panel did not report its capabilities, either
+	// because it predates the capabilities endpoint or because the probe failed.
+	SupportUnknown Support = iota
+	// SupportYes: the panel reports the feature.
+	SupportYes
+	// SupportNo: the panel reports its capabilities, without the feature.
+	SupportNo
+)
+
+// GetCapabilities reads the panel's version and feature list.
+func (c *Client) GetCapabilities(ctx context.Context) (*Capabilities, error) {
+	var caps Capabilities
+	if err := c.Do(ctx, http.MethodGet, "/api/v2/capabilities", nil, &caps, nil); err != nil {
+		return nil, err
+	}
+	return &caps, nil
+}
+
+// negotiated caches each panel's capabilities for the life of the process, so
+// the provider probes once per provider process (per alias within it, unless
+// aliases share a panel). Terraform starts a new provider process for each walk
+// (validate, plan, apply), so one command can probe more than once. Keyed by
+// base URL; the value is a *Capabilities, nil for a panel without the endpoint.
+// Failed probes are not cached.
+var negotiated sync.Map // map[string]*Capabilities
+
+// NegotiateCapabilities learns the panel's capabilities, for Supports: from the
+// process-wide cache when this panel was already probed, else from the panel.
+// A panel that predates the endpoint answers not-found, which leaves every
+// feature SupportUnknown without an error; any other failure is returned and
+// also leaves them unknown.
+func (c *Client) NegotiateCapabilities(ctx context.Context) error {
+	if v, ok := negotiated.Load(c.baseURL); ok {
+		caps, _ := v.(*Capabilities) // negotiated only ever stores *Capabilities
+		c.setCapabilities(caps)
+		return nil
+	}
+	caps, err := c.GetCapabilities(ctx)
+	if err != nil && !IsNotFound(err) {
+		return err
+	}
+	negotiated.Store(c.baseURL, caps)
+	c.setCapabilities(caps)
+	return nil
+}
+
+func (c *Client) setCapabilities(caps *Capabilities) {
+	if caps == nil {
+		return
+	}
+	c.capabilities.Store(caps)
+	if slices.Contains(caps.Features, FeatureIdempotencyKeys) {
+		c.idempotencyHonoured.Store(true)
+	}
+}
+
+// Capabilities returns what NegotiateCapabilities found, or nil when the panel
+// did not report its capabilities.
+func (c *Client) Capabilities() *Capabilities {
+	return c.capabilities.Load()
+}
+
+// Supports reports whether the panel supports feature.
+func (c *Client) Supports(feature string) Support {
+	caps := c.capabilities.Load()
+	switch {
+	case caps == nil:
+		return SupportUnknown
+	case slices.Contains(caps.Features, feature):
+		return SupportYes
+	default:
+		return SupportNo
+	}
+}
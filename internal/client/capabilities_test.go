@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNegotiateCapabilities(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/panel-main/api/v2/capabilities" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":{"version":"2.41.0","features":["loadbalancer.ccm","api.idempotency_keys"]}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv)
+	if got := c.Supports(FeatureCCMLoadBalancer); got != SupportUnknown {
+		t.Errorf("before negotiating: Supports = %v, want SupportUnknown", got)
+	}
+	if err := c.NegotiateCapabilities(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Supports(FeatureCCMLoadBalancer); got != SupportYes {
+		t.Errorf("Supports(%s) = %v, want SupportYes", FeatureCCMLoadBalancer, got)
+	}
+	if got := c.Supports(FeatureObjectLock); got != SupportNo {
+		t.Errorf("Supports(%s) = %v, want SupportNo", FeatureObjectLock, got)
+	}
+	if v := c.Capabilities().Version; v != "2.41.0" {
+		t.Errorf("Version = %q", v)
+	}
+	if !c.idempotencyHonoured.Load() {
+		t.Error("a panel reporting idempotency keys should enable transport retries of writes")
+	}
+
+	// A second client for the same panel reuses the probe.
+	c2 := newTestClient(t, srv)
+	if err := c2.NegotiateCapabilities(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := c2.Supports(FeatureCCMLoadBalancer); got != SupportYes {
+		t.Errorf("cached: Supports = %v, want SupportYes", got)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("panel probed %d times, want 1", n)
+	}
+}
+
+// TestNegotiateCapabilities_OlderPanel: a panel without the endpoint is not an
+// error; every feature stays unknown.
+func TestNegotiateCapabilities_OlderPanel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status":404,"error":"Not Found","path":"/panel-main/api/v2/capabilities"}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv)
+	if err := c.NegotiateCapabilities(context.Background()); err != nil {
+		t.Fatalf("NegotiateCapabilities = %v, want nil for a panel without the endpoint", err)
+	}
+	if got := c.Supports(FeatureNodeIPRangeAllocation); got != SupportUnknown {
+		t.Errorf("Supports = %v, want SupportUnknown", got)
+	}
+	if c.Capabilities() != nil {
+		t.Error("Capabilities() should be nil")
+	}
+}
+
+// TestNegotiateCapabilities_FailureNotCached: a failed probe is reported and a
+// later one tries the panel again.
+func TestNegotiateCapabilities_FailureNotCached(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":403,"message":"forbidden"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":{"version":"2.41.0","features":["storage.object_lock"]}}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv)
+	if err := c.NegotiateCapabilities(context.Background()); err == nil {
+		t.Fatal("NegotiateCapabilities should report the panel's error")
+	}
+	if got := c.Supports(FeatureObjectLock); got != SupportUnknown {
+		t.Errorf("after a failed probe: Supports = %v, want SupportUnknown", got)
+	}
+	fail.Store(false)
+	if err := c.NegotiateCapabilities(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Supports(FeatureObjectLock); got != SupportYes {
+		t.Errorf("after a successful probe: Supports = %v, want SupportYes", got)
+	}
+}
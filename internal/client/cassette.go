@@ -0,0 +1,319 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Cassette modes for Config.CassetteMode.
+const (
+	// CassetteRecord forwards every request to the panel and adds the redacted
+	// request/response pair to the cassette file, keeping what earlier processes
+	// recorded there (see cassette.record).
+	CassetteRecord = "record"
+	// CassetteRecordNew is CassetteRecord on a cassette emptied first, once per
+	// process.
+	CassetteRecordNew = "record_new"
+	// CassetteReplay serves every request from the cassette file without touching
+	// the network; a request with no recorded counterpart fails.
+	CassetteReplay = "replay"
+)
+
+// cassetteVersion is the on-disk format version, bumped on incompatible changes.
+const cassetteVersion = 1
+
+// recordedHeaders are the request headers kept in a cassette, for reading a trace.
+// X-API-SECRET is kept only as a redacted marker.
+var recordedHeaders = []string{"User-Agent", "X-API-KEY", "X-API-SECRET", "X-Region", "X-Project-Tag", "X-Lang"}
+
+// cassetteFile is the JSON document written to disk.
+type cassetteFile struct {
+	Version      int            `json:"version"`
+	Interactions []*interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+// recordedRequest identifies a request. URI is the path and query only (no scheme
+// or host), so a cassette recorded against one panel replays against any base URL.
+type recordedRequest struct {
+	Method  string            `json:"method"`
+	URI     string            `json:"uri"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	Status  int                 `json:"status"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    string              `json:"body,omitempty"`
+}
+
+// key is the replay match key: method, URI, scope headers and the redacted body.
+// The secret and the User-Agent are excluded so a replay does not depend on them.
+func (r recordedRequest) key() string {
+	return strings.Join([]string{r.Method, r.URI, r.Headers["X-Region"], r.Headers["X-Project-Tag"], r.Body}, "\x00")
+}
+
+// cassette is one cassette file, shared by every client in the process that is
+// configured with the same mode and path. The acceptance framework builds a new
+// provider (and client) for each Terraform command of a test, so sharing is what
+// lets a multi-step test record into, and replay from, a single cassette.
+type cassette struct {
+	mu   sync.Mutex
+	path string
+	file cassetteFile
+	// served counts, per match key, how many recorded responses replay has returned.
+	served map[string]int
+	// recorded counts, per match key, how many responses this process has recorded.
+	recorded map[string]int
+}
+
+var cassettes = struct {
+	mu sync.Mutex
+	m  map[string]*cassette
+}{m: map[string]*cassette{}}
+
+// openCassette returns the process-wide cassette for mode and path. Terraform
+// starts a provider process for each walk of one command (validate, plan,
+// apply), so CassetteRecord loads an existing file and adds to it rather than
+// start over; only CassetteRecordNew truncates it, on first use in a process.
+// Replay loads the file once.
+func openCassette(mode, path string) (*cassette, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("cassette path %q: %w", path, err)
+	}
+	cassettes.mu.Lock()
+	defer cassettes.mu.Unlock()
+	if c, ok := cassettes.m[mode+"\x00"+abs]; ok {
+		return c, nil
+	}
+
+	c := &cassette{
+		path:     abs,
+		file:     cassetteFile{Version: cassetteVersion},
+		served:   map[string]int{},
+		recorded: map[string]int{},
+	}
+	switch mode {
+	case CassetteRecord:
+		err := c.load()
+		if errors.Is(err, fs.ErrNotExist) {
+			err = c.save()
+		}
+		if err != nil {
+			return nil, err
+		}
+	case CassetteRecordNew:
+		if err := c.save(); err != nil {
+			return nil, err
+		}
+	case CassetteReplay:
+		if err := c.load(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q (want %q, %q or %q)", mode, CassetteRecord, CassetteRecordNew, CassetteReplay)
+	}
+	cassettes.m[mode+"\x00"+abs] = c
+	return c, nil
+}
+
+// load reads the cassette file into c.
+func (c *cassette) load() error {
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return fmt.Errorf("read cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &c.file); err != nil {
+		return fmt.Errorf("parse cassette %s: %w", c.path, err)
+	}
+	if c.file.Version != cassetteVersion {
+		return fmt.Errorf("cassette %s has format version %d, want %d", c.path, c.file.Version, cassetteVersion)
+	}
+	return nil
+}
+
+// save writes the cassette atomically (temp file + rename), so an interrupted
+// recording leaves the last complete cassette behind. The caller holds c.mu, or
+// is the only holder of c.
+func (c *cassette) save() error {
+	data, err := json.MarshalIndent(c.file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal cassette: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+// record adds an interaction, deduplicated by match key against what earlier
+// processes recorded. Replay starts every process at the first recorded response
+// for each key, so this process's n-th response for a key replaces the file's
+// n-th one, and is appended only past the end. The plan and apply walks of one
+// command thus share their common reads, and the longer status poll is kept.
+func (c *cassette) record(req recordedRequest, resp recordedResponse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	it := &interaction{Request: req, Response: resp}
+	k := req.key()
+	n := c.recorded[k]
+	c.recorded[k] = n + 1
+	seen := 0
+	for i, old := range c.file.Interactions {
+		if old.Request.key() != k {
+			continue
+		}
+		if seen == n {
+			c.file.Interactions[i] = it
+			return c.save()
+		}
+		seen++
+	}
+	c.file.Interactions = append(c.file.Interactions, it)
+	return c.save()
+}
+
+// replay returns the next recorded response for req. Identical requests (a status
+// poll, say) are answered with their recorded responses in recording order; once
+// those run out the last one is repeated, so a replayed poll loop that happens to
+// ask once more than the recording still converges.
+func (c *cassette) replay(req recordedRequest) (recordedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	k := req.key()
+	n := c.served[k]
+	var last *interaction
+	seen := 0
+	for _, it := range c.file.Interactions {
+		if it.Request.key() != k {
+			continue
+		}
+		if seen == n {
+			c.served[k] = n + 1
+			return it.Response, true
+		}
+		last = it
+		seen++
+	}
+	if last == nil {
+		return recordedResponse{}, false
+	}
+	return last.Response, true
+}
+
+// cassetteTransport is the record/replay http.RoundTripper installed beneath
+// doRequest when Config.CassetteMode is set.
+type cassetteTransport struct {
+	mode     string
+	cassette *cassette
+	next     http.RoundTripper
+}
+
+func newCassetteTransport(mode, path string, next http.RoundTripper) (*cassetteTransport, error) {
+	if path == "" {
+		return nil, fmt.Errorf("cassette mode %q requires a cassette path", mode)
+	}
+	c, err := openCassette(mode, path)
+	if err != nil {
+		return nil, err
+	}
+	return &cassetteTransport{mode: mode, cassette: c, next: next}, nil
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	rec := recordedRequest{
+		Method:  req.Method,
+		URI:     req.URL.RequestURI(),
+		Headers: map[string]string{},
+		Body:    string(redactJSON(body)),
+	}
+	for _, h := range recordedHeaders {
+		if v := req.Header.Get(h); v != "" {
+			if h == "X-API-SECRET" {
+				v = redacted
+			}
+			rec.Headers[h] = v
+		}
+	}
+
+	if t.mode == CassetteReplay {
+		resp, ok := t.cassette.replay(rec)
+		if !ok {
+			return nil, fmt.Errorf("cassette %s: no recorded interaction for %s %s", t.cassette.path, rec.Method, rec.URI)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", resp.Status, http.StatusText(resp.Status)),
+			StatusCode:    resp.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header(resp.Headers).Clone(),
+			Body:          io.NopCloser(strings.NewReader(resp.Body)),
+			ContentLength: int64(len(resp.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	// Transport errors are not recorded: there is no response to replay, and the
+	// retry that usually follows is recorded on its own.
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	headers := resp.Header.Clone()
+	headers.Del("Set-Cookie")
+	if err := t.cassette.record(rec, recordedResponse{
+		Status:  resp.StatusCode,
+		Headers: headers,
+		Body:    string(redactJSON(respBody)),
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,253 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestCassette_RecordRedactsAndReplays records a run against a live server, checks
+// that no secret reaches the cassette, then replays it against an unreachable base
+// URL and gets the same answers in the same order.
+func TestCassette_RecordRedactsAndReplays(t *testing.T) {
+	var polls atomic.Int32
+	kubeconfig := base64.StdEncoding.EncodeToString([]byte(sampleKubeconfig))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/panel-main/api/v2/vms":
+			body, _ := io.ReadAll(r.Body)
+			if !strings.Contains(string(body), "Hunter2!") {
+				t.Errorf("server got a redacted request body: %s", body)
+			}
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":7,"password":"Hunter2!"}}`))
+		case "/panel-main/api/v2/vms/7/status":
+			status := "PROCESSING"
+			if polls.Add(1) > 1 {
+				status = "RUNNING"
+			}
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":7,"status":"` + status + `"}}`))
+		case "/panel-main/api/v2/cluster":
+			_, _ = w.Write([]byte(`{"success":true,"data":{"clusterConfigSecret":"` + kubeconfig + `","sshKeyEncoded":"c3NoLXJzYQ=="}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "run.json")
+	run := func(c *Client) (created map[string]any, statuses []string, cluster map[string]any) {
+		t.Helper()
+		ctx := context.Background()
+		if err := c.Do(ctx, http.MethodPost, "/api/v2/vms", map[string]any{"name": "web", "password": "Hunter2!"}, &created, nil); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		for range 3 {
+			var vm struct{ Status string }
+			if err := c.Do(ctx, http.MethodGet, "/api/v2/vms/7/status", nil, &vm, nil); err != nil {
+				t.Fatalf("status: %v", err)
+			}
+			statuses = append(statuses, vm.Status)
+		}
+		if err := c.Do(ctx, http.MethodGet, "/api/v2/cluster", nil, &cluster, nil); err != nil {
+			t.Fatalf("cluster: %v", err)
+		}
+		return created, statuses, cluster
+	}
+
+	rec, err := New(Config{APIBaseURL: srv.URL, APIKeyID: "k", APISecretKey: "top-secret", CassetteMode: CassetteRecord, CassettePath: path})
+	if err != nil {
+		t.Fatalf("New(record): %v", err)
+	}
+	_, recStatuses, _ := run(rec)
+	if got := strings.Join(recStatuses, ","); got != "PROCESSING,RUNNING,RUNNING" {
+		t.Fatalf("recorded statuses = %s", got)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cassette: %v", err)
+	}
+	for _, secret := range []string{"top-secret", "Hunter2!", "c3NoLXJzYQ==", "Q0xJRU5UX0tFWQ==", kubeconfig} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains secret %q", secret)
+		}
+	}
+	if !strings.Contains(string(data), redacted) {
+		t.Errorf("cassette has no redaction markers:\n%s", data)
+	}
+
+	rep, err := New(Config{APIBaseURL: "https://replay.invalid", APIKeyID: "k", APISecretKey: "other", CassetteMode: CassetteReplay, CassettePath: path})
+	if err != nil {
+		t.Fatalf("New(replay): %v", err)
+	}
+	created, statuses, cluster := run(rep)
+	if created["password"] != redacted {
+		t.Errorf("replayed password = %v, want %s", created["password"], redacted)
+	}
+	if got := strings.Join(statuses, ","); got != "PROCESSING,RUNNING,RUNNING" {
+		t.Errorf("replayed statuses = %s, want the recorded order", got)
+	}
+	secret, _ := cluster["clusterConfigSecret"].(string)
+	kc := ParseKubeConfig(secret)
+	if kc == nil || kc.Host != "https://10.0.0.10:6443" || kc.ClientKey != redacted {
+		t.Errorf("replayed kubeconfig = %+v, want host kept and client key redacted", kc)
+	}
+	if kc != nil && kc.ClientCertificate != "Q0xJRU5UX0NFUlQ=" {
+		t.Errorf("ClientCertificate = %q, want the non-secret certificate kept", kc.ClientCertificate)
+	}
+}
+
+// newProcess forgets the cassettes this test process has open, as a new provider
+// process would start without them.
+func newProcess(t *testing.T) {
+	t.Helper()
+	cassettes.mu.Lock()
+	defer cassettes.mu.Unlock()
+	clear(cassettes.m)
+}
+
+// TestCassette_RecordAcrossProcesses: Terraform runs a command's plan and apply
+// in separate provider processes. Both record into one cassette, and each
+// process's requests then replay from it.
+func TestCassette_RecordAcrossProcesses(t *testing.T) {
+	var status atomic.Value
+	status.Store("RUNNING")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/panel-main/api/v2/vms/7":
+			s := status.Load().(string)
+			if s == "STOPPING" {
+				status.Store("STOPPED")
+			}
+			_, _ = w.Write([]byte(`{"success":true,"data":{"id":7,"status":"` + s + `"}}`))
+		case "/panel-main/api/v2/vms/7/stop":
+			status.Store("STOPPING")
+			_, _ = w.Write([]byte(`{"success":true,"data":null}`))
+		case "/panel-main/api/v2/networks":
+			_, _ = w.Write([]byte(`{"success":true,"data":[{"id":3}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	vmStatus := func(c *Client) string {
+		t.Helper()
+		var vm struct{ Status string }
+		if err := c.Do(ctx, http.MethodGet, "/api/v2/vms/7", nil, &vm, nil); err != nil {
+			t.Fatalf("get vm: %v", err)
+		}
+		return vm.Status
+	}
+	plan := func(c *Client) string {
+		t.Helper()
+		var networks []map[string]any
+		if err := c.Do(ctx, http.MethodGet, "/api/v2/networks", nil, &networks, nil); err != nil {
+			t.Fatalf("networks: %v", err)
+		}
+		return vmStatus(c)
+	}
+	apply := func(c *Client) string {
+		t.Helper()
+		statuses := []string{vmStatus(c)}
+		if err := c.Do(ctx, http.MethodPost, "/api/v2/vms/7/stop", nil, nil, nil); err != nil {
+			t.Fatalf("stop: %v", err)
+		}
+		return strings.Join(append(statuses, vmStatus(c), vmStatus(c)), ",")
+	}
+
+	path := filepath.Join(t.TempDir(), "run.json")
+	open := func(mode, baseURL string) *Client {
+		t.Helper()
+		newProcess(t)
+		c, err := New(Config{APIBaseURL: baseURL, APIKeyID: "k", APISecretKey: "s", CassetteMode: mode, CassettePath: path})
+		if err != nil {
+			t.Fatalf("New(%s): %v", mode, err)
+		}
+		return c
+	}
+	if got := plan(open(CassetteRecord, srv.URL)); got != "RUNNING" {
+		t.Fatalf("recorded plan = %s", got)
+	}
+	if got := apply(open(CassetteRecord, srv.URL)); got != "RUNNING,STOPPING,STOPPED" {
+		t.Fatalf("recorded apply = %s", got)
+	}
+
+	if got := plan(open(CassetteReplay, "https://replay.invalid")); got != "RUNNING" {
+		t.Errorf("replayed plan = %s, want RUNNING", got)
+	}
+	if got := apply(open(CassetteReplay, "https://replay.invalid")); got != "RUNNING,STOPPING,STOPPED" {
+		t.Errorf("replayed apply = %s, want RUNNING,STOPPING,STOPPED", got)
+	}
+
+	open(CassetteRecordNew, srv.URL)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil || len(file.Interactions) != 0 {
+		t.Errorf("record_new left %d interactions (err %v), want an empty cassette", len(file.Interactions), err)
+	}
+}
+
+// TestCassette_ReplayMiss: a request the cassette does not hold fails instead of
+// reaching the network.
+func TestCassette_ReplayMiss(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+	if err := os.WriteFile(path, []byte(`{"version":1,"interactions":[]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := New(Config{APIBaseURL: "https://replay.invalid", APIKeyID: "k", APISecretKey: "s", CassetteMode: CassetteReplay, CassettePath: path})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	err = c.Do(context.Background(), http.MethodPost, "/api/v2/vms", map[string]any{"name": "web"}, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction for POST /panel-main/api/v2/vms") {
+		t.Fatalf("err = %v, want a cassette miss", err)
+	}
+}
+
+func TestNew_CassetteConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]Config{
+		"unknown mode": {CassetteMode: "rewind", CassettePath: filepath.Join(dir, "a.json")},
+		"missing path": {CassetteMode: CassetteRecord},
+		"missing file": {CassetteMode: CassetteReplay, CassettePath: filepath.Join(dir, "nope.json")},
+	}
+	for name, cfg := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg.APIBaseURL, cfg.APIKeyID, cfg.APISecretKey = "https://example.test", "k", "s"
+			if _, err := New(cfg); err == nil {
+				t.Fatal("New succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestRedactJSON_LeavesCleanBodiesUntouched(t *testing.T) {
+	body := []byte(`{"b": 1,  "a": [1.50, "x"]}`)
+	if got := redactJSON(body); string(got) != string(body) {
+		t.Errorf("redactJSON rewrote a body with no secret: %s", got)
+	}
+	var v map[string]any
+	if err := json.Unmarshal(redactJSON([]byte(`{"items":[{"password":"p"}]}`)), &v); err != nil {
+		t.Fatal(err)
+	}
+	items, _ := v["items"].([]any)
+	item, _ := items[0].(map[string]any)
+	if item["password"] != redacted {
+		t.Errorf("nested password not redacted: %v", v)
+	}
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// breakerThreshold is how many consecutive failed requests, across every
+	// goroutine sharing the client, open the circuit breaker.
+	breakerThreshold = 10
+	// breakerCooldown is how long the breaker stays open before it lets one
+	// probe request through to test whether the panel has recovered.
+	breakerCooldown = 30 * time.Second
+)
+
+// ErrPanelUnavailable matches, with errors.Is, the error of a request the
+// circuit breaker refused to send.
+var ErrPanelUnavailable = errors.New("panel unavailable")
+
+// PanelUnavailableError is returned without contacting the panel while the
+// circuit breaker is open.
+type PanelUnavailableError struct {
+	// Failures is the number of consecutive failed requests so far.
+	Failures int
+	// Since is when the breaker opened.
+	Since time.Time
+	// LastErr is the failure that last kept the breaker open.
+	LastErr error
+}
+
+func (e *PanelUnavailableError) Error() string {
+	return fmt.Sprintf("panel unavailable: %d consecutive requests failed since %s (last: %v); not contacting the panel until a probe request succeeds",
+		e.Failures, e.Since.Format(time.TimeOnly), e.LastErr)
+}
+
+func (e *PanelUnavailableError) Is(target error) bool { return target == ErrPanelUnavailable }
+
+// circuitBreaker stops a client from sending requests to a panel that keeps
+// failing, so that parallel resources fail fast instead of each exhausting its
+// own retry budget. It is shared by every request of a client.
+//
+// Closed, requests flow and failures are counted; a success resets the count.
+// After threshold consecutive failures it opens and refuses every request with
+// a *PanelUnavailableError. Once cooldown has passed it is half-open: one probe
+// request goes through, and its outcome closes the breaker or re-opens it for
+// another cooldown. A probe that never reports back (its context was cancelled)
+// is replaced by another after cooldown.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	lastErr  error
+	openedAt time.Time // zero while closed
+	probeAt  time.Time // when the last half-open probe was let through
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold < 1 {
+		threshold = breakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = breakerCooldown
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a request may be sent. It returns nil while closed,
+// and for the probe of a half-open breaker.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openedAt.IsZero() {
+		return nil
+	}
+	now := b.now()
+	if now.Sub(b.openedAt) >= b.cooldown && now.Sub(b.probeAt) >= b.cooldown {
+		b.probeAt = now
+		return nil
+	}
+	return &PanelUnavailableError{Failures: b.failures, Since: b.openedAt, LastErr: b.lastErr}
+}
+
+// succeeded records a request the panel answered. It reports whether this
+// closed an open breaker.
+func (b *circuitBreaker) succeeded() (closed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	closed = !b.openedAt.IsZero()
+	b.failures, b.lastErr = 0, nil
+	b.openedAt, b.probeAt = time.Time{}, time.Time{}
+	return closed
+}
+
+// failed records a request that failed with err. It reports whether this
+// opened the breaker. A failure while open, such as a failed probe, restarts
+// the cooldown.
+func (b *circuitBreaker) failed(err error) (opened bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	b.lastErr = err
+	if !b.openedAt.IsZero() {
+		b.openedAt = b.now()
+		return false
+	}
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+		return true
+	}
+	return false
+}
+
+// isPanelFailure reports whether an HTTP response counts against the breaker:
+// a 5xx without a JSON body, such as a gateway's error page. A JSON error, such
+// as 744 (no compute capacity), shows the panel itself is up and answering.
+func isPanelFailure(statusCode int, respBody []byte) bool {
+	return statusCode >= 500 && !json.Valid(respBody)
+}
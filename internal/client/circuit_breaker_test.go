@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker_States(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newCircuitBreaker(3, time.Minute)
+	b.now = func() time.Time { return now }
+	boom := errors.New("connection refused")
+
+	for i := range 2 {
+		if b.failed(boom) {
+			t.Fatalf("breaker opened after %d failures, want 3", i+1)
+		}
+	}
+	if b.succeeded() {
+		t.Error("succeeded() reported closing a breaker that was not open")
+	}
+	for range 2 {
+		b.failed(boom)
+	}
+	if err := b.allow(); err != nil {
+		t.Fatalf("a success should have reset the count; allow() = %v", err)
+	}
+	if !b.failed(boom) {
+		t.Fatal("third consecutive failure should open the breaker")
+	}
+
+	var unavailable *PanelUnavailableError
+	if err := b.allow(); !errors.As(err, &unavailable) || unavailable.Failures != 3 || !errors.Is(err, ErrPanelUnavailable) {
+		t.Fatalf("open breaker: allow() = %v, want a PanelUnavailableError after 3 failures", err)
+	}
+
+	// After the cooldown one probe goes through; others still fail fast.
+	now = now.Add(time.Minute)
+	if err := b.allow(); err != nil {
+		t.Fatalf("half-open: probe refused: %v", err)
+	}
+	if err := b.allow(); err == nil {
+		t.Fatal("half-open: a second request went through alongside the probe")
+	}
+	// A failed probe re-opens for another cooldown.
+	b.failed(boom)
+	now = now.Add(30 * time.Second)
+	if err := b.allow(); err == nil {
+		t.Fatal("a failed probe should restart the cooldown")
+	}
+	now = now.Add(30 * time.Second)
+	if err := b.allow(); err != nil {
+		t.Fatalf("second probe refused: %v", err)
+	}
+	if !b.succeeded() {
+		t.Error("a successful probe should close the breaker")
+	}
+	if err := b.allow(); err != nil {
+		t.Errorf("closed breaker refused a request: %v", err)
+	}
+}
+
+func TestIsPanelFailure(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		want   bool
+	}{
+		{502, "<html>Bad Gateway</html>", true},
+		{503, "", true},
+		{503, `{"success":false,"errors":[{"code":744,"message":"no capacity"}]}`, false},
+		{500, `{"success":false,"errors":[{"message":"Cluster with this name already exist"}]}`, false},
+		{429, "error code: 1015", false},
+		{404, "not found", false},
+	}
+	for _, tc := range cases {
+		if got := isPanelFailure(tc.status, []byte(tc.body)); got != tc.want {
+			t.Errorf("isPanelFailure(%d, %q) = %v, want %v", tc.status, tc.body, got, tc.want)
+		}
+	}
+}
+
+// TestDo_CircuitBreakerFailsFast: once the panel has failed enough requests in
+// a row, further requests fail without reaching it, and a probe after the
+// cooldown closes the breaker again when the panel has recovered.
+func TestDo_CircuitBreakerFailsFast(t *testing.T) {
+	var calls atomic.Int32
+	var down atomic.Bool
+	down.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if down.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("<html>502 Bad Gateway</html>"))
+			return
+		}
+		_, _ = w.Write([]byte(okBody))
+	}))
+	defer srv.Close()
+
+	c, err := New(Config{
+		APIBaseURL: srv.URL, APIKeyID: "k", APISecretKey: "s",
+		Retry: RetryPolicy{BreakerThreshold: 3, BreakerCooldown: 50 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for range 3 {
+		if err := c.Do(ctx, http.MethodPost, "/x", nil, nil, nil); err == nil || errors.Is(err, ErrPanelUnavailable) {
+			t.Fatalf("request while closed: err = %v, want the panel's 502", err)
+		}
+	}
+	err = c.Do(ctx, http.MethodGet, "/x", nil, nil, nil)
+	if !errors.Is(err, ErrPanelUnavailable) {
+		t.Fatalf("request while open: err = %v, want ErrPanelUnavailable", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("panel saw %d requests, want 3 (none while open)", n)
+	}
+	if detail := ErrorDetail(err); !strings.Contains(detail, "panel is unavailable") || !strings.Contains(detail, "Details: panel unavailable") {
+		t.Errorf("ErrorDetail = %q, want the panel-unavailable description", detail)
+	}
+
+	down.Store(false)
+	time.Sleep(60 * time.Millisecond)
+	if err := c.Do(ctx, http.MethodGet, "/x", nil, nil, nil); err != nil {
+		t.Fatalf("probe after cooldown: %v", err)
+	}
+	if err := c.Do(ctx, http.MethodGet, "/x", nil, nil, nil); err != nil {
+		t.Fatalf("request after a successful probe: %v", err)
+	}
+}
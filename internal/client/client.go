@@ -12,9 +12,17 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"terraform-provider-prodata/internal/telemetry"
+
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -34,6 +42,18 @@ const (
 	transportRetryBase = 500 * time.Millisecond
 )
 
+// Attribute keys of the request, attempt and backoff spans.
+const (
+	attrRegion        = attribute.Key("prodata.region")
+	attrProjectTag    = attribute.Key("prodata.project_tag")
+	attrAttempts      = attribute.Key("prodata.api.attempts")
+	attrAttempt       = attribute.Key("prodata.api.attempt")
+	attrRateLimitWait = attribute.Key("prodata.api.rate_limit_wait_ms")
+	attrSlotWait      = attribute.Key("prodata.api.concurrency_wait_ms")
+	attrBackoffReason = attribute.Key("prodata.backoff.reason")
+	attrBackoffDelay  = attribute.Key("prodata.backoff.delay_ms")
+)
+
 // isIdempotentMethod reports whether a request can be safely retried after a transport
 // error without risk of double-applying a mutation.
 func isIdempotentMethod(method string) bool {
@@ -47,8 +67,30 @@ type Client struct {
 	userAgent    string
 	Region       string
 	ProjectTag   string
-	httpClient   *http.Client
-	limiter      *rateLimiter // nil = no client-side rate limiting
+	// Retry and DefaultTimeouts carry the provider's retry and default_timeouts
+	// blocks to the resources.
+	Retry           RetryPolicy
+	DefaultTimeouts Timeouts
+	httpClient      *http.Client
+	limiter         *rateLimiter
+	breaker         *circuitBreaker
+	slots           *requestSlots
+
+	// credentialSource, when set, supplies apiKeyID/apiSecretKey and refreshes them
+	// before credsExpiration. credsMu guards the three credential fields.
+	credentialSource CredentialSource
+	credsMu          sync.Mutex
+	credsExpiration  time.Time
+
+	readCache *readCache // coalesces list GETs; nil disables it
+
+	// idempotencyHonoured is set once the panel echoes an Idempotency-Key, or
+	// reports FeatureIdempotencyKeys, after which mutating requests are retried
+	// on transport errors too.
+	idempotencyHonoured atomic.Bool
+
+	// capabilities is set by NegotiateCapabilities; nil while unknown.
+	capabilities atomic.Pointer[Capabilities]
 }
 
 type Config struct {
@@ -58,20 +100,63 @@ type Config struct {
 	UserAgent    string
 	Region       string
 	ProjectTag   string
-	// MaxRPS, when > 0, paces outbound requests to at most this many per second to
-	// pre-empt server-side 429s on bulk applies. 0 (the default) disables pacing and
-	// relies solely on the reactive 429 backoff. Sourced from PRODATA_MAX_RPS.
+	// MaxRPS, when > 0, caps outbound requests at this many per second to pre-empt
+	// server-side 429s on bulk applies. 0 (the default) sets no ceiling. Either way
+	// the rate adapts downward on 429s and recovers afterward (see rateLimiter).
 	MaxRPS float64
+	// RequestBurst is how many requests may leave back to back before pacing at
+	// the current rate applies. Values below 1 mean 1 (strict pacing).
+	RequestBurst int
+	// MaxConcurrentWrites and MaxConcurrentReads, when > 0, cap how many
+	// mutating and read (GET/HEAD) requests are in flight at once. 0 (the
+	// default) sets no cap. Independent of MaxRPS, which paces how often
+	// requests leave rather than how many are outstanding.
+	MaxConcurrentWrites int
+	MaxConcurrentReads  int
+	// CassetteMode, when set to CassetteRecord, CassetteRecordNew or
+	// CassetteReplay, installs the HTTP record/replay layer beneath doRequest,
+	// reading or writing CassettePath. Empty (the default) talks to the panel
+	// directly. Sourced from PRODATA_CASSETTE_MODE and PRODATA_CASSETTE.
+	CassetteMode string
+	CassettePath string
+	// CredentialSource, when set, supplies the API key pair instead of APIKeyID and
+	// APISecretKey (e.g. a credential_process), and is re-invoked on expiry.
+	CredentialSource CredentialSource
+	// Transport holds the proxy, CA bundle, client certificate and minimum TLS
+	// version. The zero value talks to the panel like a plain http.Client.
+	Transport TransportConfig
+	// Retry tunes request retries, the 503 retry budget and polling error
+	// tolerance. The zero value keeps the built-in defaults.
+	Retry RetryPolicy
+	// DefaultTimeouts are the provider-wide operation timeouts handed to the
+	// resources. The zero value keeps each resource's own defaults.
+	DefaultTimeouts Timeouts
 }
 
 func New(cfg Config) (*Client, error) {
-	if cfg.APIBaseURL == "" || cfg.APIKeyID == "" || cfg.APISecretKey == "" {
+	if cfg.APIBaseURL == "" {
+		return nil, fmt.Errorf("api_base_url, api_key_id, and api_secret_key are required")
+	}
+	if cfg.CredentialSource == nil && (cfg.APIKeyID == "" || cfg.APISecretKey == "") {
 		return nil, fmt.Errorf("api_base_url, api_key_id, and api_secret_key are required")
 	}
 
-	var limiter *rateLimiter
-	if cfg.MaxRPS > 0 {
-		limiter = &rateLimiter{interval: time.Duration(float64(time.Second) / cfg.MaxRPS)}
+	// No client-level timeout on purpose: every request is issued with the caller's
+	// context (http.NewRequestWithContext in doRequest), which already carries the
+	// resource's configurable Create/Update/Delete timeout. A fixed 60s cap here would
+	// override those and abort slow synchronous creates (VM, LB, Kubernetes) regardless
+	// of the `timeouts` block, surfacing as a confusing transport error.
+	transport, err := newTransport(cfg.Transport)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := &http.Client{Transport: transport}
+	if cfg.CassetteMode != "" {
+		rt, err := newCassetteTransport(cfg.CassetteMode, cfg.CassettePath, transport)
+		if err != nil {
+			return nil, err
+		}
+		httpClient.Transport = rt
 	}
 
 	return &Client{
@@ -81,46 +166,17 @@ func New(cfg Config) (*Client, error) {
 		userAgent:    cfg.UserAgent,
 		Region:       cfg.Region,
 		ProjectTag:   cfg.ProjectTag,
-		// No client-level timeout on purpose: every request is issued with the caller's
-		// context (http.NewRequestWithContext in doRequest), which already carries the
-		// resource's configurable Create/Update/Delete timeout. A fixed 60s cap here would
-		// override those and abort slow synchronous creates (VM, LB, Kubernetes) regardless
-		// of the `timeouts` block, surfacing as a confusing transport error.
-		httpClient: &http.Client{},
-		limiter:    limiter,
-	}, nil
-}
+		httpClient:   httpClient,
+		limiter:      newRateLimiter(cfg.MaxRPS, cfg.RequestBurst),
+		breaker:      newCircuitBreaker(cfg.Retry.BreakerThreshold, cfg.Retry.BreakerCooldown),
+		slots:        newRequestSlots(cfg.MaxConcurrentWrites, cfg.MaxConcurrentReads),
 
-// rateLimiter paces requests to roughly one per `interval`, serialized across goroutines.
-// It is intentionally minimal (strict pacing, no burst) — a conservative cap that smooths
-// bulk-apply bursts to avoid self-inflicted 429s, without adding a dependency.
-type rateLimiter struct {
-	mu       sync.Mutex
-	interval time.Duration
-	next     time.Time
-}
+		Retry:           cfg.Retry,
+		DefaultTimeouts: cfg.DefaultTimeouts,
 
-// wait blocks until this request's pacing slot, or until ctx is cancelled.
-func (l *rateLimiter) wait(ctx context.Context) error {
-	l.mu.Lock()
-	now := time.Now()
-	if l.next.Before(now) {
-		l.next = now
-	}
-	slot := l.next
-	l.next = l.next.Add(l.interval)
-	l.mu.Unlock()
-
-	d := time.Until(slot)
-	if d <= 0 {
-		return nil
-	}
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case <-time.After(d):
-		return nil
-	}
+		credentialSource: cfg.CredentialSource,
+		readCache:        newReadCache(listCacheTTL),
+	}, nil
 }
 
 type apiResponse[T any] struct {
@@ -145,11 +201,47 @@ type RequestOpts struct {
 	Lang string
 }
 
+// scope resolves the region, project and language of a request: per-request opts
+// when provided, else the client defaults.
+func (c *Client) scope(opts *RequestOpts) (region, projectTag, lang string) {
+	region, projectTag = c.Region, c.ProjectTag
+	if opts != nil {
+		if opts.Region != "" {
+			region = opts.Region
+		}
+		if opts.ProjectTag != "" {
+			projectTag = opts.ProjectTag
+		}
+		lang = opts.Lang
+	}
+	return region, projectTag, lang
+}
+
 // doRequest performs an HTTP request with the standard auth + region/project headers
 // and transparent HTTP 429 retry, returning the raw status code and response body.
 // It is the shared transport for both the V2 envelope path (Do) and the V1 envelope
 // path (legacy load-balancer endpoints, parsed via parseV1Response).
 func (c *Client) doRequest(ctx context.Context, method, path string, body any, opts *RequestOpts) (int, []byte, error) {
+	region, projectTag, _ := c.scope(opts)
+	ctx, span := telemetry.Tracer().Start(ctx, "prodata.api "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(method),
+			semconv.URLPath(strings.SplitN(path, "?", 2)[0]),
+			attrRegion.String(region),
+			attrProjectTag.String(projectTag),
+		))
+	statusCode, respBody, err := c.sendWithRetries(ctx, method, path, body, opts)
+	if statusCode != 0 {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+	}
+	telemetry.End(span, err)
+	return statusCode, respBody, err
+}
+
+// sendWithRetries is doRequest's retry loop. Each attempt, and each backoff
+// between attempts, is a child span of the request's span in ctx.
+func (c *Client) sendWithRetries(ctx context.Context, method, path string, body any, opts *RequestOpts) (int, []byte, error) {
 	var bodyBytes []byte
 	if body != nil {
 		b, err := json.Marshal(body)
@@ -160,21 +252,22 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, o
 	}
 
 	fullURL := c.baseURL + path
+	region, projectTag, lang := c.scope(opts)
+	ctx = withHTTPLog(ctx)
 
-	// Determine region and project: use per-request opts if provided, else client defaults.
-	region := c.Region
-	projectTag := c.ProjectTag
-	if opts != nil {
-		if opts.Region != "" {
-			region = opts.Region
-		}
-		if opts.ProjectTag != "" {
-			projectTag = opts.ProjectTag
-		}
+	// A mutation can change any list: drop cached lists both before it (reads that
+	// start now must not be served the old list) and after it (a list fetched while
+	// it was in flight may predate it).
+	if c.readCache != nil && invalidatesReadCache(method) {
+		c.readCache.invalidate()
+		defer c.readCache.invalidate()
 	}
-	lang := ""
-	if opts != nil {
-		lang = opts.Lang
+
+	// One key per logical mutating request, shared by all of its attempts, so a
+	// panel that honours it can tell a re-send from a new request.
+	var idempotencyKey string
+	if !isIdempotentMethod(method) {
+		idempotencyKey = newIdempotencyKey()
 	}
 
 	// Edge rate limiting (HTTP 429 — e.g. Cloudflare error 1015) rejects the
@@ -182,7 +275,17 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, o
 	// no work was performed. Bulk applies (many parallel resources) trip per-IP
 	// rate limits; retry transparently with backoff instead of failing the apply.
 	transportRetries := 0
+	retrying429 := false
 	for attempt := 0; ; attempt++ {
+		// While the panel keeps failing, fail fast rather than add to the pile.
+		if err := c.breaker.allow(); err != nil {
+			return 0, nil, err
+		}
+		apiKeyID, apiSecretKey, err := c.credentials(ctx)
+		if err != nil {
+			return 0, nil, err
+		}
+
 		var reqBody io.Reader
 		if bodyBytes != nil {
 			reqBody = bytes.NewReader(bodyBytes)
@@ -194,40 +297,68 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, o
 
 		req.Header.Set("Content-Type", "application/json")
 		req.Header.Set("User-Agent", c.userAgent)
-		req.Header.Set("X-API-KEY", c.apiKeyID)
-		req.Header.Set("X-API-SECRET", c.apiSecretKey)
+		req.Header.Set("X-API-KEY", apiKeyID)
+		req.Header.Set("X-API-SECRET", apiSecretKey)
 		req.Header.Set("X-Region", region)
 		req.Header.Set("X-Project-Tag", projectTag)
 		if lang != "" {
 			req.Header.Set("X-Lang", lang)
 		}
+		if idempotencyKey != "" {
+			setIdempotencyKey(req.Header, idempotencyKey, c.idempotencyHonoured.Load())
+		}
 
-		if c.limiter != nil {
-			if werr := c.limiter.wait(ctx); werr != nil {
+		attemptCtx, attemptSpan := telemetry.Tracer().Start(ctx, "prodata.api.attempt",
+			trace.WithAttributes(attrAttempt.Int(attempt+1)))
+		trace.SpanFromContext(ctx).SetAttributes(attrAttempts.Int(attempt + 1))
+		if !retrying429 {
+			waitStart := time.Now()
+			if werr := c.limiter.wait(attemptCtx); werr != nil {
+				telemetry.End(attemptSpan, werr)
 				return 0, nil, werr
 			}
+			attemptSpan.SetAttributes(attrRateLimitWait.Int64(time.Since(waitStart).Milliseconds()))
 		}
+		retrying429 = false
+		slotStart := time.Now()
+		release, serr := c.slots.acquire(attemptCtx, method)
+		if serr != nil {
+			telemetry.End(attemptSpan, serr)
+			return 0, nil, serr
+		}
+		attemptSpan.SetAttributes(attrSlotWait.Int64(time.Since(slotStart).Milliseconds()))
+		otel.GetTextMapPropagator().Inject(attemptCtx, propagation.HeaderCarrier(req.Header))
 
+		start := time.Now()
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
+			release()
+			telemetry.End(attemptSpan, err)
+			logHTTPAttempt(ctx, httpAttempt{
+				method: method, path: path, attempt: attempt + 1, duration: time.Since(start),
+				reqHeader: req.Header, reqBody: bodyBytes, err: err,
+			})
+			if ctx.Err() == nil && !isTLSSetupError(err) {
+				c.recordOutcome(ctx, err)
+			}
 			// Retry idempotent (GET/HEAD) requests on a transient transport error so a
 			// momentary network blip during a refresh doesn't abort the whole plan.
-			// Non-idempotent methods are never retried here: the request may already have
-			// reached the server, and re-sending could double-apply. ctx is honored.
-			if isIdempotentMethod(method) && transportRetries < transportMaxRetries && ctx.Err() == nil {
-				wait := transportRetryBase << transportRetries
+			// Mutating methods are retried only once the panel has shown it honours
+			// idempotency keys: the request may already have reached the server, and
+			// without the key re-sending could double-apply. A failed TLS handshake
+			// (untrusted CA, missing client certificate) is never retried. ctx is honored.
+			if c.canRetryTransportError(method, idempotencyKey) && !isTLSSetupError(err) && transportRetries < c.Retry.transportRetries() && ctx.Err() == nil {
+				wait := c.Retry.transportBackoff(transportRetries)
 				transportRetries++
-				tflog.Warn(ctx, "transient transport error — retrying idempotent request", map[string]any{
+				tflog.Warn(ctx, "transient transport error — retrying request", map[string]any{
 					"method":      method,
 					"path":        path,
 					"attempt":     transportRetries,
-					"max_retries": transportMaxRetries,
+					"max_retries": c.Retry.transportRetries(),
 					"error":       err.Error(),
 				})
-				select {
-				case <-ctx.Done():
-					return 0, nil, ctx.Err()
-				case <-time.After(wait):
+				if berr := backoff(ctx, "transport_error", wait); berr != nil {
+					return 0, nil, berr
 				}
 				continue
 			}
@@ -238,24 +369,46 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, o
 		respHeader := resp.Header
 		statusCode := resp.StatusCode
 		resp.Body.Close()
+		release()
+		attemptSpan.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+		telemetry.End(attemptSpan, readErr)
+		logHTTPAttempt(ctx, httpAttempt{
+			method: method, path: path, attempt: attempt + 1, duration: time.Since(start),
+			reqHeader: req.Header, reqBody: bodyBytes,
+			status: statusCode, respHeader: respHeader, respBody: respBody, err: readErr,
+		})
 		if readErr != nil {
 			return 0, nil, fmt.Errorf("read response: %w", readErr)
 		}
 
-		if statusCode == http.StatusTooManyRequests && attempt < rateLimitMaxRetries {
-			wait := retryAfterDelay(respHeader, attempt)
+		c.noteIdempotencySupport(idempotencyKey, respHeader)
+		if isPanelFailure(statusCode, respBody) {
+			c.recordOutcome(ctx, fmt.Errorf("HTTP %d %s", statusCode, http.StatusText(statusCode)))
+		} else {
+			c.recordOutcome(ctx, nil)
+		}
+
+		if statusCode != http.StatusTooManyRequests {
+			c.limiter.succeeded()
+		} else {
+			retryAfter, _ := retryAfterHeader(respHeader)
+			c.limiter.throttled(retryAfter)
+		}
+
+		if statusCode == http.StatusTooManyRequests && attempt < c.Retry.rateLimitRetries() {
+			wait := c.Retry.retryAfterDelay(respHeader, attempt)
 			tflog.Warn(ctx, "rate limited (HTTP 429) — backing off before retry", map[string]any{
 				"method":      method,
 				"path":        path,
 				"attempt":     attempt + 1,
-				"max_retries": rateLimitMaxRetries,
+				"max_retries": c.Retry.rateLimitRetries(),
 				"retry_in":    wait.String(),
+				"rate_limit":  c.limiter.currentRate(),
 			})
-			select {
-			case <-ctx.Done():
-				return 0, nil, ctx.Err()
-			case <-time.After(wait):
+			if berr := backoff(ctx, "rate_limited", wait); berr != nil {
+				return 0, nil, berr
 			}
+			retrying429 = true
 			continue
 		}
 
@@ -263,6 +416,39 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any, o
 	}
 }
 
+// recordOutcome feeds one attempt's outcome to the circuit breaker: failure is
+// nil when the panel answered. It logs the breaker opening and closing.
+func (c *Client) recordOutcome(ctx context.Context, failure error) {
+	if failure == nil {
+		if c.breaker.succeeded() {
+			tflog.Info(ctx, "panel is answering again — circuit breaker closed")
+		}
+		return
+	}
+	if c.breaker.failed(failure) {
+		tflog.Warn(ctx, "panel keeps failing — circuit breaker open, failing requests fast", map[string]any{
+			"consecutive_failures": c.breaker.threshold,
+			"probe_in":             c.breaker.cooldown.String(),
+			"error":                failure.Error(),
+		})
+	}
+}
+
+// backoff sleeps for d before a retry, or until ctx is cancelled. The wait is
+// traced as its own span so that time spent backing off shows up in a trace.
+func backoff(ctx context.Context, reason string, d time.Duration) error {
+	_, span := telemetry.Tracer().Start(ctx, "prodata.api.backoff",
+		trace.WithAttributes(attrBackoffReason.String(reason), attrBackoffDelay.Int64(d.Milliseconds())))
+	select {
+	case <-ctx.Done():
+		telemetry.End(span, ctx.Err())
+		return ctx.Err()
+	case <-time.After(d):
+		span.End()
+		return nil
+	}
+}
+
 // Do issues a request to a V2 API endpoint and decodes the {success,data,errors}
 // envelope into result.
 func (c *Client) Do(ctx context.Context, method, path string, body, result any, opts *RequestOpts) error {
@@ -326,46 +512,53 @@ func parseResponse(statusCode int, respBody []byte, result any) error {
 
 // retryAfterDelay computes how long to wait before retrying an HTTP 429 response.
 // It honors the Retry-After header (delay-seconds or HTTP-date form) when present,
-// otherwise falls back to exponential backoff (2s, 4s, 8s, ...). The result is
-// jittered (±25%) to avoid a thundering herd when many parallel resources are
-// rate-limited at once, and each wait is capped at rateLimitMaxDelay.
-func retryAfterDelay(h http.Header, attempt int) time.Duration {
-	delay := time.Duration(0)
-	fromHeader := false
-	if ra := strings.TrimSpace(h.Get("Retry-After")); ra != "" {
-		if secs, err := strconv.Atoi(ra); err == nil && secs >= 0 {
-			delay, fromHeader = time.Duration(secs)*time.Second, true
-		} else if t, err := http.ParseTime(ra); err == nil {
-			if d := time.Until(t); d > 0 {
-				delay = d
-			}
-			fromHeader = true
-		}
-	}
+// otherwise falls back to exponential backoff (2s, 4s, 8s, ... by default). The
+// result is jittered (±25%) to avoid a thundering herd when many parallel
+// resources are rate-limited at once, and each wait is capped at the policy's
+// ceiling (rateLimitMaxDelay by default).
+func (p RetryPolicy) retryAfterDelay(h http.Header, attempt int) time.Duration {
+	base, ceiling := p.rateLimitBackoff()
+	delay, fromHeader := retryAfterHeader(h)
 	if !fromHeader {
-		// Exponential backoff: rateLimitBaseDelay doubles each attempt.
-		delay = rateLimitBaseDelay << attempt
+		// Exponential backoff: base doubles each attempt.
+		delay = base << attempt
 		if delay <= 0 { // guard against shift overflow
-			delay = rateLimitMaxDelay
+			delay = ceiling
 		}
 	}
-	if delay > rateLimitMaxDelay {
-		delay = rateLimitMaxDelay
+	if delay > ceiling {
+		delay = ceiling
 	}
 	if delay <= 0 {
 		return 0
 	}
-	// ±25% jitter, then clamp so rateLimitMaxDelay stays a hard ceiling.
+	// ±25% jitter, then clamp so the ceiling stays a hard one.
 	jitter := time.Duration(rand.Int63n(int64(delay)/2+1)) - delay/4
 	delay += jitter
 	if delay < 0 {
 		delay = 0
-	} else if delay > rateLimitMaxDelay {
-		delay = rateLimitMaxDelay
+	} else if delay > ceiling {
+		delay = ceiling
 	}
 	return delay
 }
 
+// retryAfterHeader parses a Retry-After header in delay-seconds or HTTP-date form.
+// It reports false when the header is absent or malformed.
+func retryAfterHeader(h http.Header) (time.Duration, bool) {
+	ra := strings.TrimSpace(h.Get("Retry-After"))
+	if ra == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(ra); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(ra); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
 type Image struct {
 	ID       int64  `json:"id"`
 	Name     string `json:"name"`
@@ -420,12 +613,16 @@ type Volume struct {
 	AttachedID *int64 `json:"attachedId"`
 }
 
+// GetVolumes lists the volumes in scope. Concurrent identical calls share one request
+// and the result is reused briefly (see readCache).
 func (c *Client) GetVolumes(ctx context.Context, opts *RequestOpts) ([]Volume, error) {
-	var volumes []Volume
-	if err := c.Do(ctx, http.MethodGet, "/api/v2/volumes", nil, &volumes, opts); err != nil {
-		return nil, err
-	}
-	return volumes, nil
+	return cachedList(ctx, c, "GetVolumes", opts, func(ctx context.Context) ([]Volume, error) {
+		var volumes []Volume
+		if err := c.Do(ctx, http.MethodGet, "/api/v2/volumes", nil, &volumes, opts); err != nil {
+			return nil, err
+		}
+		return volumes, nil
+	})
 }
 
 func (c *Client) GetVolume(ctx context.Context, id int64, opts *RequestOpts) (*Volume, error) {
@@ -531,12 +728,16 @@ type LocalNetwork struct {
 	Linked  bool   `json:"linked"`
 }
 
+// GetLocalNetworks lists the networks in scope. Concurrent identical calls share one request
+// and the result is reused briefly (see readCache).
 func (c *Client) GetLocalNetworks(ctx context.Context, opts *RequestOpts) ([]LocalNetwork, error) {
-	var networks []LocalNetwork
-	if err := c.Do(ctx, http.MethodGet, "/api/v2/local-networks", nil, &networks, opts); err != nil {
-		return nil, err
-	}
-	return networks, nil
+	return cachedList(ctx, c, "GetLocalNetworks", opts, func(ctx context.Context) ([]LocalNetwork, error) {
+		var networks []LocalNetwork
+		if err := c.Do(ctx, http.MethodGet, "/api/v2/local-networks", nil, &networks, opts); err != nil {
+			return nil, err
+		}
+		return networks, nil
+	})
 }
 
 type CreateLocalNetworkRequest struct {
@@ -636,12 +837,15 @@ type PublicIP struct {
 	Gateway string `json:"gateway"`
 }
 
+// GetPublicIPs lists the public IPs in scope, coalesced like GetVolumes.
 func (c *Client) GetPublicIPs(ctx context.Context, opts *RequestOpts) ([]PublicIP, error) {
-	var ips []PublicIP
-	if err := c.Do(ctx, http.MethodGet, "/api/v2/public-ips", nil, &ips, opts); err != nil {
-		return nil, err
-	}
-	return ips, nil
+	return cachedList(ctx, c, "GetPublicIPs", opts, func(ctx context.Context) ([]PublicIP, error) {
+		var ips []PublicIP
+		if err := c.Do(ctx, http.MethodGet, "/api/v2/public-ips", nil, &ips, opts); err != nil {
+			return nil, err
+		}
+		return ips, nil
+	})
 }
 
 func (c *Client) GetPublicIP(ctx context.Context, id int64, opts *RequestOpts) (*PublicIP, error) {
@@ -771,6 +975,7 @@ type CreateVmRequest struct {
 	UserData *string `json:"userData,omitempty"`
 }
 
+// GetVms lists the VMs in scope, coalesced like GetVolumes.
 func (c *Client) GetVms(ctx context.Context, opts *RequestOpts) ([]Vm, error) {
 	path := "/api/v2/vms"
 	params := url.Values{}
@@ -786,11 +991,13 @@ func (c *Client) GetVms(ctx context.Context, opts *RequestOpts) ([]Vm, error) {
 		path = path + "?" + params.Encode()
 	}
 
-	var vms []Vm
-	if err := c.Do(ctx, http.MethodGet, path, nil, &vms, opts); err != nil {
-		return nil, err
-	}
-	return vms, nil
+	return cachedList(ctx, c, "GetVms", opts, func(ctx context.Context) ([]Vm, error) {
+		var vms []Vm
+		if err := c.Do(ctx, http.MethodGet, path, nil, &vms, opts); err != nil {
+			return nil, err
+		}
+		return vms, nil
+	})
 }
 
 func (c *Client) GetVm(ctx context.Context, id int64, opts *RequestOpts) (*Vm, error) {
@@ -1084,39 +1291,52 @@ func (c *Client) StartVm(ctx context.Context, id int64, opts *RequestOpts) error
 	return nil
 }
 
-// WaitForVmStatus polls the VM until it reaches targetStatus or timeout.
-// Tolerates up to 3 consecutive transient errors during polling.
+// WaitForVmStatus polls the VM until it reaches targetStatus or timeout. A VM
+// in ERROR ends the wait early. Tolerates up to VmWaitMaxConsecutiveErrors
+// consecutive transient errors during polling, or the retry policy's tolerance.
 func (c *Client) WaitForVmStatus(ctx context.Context, vmID int64, targetStatus string, timeout time.Duration, opts *RequestOpts) error {
-	const (
-		pollInterval       = 5 * time.Second
-		maxConsecutiveErrs = 3
-	)
-
-	deadline := time.Now().Add(timeout)
-	consecutiveErrs := 0
-
-	for {
+	w := &Waiter[*Vm]{
+		Name:                 fmt.Sprintf("VM %d", vmID),
+		Op:                   "vm_status",
+		ID:                   vmID,
+		Refresh:              c.VmStatusRefresh(vmID, opts),
+		Target:               []string{targetStatus},
+		Failure:              []string{"ERROR"},
+		MaxConsecutiveErrors: c.Retry.PollErrorToleranceOr(VmWaitMaxConsecutiveErrors),
+		MinTimeout:           VmWaitMinTimeout,
+		MaxPollInterval:      VmWaitMaxPollInterval,
+		Timeout:              timeout,
+	}
+	_, err := w.Wait(ctx)
+	return err
+}
+
+// WaitForVmSettled waits until the VM leaves a transitional status such as
+// STARTING or STOPPING, returning it once it is RUNNING or STOPPED. ERROR fails
+// the wait, as in WaitForVmStatus.
+func (c *Client) WaitForVmSettled(ctx context.Context, vmID int64, timeout time.Duration, opts *RequestOpts) (*Vm, error) {
+	w := &Waiter[*Vm]{
+		Name:                 fmt.Sprintf("VM %d", vmID),
+		Op:                   "vm_settled",
+		ID:                   vmID,
+		Refresh:              c.VmStatusRefresh(vmID, opts),
+		Target:               []string{"RUNNING", "STOPPED"},
+		Failure:              []string{"ERROR"},
+		MaxConsecutiveErrors: c.Retry.PollErrorToleranceOr(VmWaitMaxConsecutiveErrors),
+		MinTimeout:           VmWaitMinTimeout,
+		MaxPollInterval:      VmWaitMaxPollInterval,
+		Timeout:              timeout,
+	}
+	return w.Wait(ctx)
+}
+
+// VmStatusRefresh returns a Waiter refresh function reading the VM's status.
+func (c *Client) VmStatusRefresh(vmID int64, opts *RequestOpts) func(context.Context) (*Vm, string, error) {
+	return func(ctx context.Context) (*Vm, string, error) {
 		vm, err := c.GetVmStatus(ctx, vmID, opts)
 		if err != nil {
-			consecutiveErrs++
-			if consecutiveErrs >= maxConsecutiveErrs {
-				return fmt.Errorf("polling VM %d: %w (after %d consecutive failures)", vmID, err, consecutiveErrs)
-			}
-		} else {
-			consecutiveErrs = 0
-			if vm.Status == targetStatus {
-				return nil
-			}
-		}
-
-		if time.Now().After(deadline) {
-			return fmt.Errorf("timed out waiting for VM %d to reach %s", vmID, targetStatus)
-		}
-
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(pollInterval):
+			return nil, "", err
 		}
+		return vm, vm.Status, nil
 	}
 }
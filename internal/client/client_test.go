@@ -414,7 +414,7 @@ func TestRetryAfterDelay(t *testing.T) {
 			if tt.header != "" {
 				h.Set("Retry-After", tt.header)
 			}
-			got := retryAfterDelay(h, tt.attempt)
+			got := RetryPolicy{}.retryAfterDelay(h, tt.attempt)
 			if got < tt.minDelay || got > tt.maxDelay {
 				t.Errorf("retryAfterDelay = %v, want [%v, %v]", got, tt.minDelay, tt.maxDelay)
 			}
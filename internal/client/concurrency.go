@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// requestSlots bounds how many requests a client has in flight at once, with
+// separate limits for mutating requests and reads, so a cap on simultaneous
+// creates does not slow the many cheap GETs of a refresh. Unlike the rate
+// limiter it bounds concurrency, not throughput: a synchronous create that
+// holds the panel for minutes keeps its slot for that long.
+type requestSlots struct {
+	writes *semaphore.Weighted // nil: unbounded
+	reads  *semaphore.Weighted // nil: unbounded
+}
+
+// newRequestSlots returns slots for at most writes mutating and reads read
+// requests in flight. Values below 1 mean no limit.
+func newRequestSlots(writes, reads int) *requestSlots {
+	s := &requestSlots{}
+	if writes > 0 {
+		s.writes = semaphore.NewWeighted(int64(writes))
+	}
+	if reads > 0 {
+		s.reads = semaphore.NewWeighted(int64(reads))
+	}
+	return s
+}
+
+// acquire blocks until a request with this method may be sent, or ctx is done.
+// The caller must call release once the response has been read.
+func (s *requestSlots) acquire(ctx context.Context, method string) (release func(), err error) {
+	sem := s.writes
+	if isIdempotentMethod(method) {
+		sem = s.reads
+	}
+	if sem == nil {
+		return func() {}, nil
+	}
+	if err := sem.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	return func() { sem.Release(1) }, nil
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestDo_ConcurrencyLimits: at most MaxConcurrentWrites POSTs reach the panel
+// at once, while GETs pass alongside them under their own limit.
+func TestDo_ConcurrencyLimits(t *testing.T) {
+	var inFlightPosts, maxPosts atomic.Int32
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			n := inFlightPosts.Add(1)
+			for {
+				m := maxPosts.Load()
+				if n <= m || maxPosts.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			<-unblock
+			inFlightPosts.Add(-1)
+		}
+		_, _ = w.Write([]byte(okBody))
+	}))
+	defer srv.Close()
+
+	c, err := New(Config{
+		APIBaseURL: srv.URL, APIKeyID: "k", APISecretKey: "s",
+		MaxConcurrentWrites: 2, MaxConcurrentReads: 1, RequestBurst: 100, MaxRPS: 1000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Go(func() {
+			if err := c.Do(ctx, http.MethodPost, "/x", nil, nil, nil); err != nil {
+				t.Errorf("POST: %v", err)
+			}
+		})
+	}
+	// Wait for the write slots to fill, then check a GET is not held up by them.
+	deadline := time.Now().Add(5 * time.Second)
+	for inFlightPosts.Load() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	getCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	if err := c.Do(getCtx, http.MethodGet, "/x", nil, nil, nil); err != nil {
+		t.Errorf("GET while the write slots are full: %v", err)
+	}
+	if n := inFlightPosts.Load(); n != 2 {
+		t.Errorf("%d POSTs in flight, want 2", n)
+	}
+
+	close(unblock)
+	wg.Wait()
+	if m := maxPosts.Load(); m != 2 {
+		t.Errorf("max concurrent POSTs = %d, want 2", m)
+	}
+}
+
+func TestRequestSlots_ContextCancelled(t *testing.T) {
+	s := newRequestSlots(1, 0)
+	release, err := s.acquire(context.Background(), http.MethodPost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.acquire(ctx, http.MethodDelete); err == nil {
+		t.Error("acquire with every write slot taken and a cancelled context should fail")
+	}
+	if _, err := s.acquire(ctx, http.MethodGet); err != nil {
+		t.Errorf("reads are unbounded; acquire = %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	// credentialRefreshWindow re-fetches credentials this long before they expire,
+	// so a request never leaves with a key that lapses in flight.
+	credentialRefreshWindow = time.Minute
+	// credentialProcessTimeout bounds one run of a credential_process command.
+	credentialProcessTimeout = time.Minute
+)
+
+// Credentials is an API key pair produced by a CredentialSource.
+type Credentials struct {
+	APIKeyID     string
+	APISecretKey string
+	// Expiration is when the pair stops being valid; zero means it does not expire.
+	Expiration time.Time
+}
+
+// CredentialSource produces API credentials on demand. The client calls it on first
+// use and again whenever the previous credentials are about to expire.
+type CredentialSource func(ctx context.Context) (Credentials, error)
+
+// credentialProcessOutput is the JSON a credential_process command prints on stdout.
+type credentialProcessOutput struct {
+	APIKeyID     string `json:"api_key_id"`
+	APISecretKey string `json:"api_secret_key"`
+	// Expiration is an RFC 3339 timestamp; omit it for credentials that do not expire.
+	Expiration string `json:"expiration,omitempty"`
+}
+
+// CredentialProcess returns a CredentialSource that runs command (through the
+// platform shell, like AWS credential_process) and reads the key pair from its
+// JSON output, so the secret never passes through Terraform configuration, state
+// or the environment. stdout is never included in errors; stderr is, to explain a
+// failure.
+func CredentialProcess(command string) CredentialSource {
+	return func(ctx context.Context) (Credentials, error) {
+		ctx, cancel := context.WithTimeout(ctx, credentialProcessTimeout)
+		defer cancel()
+
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd.exe", "/C", command)
+		} else {
+			cmd = exec.CommandContext(ctx, "/bin/sh", "-c", command)
+		}
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return Credentials{}, fmt.Errorf("credential_process failed: %w: %s", err, msg)
+			}
+			return Credentials{}, fmt.Errorf("credential_process failed: %w", err)
+		}
+
+		var out credentialProcessOutput
+		if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+			// The output may be a secret in the wrong shape: do not echo it.
+			return Credentials{}, fmt.Errorf("credential_process output is not valid JSON")
+		}
+		if out.APIKeyID == "" || out.APISecretKey == "" {
+			return Credentials{}, fmt.Errorf("credential_process output must set api_key_id and api_secret_key")
+		}
+		creds := Credentials{APIKeyID: out.APIKeyID, APISecretKey: out.APISecretKey}
+		if out.Expiration != "" {
+			exp, err := time.Parse(time.RFC3339, out.Expiration)
+			if err != nil {
+				return Credentials{}, fmt.Errorf("credential_process expiration %q is not RFC 3339: %w", out.Expiration, err)
+			}
+			creds.Expiration = exp
+		}
+		return creds, nil
+	}
+}
+
+// EnsureCredentials fetches credentials from the CredentialSource, if one is
+// configured and the current ones are missing or about to expire. The provider
+// calls it in Configure to surface a broken credential_process up front.
+func (c *Client) EnsureCredentials(ctx context.Context) error {
+	_, _, err := c.credentials(ctx)
+	return err
+}
+
+// credentials returns the key pair for the next request, refreshing it from the
+// CredentialSource when needed. Concurrent callers share one refresh.
+func (c *Client) credentials(ctx context.Context) (string, string, error) {
+	if c.credentialSource == nil {
+		return c.apiKeyID, c.apiSecretKey, nil
+	}
+	c.credsMu.Lock()
+	defer c.credsMu.Unlock()
+	fresh := c.apiKeyID != "" && (c.credsExpiration.IsZero() || time.Until(c.credsExpiration) > credentialRefreshWindow)
+	if !fresh {
+		creds, err := c.credentialSource(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		c.apiKeyID, c.apiSecretKey, c.credsExpiration = creds.APIKeyID, creds.APISecretKey, creds.Expiration
+	}
+	return c.apiKeyID, c.apiSecretKey, nil
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testCredentialProcess writes a script that prints a numbered key pair (key-1,
+// key-2, ...) expiring at exp, and returns the command that runs it.
+func testCredentialProcess(t *testing.T, exp string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("credential_process test script needs a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "creds.sh")
+	body := `n=$(cat "$0.count" 2>/dev/null || echo 0); n=$((n+1)); echo $n > "$0.count"
+printf '{"api_key_id":"key-%d","api_secret_key":"secret-%d"` + exp + `}' $n $n
+`
+	if err := os.WriteFile(script, []byte(body), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return "/bin/sh " + script
+}
+
+// keyRecorder is a panel stub recording the X-API-KEY of each request.
+func keyRecorder(t *testing.T) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys = append(keys, r.Header.Get("X-API-KEY")+"/"+r.Header.Get("X-API-SECRET"))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"success":true,"data":null}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), keys...)
+	}
+}
+
+func TestCredentialProcess_CachedUntilExpiry(t *testing.T) {
+	srv, keys := keyRecorder(t)
+	cmd := testCredentialProcess(t, fmt.Sprintf(`,"expiration":"%s"`, time.Now().Add(time.Hour).Format(time.RFC3339)))
+	c, err := New(Config{APIBaseURL: srv.URL, CredentialSource: CredentialProcess(cmd)})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for range 3 {
+		if err := c.Do(context.Background(), http.MethodGet, "/api/v2/x", nil, nil, nil); err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+	}
+	if got := strings.Join(keys(), ","); got != "key-1/secret-1,key-1/secret-1,key-1/secret-1" {
+		t.Errorf("keys = %s, want the first pair reused", got)
+	}
+}
+
+func TestCredentialProcess_ReinvokedOnExpiry(t *testing.T) {
+	srv, keys := keyRecorder(t)
+	// Inside the refresh window: every request must fetch a new pair.
+	cmd := testCredentialProcess(t, fmt.Sprintf(`,"expiration":"%s"`, time.Now().Add(10*time.Second).Format(time.RFC3339)))
+	c, err := New(Config{APIBaseURL: srv.URL, CredentialSource: CredentialProcess(cmd)})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := c.EnsureCredentials(context.Background()); err != nil {
+		t.Fatalf("EnsureCredentials: %v", err)
+	}
+	for range 2 {
+		if err := c.Do(context.Background(), http.MethodGet, "/api/v2/x", nil, nil, nil); err != nil {
+			t.Fatalf("Do: %v", err)
+		}
+	}
+	if got := strings.Join(keys(), ","); got != "key-2/secret-2,key-3/secret-3" {
+		t.Errorf("keys = %s, want a fresh pair per request", got)
+	}
+}
+
+func TestCredentialProcess_NoExpirationNeverRefreshes(t *testing.T) {
+	cmd := testCredentialProcess(t, "")
+	src := CredentialProcess(cmd)
+	creds, err := src(context.Background())
+	if err != nil {
+		t.Fatalf("source: %v", err)
+	}
+	if !creds.Expiration.IsZero() || creds.APIKeyID != "key-1" {
+		t.Errorf("creds = %+v, want key-1 with no expiration", creds)
+	}
+}
+
+func TestCredentialProcess_Errors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	cases := map[string]struct {
+		command string
+		want    string
+	}{
+		"exit status":   {`echo "vault sealed" >&2; exit 3`, "vault sealed"},
+		"not json":      {`echo sk_live_secret`, "not valid JSON"},
+		"missing field": {`echo '{"api_key_id":"k"}'`, "must set api_key_id and api_secret_key"},
+		"bad expiry":    {`echo '{"api_key_id":"k","api_secret_key":"s","expiration":"tomorrow"}'`, "not RFC 3339"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := CredentialProcess(tc.command)(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("err = %v, want %q", err, tc.want)
+			}
+			if strings.Contains(err.Error(), "sk_live_secret") {
+				t.Errorf("error echoes the process output: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrorCategory groups panel errors by what the user can do about them.
+type ErrorCategory string
+
+const (
+	// CategoryNotFound: the object does not exist (any more).
+	CategoryNotFound ErrorCategory = "not-found"
+	// CategoryConflict: the object's current state, or another object, prevents
+	// the request (a name already taken, an object still in use or locked).
+	CategoryConflict ErrorCategory = "conflict"
+	// CategoryQuota: the request exceeds a limit or the free capacity of the
+	// user's own resources.
+	CategoryQuota ErrorCategory = "quota"
+	// CategoryTransient: a panel-side condition that usually clears on its own.
+	CategoryTransient ErrorCategory = "transient"
+	// CategoryValidation: the request itself is wrong; fix the configuration.
+	CategoryValidation ErrorCategory = "validation"
+	// CategoryUnknown: an error the catalog does not know, or the panel's
+	// internal catch-all (627) that says nothing about the cause.
+	CategoryUnknown ErrorCategory = "unknown"
+)
+
+// Sentinel errors for the panel's business error codes. An *APIError carrying
+// the code matches with errors.Is, e.g. errors.Is(err, ErrBucketNotEmpty).
+var (
+	ErrNotFound              = errors.New("not found")
+	ErrLocalNetworkNameTaken = errors.New("local network name already exists")
+	ErrLocalNetworkInUse     = errors.New("local network is in use")
+	ErrBucketNameTaken       = errors.New("bucket name already exists")
+	ErrUnhandled             = errors.New("unhandled panel error")
+	ErrBucketNotFound        = errors.New("bucket not found")
+	ErrRegionUnresolved      = errors.New("region could not be resolved")
+	ErrProjectUnresolved     = errors.New("project tag could not be resolved")
+	ErrVmNameTaken           = errors.New("VM name already exists")
+	ErrLbNameTaken           = errors.New("load balancer name already exists")
+	ErrPublicIpAttached      = errors.New("public IP is attached to a VM")
+	ErrVmMustBeStopped       = errors.New("VM must be stopped")
+	ErrBucketOtherProject    = errors.New("bucket belongs to another project")
+	ErrLbNotFound            = errors.New("load balancer not found")
+	ErrInsufficientFreeIPs   = errors.New("not enough free IPs in the local network")
+	ErrBucketNotEmpty        = errors.New("bucket is not empty")
+	ErrNoIPPool              = errors.New("no IP pool in region")
+	ErrNoComputeCapacity     = errors.New("no compute capacity")
+	ErrProvisioningFailed    = errors.New("infrastructure provisioning failed")
+	ErrBackendVmNotFound     = errors.New("backend VM not found")
+	ErrLastWorkerPool        = errors.New("cannot delete the last worker node pool")
+	ErrVersionUnavailable    = errors.New("version not available in region")
+
+	// The kuber endpoints report these without a code; they are matched by message.
+	ErrClusterNameTaken   = errors.New("cluster name already exists")
+	ErrNodePoolNameTaken  = errors.New("node pool name already exists")
+	ErrMasterPoolDeletion = errors.New("cannot delete a master node pool")
+)
+
+// ErrorInfo describes a known panel error: its sentinel, category and the
+// user-facing text diagnostics render for it.
+type ErrorInfo struct {
+	Err      error
+	Category ErrorCategory
+	// Description says what went wrong, as a sentence.
+	Description string
+	// Remediation says how to fix it, as one or two sentences.
+	Remediation string
+}
+
+// errorCatalog maps the panel's business error codes to their ErrorInfo.
+var errorCatalog = map[int]ErrorInfo{
+	601: {ErrNotFound, CategoryNotFound,
+		"The object does not exist.",
+		"If it was deleted outside Terraform, refresh to drop it from state."},
+	614: {ErrLocalNetworkNameTaken, CategoryConflict,
+		"A local network with this name already exists.",
+		"Choose a different name, or import the existing network."},
+	615: {ErrLocalNetworkInUse, CategoryConflict,
+		"The local network is still in use.",
+		"Remove the VMs, load balancers and clusters attached to it first."},
+	626: {ErrBucketNameTaken, CategoryConflict,
+		"A bucket with this name already exists.",
+		"Choose a different name."},
+	627: {ErrUnhandled, CategoryUnknown,
+		"The panel hit an internal error it does not report in detail (its generic HTTP 500).",
+		"Contact support with this error; the panel response below identifies the request."},
+	628: {ErrBucketNotFound, CategoryNotFound,
+		"The bucket does not exist.",
+		"If it was deleted outside Terraform, refresh to drop it from state."},
+	638: {ErrRegionUnresolved, CategoryValidation,
+		"The region could not be resolved.",
+		"Check the provider or resource region configuration."},
+	645: {ErrProjectUnresolved, CategoryValidation,
+		"The project tag could not be resolved.",
+		"Check the provider or resource project configuration."},
+	666: {ErrVmNameTaken, CategoryConflict,
+		"A virtual machine with this name already exists.",
+		"Choose a different name."},
+	701: {ErrLbNameTaken, CategoryConflict,
+		"A load balancer with this name already exists in this region.",
+		"Choose a different name."},
+	703: {ErrNotFound, CategoryNotFound,
+		"The object does not exist.",
+		"If it was deleted outside Terraform, refresh to drop it from state."},
+	705: {ErrPublicIpAttached, CategoryConflict,
+		"The public IP is attached to a virtual machine.",
+		"Detach it (remove its prodata_public_ip_attachment) first."},
+	711: {ErrVmMustBeStopped, CategoryConflict,
+		"The virtual machine must be stopped for this operation.",
+		"Stop it, or retry once a concurrent start has settled."},
+	712: {ErrBucketOtherProject, CategoryConflict,
+		"The bucket exists but belongs to a different project.",
+		"Choose a different name, or use the project that owns the bucket."},
+	736: {ErrLbNotFound, CategoryNotFound,
+		"Load balancer not found.",
+		"If it was deleted outside Terraform, refresh to drop it from state."},
+	737: {ErrInsufficientFreeIPs, CategoryQuota,
+		"The local network does not have enough free IPs.",
+		"A load balancer needs at least three free IPs: one VIP plus two for the hidden nginx VMs. Free some addresses or use a larger network."},
+	738: {ErrBucketNotEmpty, CategoryConflict,
+		"The bucket still contains objects.",
+		"Empty it first, including every object version and delete marker, then retry."},
+	743: {ErrNoIPPool, CategoryTransient,
+		"No IP pool is available for load balancers in this region.",
+		"This is usually transient capacity; retry shortly, and contact support if it persists."},
+	744: {ErrNoComputeCapacity, CategoryTransient,
+		"No compute capacity is available to provision the request.",
+		"This is usually transient; retry shortly, and contact support if it persists."},
+	747: {ErrProvisioningFailed, CategoryTransient,
+		"The load balancer could not be provisioned: the infrastructure (VM provisioning) service reported an error.",
+		"Retry shortly, and contact support if it persists."},
+	748: {ErrBackendVmNotFound, CategoryValidation,
+		"Backend VM not found.",
+		"The vm_ids set must contain VM guids (the guid attribute of prodata_vm, e.g. prodata_vm.web[*].guid), not numeric ids."},
+	756: {ErrLastWorkerPool, CategoryConflict,
+		"Cannot delete the last worker node pool of a cluster.",
+		"Destroy the whole cluster instead, or add another worker pool first."},
+	757: {ErrVersionUnavailable, CategoryValidation,
+		"The requested Kubernetes version is not available in this region.",
+		"Choose one returned by the prodata_kubernetes_versions data source."},
+}
+
+// panelUnavailableInfo describes a request the circuit breaker refused.
+var panelUnavailableInfo = ErrorInfo{
+	Err:      ErrPanelUnavailable,
+	Category: CategoryTransient,
+	Description: "The panel is unavailable: recent requests kept failing with server or network errors, " +
+		"so the provider stopped sending new ones instead of retrying each of them.",
+	Remediation: "Check the panel's status and your network or proxy, then run the command again. " +
+		"The provider probes the panel again after the retry block's circuit_breaker_cooldown (30s by default).",
+}
+
+// errorMessageCatalog covers errors the kuber endpoints report without a code,
+// keyed by a substring of their (English, see withEnglishLang) message.
+var errorMessageCatalog = []struct {
+	message string
+	info    ErrorInfo
+}{
+	{"Cluster with this name already exist", ErrorInfo{ErrClusterNameTaken, CategoryConflict,
+		"A Kubernetes cluster with this name already exists in this region.",
+		"Choose a different name."}},
+	{"Node pool with this name already exists", ErrorInfo{ErrNodePoolNameTaken, CategoryConflict,
+		"A node pool with this name already exists in this cluster.",
+		"Choose a different name."}},
+	{"Cannot delete master node pool", ErrorInfo{ErrMasterPoolDeletion, CategoryValidation,
+		"This node pool is a control-plane (master) pool and cannot be deleted via this resource.",
+		"Destroy the cluster to remove its control plane."}},
+}
+
+// transientRemediation is the hint for an uncatalogued error whose HTTP status
+// marks it transient.
+const transientRemediation = "This is usually transient; retry shortly, and contact support if it persists."
+
+// info returns the catalog entry for the first known code (or message) this
+// error carries.
+func (e *APIError) info() (ErrorInfo, bool) {
+	for _, c := range e.Codes {
+		if info, ok := errorCatalog[c]; ok {
+			return info, true
+		}
+	}
+	for _, m := range errorMessageCatalog {
+		if strings.Contains(e.Message, m.message) {
+			return m.info, true
+		}
+	}
+	return ErrorInfo{}, false
+}
+
+// Is reports whether target is the sentinel for one of this error's codes, so
+// errors.Is(err, ErrVmNameTaken) matches an *APIError carrying code 666.
+func (e *APIError) Is(target error) bool {
+	for _, c := range e.Codes {
+		if info, ok := errorCatalog[c]; ok && info.Err == target {
+			return true
+		}
+	}
+	for _, m := range errorMessageCatalog {
+		if m.info.Err == target && strings.Contains(e.Message, m.message) {
+			return true
+		}
+	}
+	return false
+}
+
+// Category classifies the error: from its first catalogued code, else from its
+// HTTP status.
+func (e *APIError) Category() ErrorCategory {
+	if info, ok := e.info(); ok {
+		return info.Category
+	}
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return CategoryNotFound
+	case http.StatusConflict:
+		return CategoryConflict
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return CategoryValidation
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return CategoryTransient
+	}
+	return CategoryUnknown
+}
+
+// LookupError returns the catalog entry for err, if it is (or wraps) an
+// *APIError with a known code or message.
+func LookupError(err error) (ErrorInfo, bool) {
+	if errors.Is(err, ErrPanelUnavailable) {
+		return panelUnavailableInfo, true
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return ErrorInfo{}, false
+	}
+	return apiErr.info()
+}
+
+// ErrorCategoryOf classifies err; anything but an *APIError or an open circuit
+// breaker is CategoryUnknown.
+func ErrorCategoryOf(err error) ErrorCategory {
+	if errors.Is(err, ErrPanelUnavailable) {
+		return CategoryTransient
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return CategoryUnknown
+	}
+	return apiErr.Category()
+}
+
+// ErrorDetail renders err as the detail of a Terraform diagnostic: what went
+// wrong, how to fix it, then the panel's own response for support. Errors the
+// catalog does not know render as err.Error(), with a retry hint when their
+// HTTP status marks them transient.
+func ErrorDetail(err error) string {
+	info, ok := LookupError(err)
+	if !ok {
+		if ErrorCategoryOf(err) == CategoryTransient {
+			return fmt.Sprintf("%s\n\nHow to fix: %s", err.Error(), transientRemediation)
+		}
+		return err.Error()
+	}
+	label := "Panel response"
+	if info.Err == ErrPanelUnavailable {
+		// The breaker answered, not the panel.
+		label = "Details"
+	}
+	return fmt.Sprintf("%s\n\nHow to fix: %s\n\n%s: %s", info.Description, info.Remediation, label, err.Error())
+}
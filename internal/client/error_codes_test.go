@@ -0,0 +1,93 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestErrorCatalog_Complete(t *testing.T) {
+	for code, info := range errorCatalog {
+		if info.Err == nil || info.Category == "" || info.Description == "" || info.Remediation == "" {
+			t.Errorf("code %d: incomplete entry %+v", code, info)
+		}
+	}
+	for _, m := range errorMessageCatalog {
+		if m.info.Err == nil || m.info.Category == "" || m.info.Description == "" || m.info.Remediation == "" {
+			t.Errorf("message %q: incomplete entry %+v", m.message, m.info)
+		}
+	}
+}
+
+func TestAPIError_Is(t *testing.T) {
+	err := fmt.Errorf("create VM: %w", &APIError{StatusCode: 409, Codes: []int{666}, Message: "exists"})
+	if !errors.Is(err, ErrVmNameTaken) {
+		t.Error("errors.Is(ErrVmNameTaken) should match code 666 through wrapping")
+	}
+	if errors.Is(err, ErrLbNameTaken) {
+		t.Error("errors.Is(ErrLbNameTaken) should not match code 666")
+	}
+	kuber := &APIError{StatusCode: 500, Message: "Node pool with this name already exists"}
+	if !errors.Is(kuber, ErrNodePoolNameTaken) {
+		t.Error("errors.Is(ErrNodePoolNameTaken) should match by message")
+	}
+}
+
+func TestErrorCategoryOf(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want ErrorCategory
+	}{
+		{"catalogued not found", &APIError{StatusCode: 400, Codes: []int{703}}, CategoryNotFound},
+		{"catalogued quota", &APIError{StatusCode: 400, Codes: []int{737}}, CategoryQuota},
+		{"catalogued transient", &APIError{StatusCode: 503, Codes: []int{744}}, CategoryTransient},
+		{"712 is not not-found", &APIError{StatusCode: 403, Codes: []int{712}}, CategoryConflict},
+		{"627 catch-all", &APIError{StatusCode: 500, Codes: []int{627}}, CategoryUnknown},
+		{"first known code wins", &APIError{StatusCode: 400, Codes: []int{999, 757}}, CategoryValidation},
+		{"http 404", &APIError{StatusCode: 404, Codes: []int{999}}, CategoryNotFound},
+		{"http 502", &APIError{StatusCode: 502}, CategoryTransient},
+		{"http 500", &APIError{StatusCode: 500}, CategoryUnknown},
+		{"non-api", errors.New("plain"), CategoryUnknown},
+	}
+	for _, tc := range cases {
+		if got := ErrorCategoryOf(tc.err); got != tc.want {
+			t.Errorf("%s: category = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestErrorDetail(t *testing.T) {
+	cases := []struct {
+		name      string
+		err       error
+		wantSubst []string
+	}{
+		{"701 duplicate name", &APIError{StatusCode: 400, Codes: []int{701}, Message: "x"}, []string{"already exists", "How to fix: Choose a different name."}},
+		{"736 not found", &APIError{StatusCode: 404, Codes: []int{736}, Message: "x"}, []string{"not found"}},
+		{"737 free IPs", &APIError{StatusCode: 400, Codes: []int{737}, Message: "x"}, []string{"free IPs"}},
+		{"743 no ip pool", &APIError{StatusCode: 503, Codes: []int{743}, Message: "x"}, []string{"IP pool"}},
+		{"744 no compute capacity", &APIError{StatusCode: 503, Codes: []int{744}, Message: "x"}, []string{"compute capacity"}},
+		{"747 provisioning failed", &APIError{StatusCode: 502, Codes: []int{747}, Message: "x"}, []string{"could not be provisioned"}},
+		{"756 last worker pool", &APIError{StatusCode: 409, Codes: []int{756}, Message: "x"}, []string{"last worker node pool"}},
+		{"757 version unavailable", &APIError{StatusCode: 422, Codes: []int{757}, Message: "x"}, []string{"not available in this region"}},
+		{"duplicate cluster name", &APIError{StatusCode: 500, Message: "Cluster with this name already exist"}, []string{"cluster with this name already exists"}},
+		{"panel response kept", &APIError{StatusCode: 500, Codes: []int{627}, Message: "Unhandled error"}, []string{"627", "Unhandled error", "contact support"}},
+		{"wrapping context kept", fmt.Errorf("polling: %w", &APIError{StatusCode: 409, Codes: []int{738}}), []string{"Panel response: polling: api error [738]"}},
+		{"unknown transient gets a hint", &APIError{StatusCode: 503, Message: "down"}, []string{"down", "retry shortly"}},
+		{"unknown code falls through", &APIError{StatusCode: 500, Codes: []int{999}, Message: "raw msg"}, []string{"raw msg"}},
+		{"non-api error falls through", errors.New("plain"), []string{"plain"}},
+	}
+	for _, tc := range cases {
+		got := ErrorDetail(tc.err)
+		for _, want := range tc.wantSubst {
+			if !strings.Contains(strings.ToLower(got), strings.ToLower(want)) {
+				t.Errorf("%s: ErrorDetail() = %q, want substring %q", tc.name, got, want)
+			}
+		}
+	}
+	if got := ErrorDetail(errors.New("plain")); got != "plain" {
+		t.Errorf("ErrorDetail(non-api) = %q, want the error text unchanged", got)
+	}
+}
@@ -42,9 +42,14 @@ func IsAPIError(err error, code int) bool {
 }
 
 // IsNotFound reports whether err indicates that the resource does not exist
-// (HTTP 404, or API codes 601/703/628/736). Code 712 (cross-project — bucket
-// exists but is owned by another project) is intentionally NOT treated as
-// not-found: silently dropping state for someone else's bucket would be a footgun.
+// (HTTP 404, or API codes 601/703/628/736 anywhere in Codes). Code 712
+// (cross-project — bucket exists but is owned by another project) is
+// intentionally NOT treated as not-found: silently dropping state for someone
+// else's bucket would be a footgun.
+//
+// Unlike ErrorCategoryOf, which classifies by the first catalogued code, this
+// must not let another code shadow a not-found one: a Read that misses the
+// deletion keeps a stale object in state.
 func IsNotFound(err error) bool {
 	var apiErr *APIError
 	if !errors.As(err, &apiErr) {
@@ -60,13 +65,7 @@ func IsNotFound(err error) bool {
 // in the network to allocate a load balancer" error (code 737). It is
 // deliberately NOT folded into IsNotFound: this is a create-time validation
 // failure, not a missing resource — treating it as not-found would mask it.
-func IsInsufficientFreeIPs(err error) bool {
-	var apiErr *APIError
-	if !errors.As(err, &apiErr) {
-		return false
-	}
-	return apiErr.HasCode(737)
-}
+func IsInsufficientFreeIPs(err error) bool { return errors.Is(err, ErrInsufficientFreeIPs) }
 
 // IsRetryableTransient reports whether err is a genuinely transient failure
 // that is worth retrying — an HTTP 503 (Service Unavailable). The panel uses
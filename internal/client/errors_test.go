@@ -94,6 +94,8 @@ func TestIsNotFound(t *testing.T) {
 		{"code 628 bucket gone", &APIError{StatusCode: 400, Codes: []int{628}, Message: "bucket not found"}, true},
 		{"code 712 cross-project NOT not-found", &APIError{StatusCode: 403, Codes: []int{712}, Message: "not yours"}, false},
 		{"code 627", &APIError{StatusCode: 500, Codes: []int{627}, Message: "busy"}, false},
+		{"http 404 carrying 712", &APIError{StatusCode: 404, Codes: []int{712}, Message: "not yours"}, true},
+		{"627 then 601", &APIError{StatusCode: 500, Codes: []int{627, 601}, Message: "busy"}, true},
 		{"http 500 no codes", &APIError{StatusCode: 500, Message: "server error"}, false},
 		{"wrapped 601", fmt.Errorf("wrap: %w", &APIError{Codes: []int{601}}), true},
 		{"plain error", errors.New("not found"), false},
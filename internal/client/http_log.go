@@ -0,0 +1,85 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpLogSubsystem is the tflog subsystem of the panel wire log: one DEBUG line
+// per attempt (method, path, status, duration, attempt) and, at TRACE, the
+// redacted headers and bodies. Its level follows TF_LOG_PROVIDER_PRODATA_HTTP,
+// else the provider's own level, so the wire log can be turned up on its own.
+const httpLogSubsystem = "prodata_http"
+
+// httpLogLevelEnv sets the level of the wire log independently of the provider's.
+const httpLogLevelEnv = "TF_LOG_PROVIDER_PRODATA_HTTP"
+
+// maxLoggedBody caps how much of a body the wire log shows.
+const maxLoggedBody = 64 << 10
+
+// withHTTPLog returns ctx with the wire-log subsystem attached.
+func withHTTPLog(ctx context.Context) context.Context {
+	return tflog.NewSubsystem(ctx, httpLogSubsystem, tflog.WithLevelFromEnv(httpLogLevelEnv))
+}
+
+// httpAttempt is one round trip of doRequest, as reported to the wire log.
+type httpAttempt struct {
+	method     string
+	path       string
+	attempt    int
+	duration   time.Duration
+	reqHeader  http.Header
+	reqBody    []byte
+	status     int // 0 when the round trip failed
+	respHeader http.Header
+	respBody   []byte
+	err        error
+}
+
+// logHTTPAttempt writes a to the wire log.
+func logHTTPAttempt(ctx context.Context, a httpAttempt) {
+	fields := map[string]any{
+		"method":      a.method,
+		"path":        a.path,
+		"attempt":     a.attempt,
+		"duration_ms": a.duration.Milliseconds(),
+	}
+	if a.err != nil {
+		fields["error"] = a.err.Error()
+	} else {
+		fields["status"] = a.status
+	}
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "panel request", fields)
+
+	fields["request_headers"] = redactHeaders(a.reqHeader)
+	fields["request_body"] = loggableBody(a.reqBody)
+	if a.err == nil {
+		fields["response_headers"] = redactHeaders(a.respHeader)
+		fields["response_body"] = loggableBody(a.respBody)
+	}
+	tflog.SubsystemTrace(ctx, httpLogSubsystem, "panel request and response", fields)
+}
+
+// loggableBody renders a body for the wire log: JSON with its secret fields
+// redacted, truncated to maxLoggedBody. A body that looks like JSON but does not
+// parse (a truncated kubeconfig response, say) cannot be scrubbed and is left
+// out entirely.
+func loggableBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && !json.Valid(trimmed) {
+		return fmt.Sprintf("(%d bytes of unparseable JSON omitted)", len(body))
+	}
+	out := redactJSON(body)
+	if len(out) > maxLoggedBody {
+		return string(out[:maxLoggedBody]) + fmt.Sprintf("… (%d bytes total)", len(out))
+	}
+	return string(out)
+}
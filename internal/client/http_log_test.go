@@ -0,0 +1,94 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// wireLog runs one POST through a client whose panel answers with respBody, and
+// returns the prodata_http log entries.
+func wireLog(t *testing.T, reqBody any, respBody string) []map[string]any {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc")
+		_, _ = w.Write([]byte(respBody))
+	}))
+	defer srv.Close()
+	c, err := New(Config{APIBaseURL: srv.URL, APIKeyID: "k", APISecretKey: "top-secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &buf)
+	if _, _, err := c.doRequest(ctx, http.MethodPost, "/api/v2/vms", reqBody, nil); err != nil {
+		t.Fatalf("doRequest: %v", err)
+	}
+	entries, err := tflogtest.MultilineJSONDecode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []map[string]any
+	for _, e := range entries {
+		if e["@module"] == "provider."+httpLogSubsystem {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func TestHTTPLog_AttemptSummaryAndBodies(t *testing.T) {
+	entries := wireLog(t, map[string]any{"name": "vm1", "password": "hunter2"}, `{"success":true,"data":{"id":7}}`)
+	if len(entries) != 2 {
+		t.Fatalf("got %d wire-log entries, want a DEBUG summary and a TRACE detail: %v", len(entries), entries)
+	}
+	summary, detail := entries[0], entries[1]
+	respBody, _ := detail["response_body"].(string)
+	reqBody, _ := detail["request_body"].(string)
+	if summary["@level"] != "debug" || summary["method"] != "POST" || summary["path"] != "/api/v2/vms" ||
+		summary["status"] != float64(200) || summary["attempt"] != float64(1) || summary["duration_ms"] == nil {
+		t.Errorf("summary = %v", summary)
+	}
+	if detail["@level"] != "trace" || !strings.Contains(respBody, `"id":7`) {
+		t.Errorf("detail = %v", detail)
+	}
+	if !strings.Contains(reqBody, `"name":"vm1"`) || strings.Contains(reqBody, "hunter2") {
+		t.Errorf("request_body = %s, want the password redacted", reqBody)
+	}
+	reqHeaders, _ := detail["request_headers"].(map[string]any)
+	respHeaders, _ := detail["response_headers"].(map[string]any)
+	if reqHeaders["X-Api-Secret"] != redacted || reqHeaders["X-Api-Key"] != "k" || respHeaders["Set-Cookie"] != redacted {
+		t.Errorf("headers = %v / %v, want secrets redacted", reqHeaders, respHeaders)
+	}
+}
+
+func TestHTTPLog_RedactsSecretResponses(t *testing.T) {
+	entries := wireLog(t, nil,
+		`{"success":true,"data":{"sshKeyEncoded":"ssh-rsa AAA","privateKeyEncoded":"PRIVATE","clusterConfigSecret":"apiVersion: v1\nusers:\n- user:\n    token: tok123\n"}}`)
+	body, _ := entries[len(entries)-1]["response_body"].(string)
+	if body == "" {
+		t.Fatalf("no response_body in %v", entries)
+	}
+	for _, secret := range []string{"ssh-rsa AAA", "PRIVATE", "tok123"} {
+		if strings.Contains(body, secret) {
+			t.Errorf("response_body leaks %q: %s", secret, body)
+		}
+	}
+}
+
+func TestLoggableBody(t *testing.T) {
+	if got := loggableBody([]byte(`{"clusterConfigSecret":"tok`)); strings.Contains(got, "tok") {
+		t.Errorf("truncated JSON logged verbatim: %s", got)
+	}
+	if got := loggableBody([]byte("<html>bad gateway</html>")); got != "<html>bad gateway</html>" {
+		t.Errorf("non-JSON body = %q, want it verbatim", got)
+	}
+	if got := loggableBody(bytes.Repeat([]byte("x"), maxLoggedBody+10)); len(got) > maxLoggedBody+64 {
+		t.Errorf("body of %d bytes logged, want it truncated", len(got))
+	}
+}
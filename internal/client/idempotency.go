@@ -0,0 +1,54 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// idempotencyKeyHeader carries a client-generated key on every mutating request.
+// The key is fixed for one logical request and reused by all of its retries. A
+// panel that honours it runs a repeated key only once and replays the first
+// response. It also echoes the header back, which is how the client finds out it
+// is safe to re-send a POST after a transport error.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// setIdempotencyKey adds the key to a request's headers. net/http re-sends a
+// request carrying an Idempotency-Key header by itself when a reused keep-alive
+// connection closes before answering (Request.isReplayable). That is only safe
+// once the panel is known to honour the key. Until then the header is stored
+// under its lowercase name, which net/http does not recognise but which reaches
+// the panel as the same header, since header names are case-insensitive.
+func setIdempotencyKey(h http.Header, key string, honoured bool) {
+	if honoured {
+		h.Set(idempotencyKeyHeader, key)
+		return
+	}
+	h[strings.ToLower(idempotencyKeyHeader)] = []string{key}
+}
+
+// newIdempotencyKey returns a random (version 4) UUID.
+func newIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:]) // crypto/rand.Read never fails on supported platforms
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// noteIdempotencySupport records that the panel honours idempotency keys when a
+// response to a keyed request echoes the key back.
+func (c *Client) noteIdempotencySupport(key string, h http.Header) {
+	if key != "" && h.Get(idempotencyKeyHeader) == key {
+		c.idempotencyHonoured.Store(true)
+	}
+}
+
+// canRetryTransportError reports whether a request may be re-sent after a
+// transport error. GET and HEAD always can. A mutating request can only once the
+// panel has shown that it honours idempotency keys: the first attempt may already
+// have been applied, and the shared key lets the panel recognise the re-send.
+func (c *Client) canRetryTransportError(method, idempotencyKey string) bool {
+	return isIdempotentMethod(method) || (idempotencyKey != "" && c.idempotencyHonoured.Load())
+}
@@ -411,38 +411,11 @@ func IsKuberNotFound(err error) bool {
 
 // IsLastWorkerPool reports whether err is the "cannot delete the last worker node
 // pool" guard (code 756, G1).
-func IsLastWorkerPool(err error) bool { return IsAPIError(err, 756) }
+func IsLastWorkerPool(err error) bool { return errors.Is(err, ErrLastWorkerPool) }
 
 // IsVersionUnavailable reports whether err is the "version not available in this
 // region" error (code 757, G10).
-func IsVersionUnavailable(err error) bool { return IsAPIError(err, 757) }
-
-// KuberErrorDetail maps known kuber failures to a clean, user-facing message for
-// Terraform diagnostics. Unrecognized errors fall through to err.Error().
-func KuberErrorDetail(err error) string {
-	var apiErr *APIError
-	if errors.As(err, &apiErr) {
-		switch {
-		case apiErr.HasCode(756):
-			return "Cannot delete the last worker node pool of a cluster. Destroy the whole cluster instead, or add another worker pool first."
-		case apiErr.HasCode(757):
-			return "The requested Kubernetes version is not available in this region. Choose one returned by the prodata_kubernetes_versions data source."
-		case apiErr.HasCode(638):
-			return "The X-Region could not be resolved. Check the provider/region configuration."
-		case apiErr.HasCode(645):
-			return "The project tag could not be resolved. Check the provider/project configuration."
-		}
-		switch {
-		case strings.Contains(apiErr.Message, "Cluster with this name already exist"):
-			return "A Kubernetes cluster with this name already exists in this region. Choose a different name."
-		case strings.Contains(apiErr.Message, "Node pool with this name already exists"):
-			return "A node pool with this name already exists in this cluster. Choose a different name."
-		case strings.Contains(apiErr.Message, "Cannot delete master node pool"):
-			return "This node pool is a control-plane (master) pool and cannot be deleted via this resource."
-		}
-	}
-	return err.Error()
-}
+func IsVersionUnavailable(err error) bool { return errors.Is(err, ErrVersionUnavailable) }
 
 // ---- cluster operations ----
 
@@ -465,16 +438,19 @@ func (c *Client) GetCluster(ctx context.Context, id int64, opts *RequestOpts) (*
 // ListClusters returns every non-DELETED cluster visible to the resolved
 // region+project (getClusters honors X-Region / X-Project-Tag). Used by the
 // cluster data source and for adopt-or-error after a lost create response.
+// Coalesced like GetVolumes.
 func (c *Client) ListClusters(ctx context.Context, opts *RequestOpts) ([]Cluster, error) {
-	dtos, err := doKuberV1[[]clusterDTO](ctx, c, http.MethodGet, "/getClusters", nil, opts)
-	if err != nil {
-		return nil, err
-	}
-	out := make([]Cluster, 0, len(dtos))
-	for i := range dtos {
-		out = append(out, *dtos[i].toCluster())
-	}
-	return out, nil
+	return cachedList(ctx, c, "ListClusters", opts, func(ctx context.Context) ([]Cluster, error) {
+		dtos, err := doKuberV1[[]clusterDTO](ctx, c, http.MethodGet, "/getClusters", nil, opts)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]Cluster, 0, len(dtos))
+		for i := range dtos {
+			out = append(out, *dtos[i].toCluster())
+		}
+		return out, nil
+	})
 }
 
 // DeleteCluster soft-deletes a cluster (synchronous on the backend ack; infra
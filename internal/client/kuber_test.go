@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -330,8 +329,8 @@ func TestDeleteNodePool_LastWorkerPool756(t *testing.T) {
 	if !IsLastWorkerPool(err) {
 		t.Errorf("expected IsLastWorkerPool=true, got: %v", err)
 	}
-	if !strings.Contains(KuberErrorDetail(err), "last worker node pool") {
-		t.Errorf("KuberErrorDetail = %q", KuberErrorDetail(err))
+	if !strings.Contains(ErrorDetail(err), "last worker node pool") {
+		t.Errorf("ErrorDetail = %q", ErrorDetail(err))
 	}
 }
 
@@ -402,29 +401,6 @@ func TestGetMasterNodeConfigs_PathAndDecode(t *testing.T) {
 	}
 }
 
-func TestKuberErrorDetail(t *testing.T) {
-	cases := []struct {
-		name      string
-		err       error
-		wantSubst string
-	}{
-		{"756 last worker pool", &APIError{StatusCode: 409, Codes: []int{756}, Message: "x"}, "last worker node pool"},
-		{"757 version unavailable", &APIError{StatusCode: 422, Codes: []int{757}, Message: "x"}, "not available in this region"},
-		{"duplicate cluster name", &APIError{StatusCode: 500, Message: "Cluster with this name already exist"}, "already exists"},
-		{"duplicate pool name", &APIError{StatusCode: 500, Message: "Node pool with this name already exists"}, "node pool with this name"},
-		{"unknown falls through", &APIError{StatusCode: 500, Codes: []int{627}, Message: "raw"}, "raw"},
-		{"non-api falls through", errors.New("plain"), "plain"},
-	}
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			got := KuberErrorDetail(tc.err)
-			if !strings.Contains(strings.ToLower(got), strings.ToLower(tc.wantSubst)) {
-				t.Errorf("KuberErrorDetail() = %q, want substring %q", got, tc.wantSubst)
-			}
-		})
-	}
-}
-
 func keysOf(m map[string]any) []string {
 	out := make([]string, 0, len(m))
 	for k := range m {
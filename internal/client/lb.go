@@ -3,7 +3,6 @@ package client
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -219,32 +218,6 @@ func singleLB(dto *lbDTO, err error, op string) (*LoadBalancer, error) {
 	return dto.toLoadBalancer(), nil
 }
 
-// LBErrorDetail maps known load-balancer error codes to a clean, user-facing
-// message so Terraform diagnostics don't surface the raw "api error [code]
-// (http ...)" form. Unrecognized errors fall through to err.Error().
-func LBErrorDetail(err error) string {
-	var apiErr *APIError
-	if errors.As(err, &apiErr) {
-		switch {
-		case apiErr.HasCode(701):
-			return "A load balancer with this name already exists in this region. Choose a different name."
-		case apiErr.HasCode(736):
-			return "Load balancer not found."
-		case apiErr.HasCode(737):
-			return "The local network does not have enough free IPs. A load balancer needs at least three: one VIP plus two for the hidden nginx VMs."
-		case apiErr.HasCode(743):
-			return "No IP pool is available for load balancers in this region. This is usually transient capacity; retry shortly or contact support if it persists."
-		case apiErr.HasCode(744):
-			return "No compute capacity is available to provision the load balancer's nginx VMs. This is usually transient; retry shortly or contact support if it persists."
-		case apiErr.HasCode(747):
-			return "The load balancer could not be provisioned: the infrastructure (VM provisioning) service reported an error. Retry shortly, and contact support if it persists."
-		case apiErr.HasCode(748):
-			return "Backend VM not found. The vm_ids set must contain VM guids (the guid attribute of prodata_vm, e.g. prodata_vm.web[*].guid), not numeric ids."
-		}
-	}
-	return err.Error()
-}
-
 // CreateLoadBalancerFrontend creates a VM-backed load balancer.
 func (c *Client) CreateLoadBalancerFrontend(ctx context.Context, req LoadBalancerRequest, opts *RequestOpts) (*LoadBalancer, error) {
 	dto, err := doLBV1[*lbDTO](ctx, c, http.MethodPost, "/api/loadbalancer/createLoadbalancer", req, opts)
@@ -2,7 +2,6 @@ package client
 
 import (
 	"context"
-	"errors"
 	"strings"
 	"testing"
 )
@@ -180,32 +179,6 @@ func TestGetLoadBalancer_ProtocolUDPLowercase(t *testing.T) {
 	}
 }
 
-func TestLBErrorDetail(t *testing.T) {
-	cases := []struct {
-		name      string
-		err       error
-		wantSubst string
-	}{
-		{"701 duplicate name", &APIError{StatusCode: 400, Codes: []int{701}, Message: "x"}, "already exists"},
-		{"736 not found", &APIError{StatusCode: 404, Codes: []int{736}, Message: "x"}, "not found"},
-		{"737 free IPs", &APIError{StatusCode: 400, Codes: []int{737}, Message: "x"}, "free IPs"},
-		{"743 no ip pool", &APIError{StatusCode: 503, Codes: []int{743}, Message: "x"}, "IP pool"},
-		{"744 no compute capacity", &APIError{StatusCode: 503, Codes: []int{744}, Message: "x"}, "compute capacity"},
-		{"747 provisioning failed", &APIError{StatusCode: 502, Codes: []int{747}, Message: "x"}, "could not be provisioned"},
-		{"627 unhandled falls through to raw", &APIError{StatusCode: 500, Codes: []int{627}, Message: "Unhandled error"}, "627"},
-		{"unknown code falls through", &APIError{StatusCode: 500, Codes: []int{999}, Message: "raw msg"}, "raw msg"},
-		{"non-api error falls through", errors.New("plain"), "plain"},
-	}
-	for _, c := range cases {
-		t.Run(c.name, func(t *testing.T) {
-			got := LBErrorDetail(c.err)
-			if !strings.Contains(got, c.wantSubst) {
-				t.Errorf("LBErrorDetail() = %q, want substring %q", got, c.wantSubst)
-			}
-		})
-	}
-}
-
 func TestGetLoadBalancer_NotFound736(t *testing.T) {
 	body := `{"success":false,"data":null,"errors":[{"code":736,"message":"Load balancer not found."}]}`
 	server := newTestServer(404, body)
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// aimdDecrease is the multiplicative cut applied to the request rate on a 429.
+	aimdDecrease = 0.5
+	// aimdIncrease is the additive recovery, in requests per second, applied on each
+	// response that is not a 429. At 10 req/s that is +1 req/s every second.
+	aimdIncrease = 0.1
+	// aimdDecreaseCooldown collapses a volley of 429s (every in-flight request of a
+	// bulk apply hitting the same limit) into a single rate cut.
+	aimdDecreaseCooldown = time.Second
+	// minAdaptiveRPS is the floor the adaptive rate never drops below.
+	minAdaptiveRPS = 0.5
+	// rateWindow is the window over which the unthrottled request rate is measured,
+	// to seed the first rate cut when no ceiling is configured.
+	rateWindow = time.Second
+)
+
+// rateLimiter is a token bucket shared by every request of a client. It has two
+// layers:
+//
+//   - A configured ceiling (MaxRPS) with a burst allowance. Zero means no ceiling.
+//   - AIMD adaptation: each 429 halves the current rate (seeded from the measured
+//     rate when unthrottled), every other response adds aimdIncrease back, until
+//     the rate is back at the ceiling (or, with no ceiling, throttling is lifted).
+//     A Retry-After header additionally pauses all requests until it elapses.
+//
+// Requests reserve a token up front (the bucket may go negative), so concurrent
+// waiters are served in arrival order at the current rate. The retry of a 429 does
+// not wait here: doRequest already backs it off by its Retry-After.
+type rateLimiter struct {
+	mu sync.Mutex
+
+	ceiling float64 // configured max req/s; 0 = none
+	burst   float64
+
+	rate   float64 // current req/s; 0 = unthrottled
+	tokens float64
+	last   time.Time // last token refill
+
+	pausedUntil  time.Time // Retry-After: no request leaves before this
+	lastDecrease time.Time
+	// recoverTo is, with no ceiling, the rate at which throttling is lifted again:
+	// the measured rate when the first 429 arrived.
+	recoverTo float64
+
+	windowStart   time.Time
+	windowCount   int
+	lastWindowRPS float64
+}
+
+// newRateLimiter returns a limiter capped at maxRPS (0 = no ceiling) with a bucket
+// of burst tokens (values below 1 mean 1: strict pacing).
+func newRateLimiter(maxRPS float64, burst int) *rateLimiter {
+	if maxRPS < 0 {
+		maxRPS = 0
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		ceiling: maxRPS,
+		burst:   float64(burst),
+		rate:    maxRPS,
+		tokens:  float64(burst),
+		last:    time.Now(),
+	}
+}
+
+// wait blocks until this request may be sent, or until ctx is cancelled.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.measure(now)
+	var d time.Duration
+	if l.rate > 0 {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.tokens--
+		if l.tokens < 0 {
+			d = time.Duration(-l.tokens / l.rate * float64(time.Second))
+		}
+	}
+	l.last = now
+	if p := l.pausedUntil.Sub(now); p > d {
+		d = p
+	}
+	l.mu.Unlock()
+
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// measure counts a request toward the observed rate. The caller holds l.mu.
+func (l *rateLimiter) measure(now time.Time) {
+	if elapsed := now.Sub(l.windowStart); elapsed >= rateWindow {
+		if elapsed < 2*rateWindow {
+			l.lastWindowRPS = float64(l.windowCount) / elapsed.Seconds()
+		} else {
+			l.lastWindowRPS = 0 // idle gap: the old window says nothing about now
+		}
+		l.windowStart, l.windowCount = now, 0
+	}
+	l.windowCount++
+}
+
+// throttled records a 429. retryAfter is the server's Retry-After, or 0 if absent.
+func (l *rateLimiter) throttled(retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if retryAfter > 0 && now.Add(retryAfter).After(l.pausedUntil) {
+		l.pausedUntil = now.Add(retryAfter)
+	}
+	if now.Sub(l.lastDecrease) < aimdDecreaseCooldown {
+		return
+	}
+	l.lastDecrease = now
+	if l.rate == 0 {
+		observed := l.lastWindowRPS
+		if c := float64(l.windowCount); c > observed {
+			observed = c
+		}
+		if observed < 2*minAdaptiveRPS {
+			observed = 2 * minAdaptiveRPS
+		}
+		l.rate, l.recoverTo = observed, observed
+		l.last = now
+	}
+	l.rate *= aimdDecrease
+	if l.rate < minAdaptiveRPS {
+		l.rate = minAdaptiveRPS
+	}
+	if l.tokens > 1 {
+		l.tokens = 1 // no burst straight after a 429
+	}
+}
+
+// succeeded records a response that was not a 429, recovering the rate.
+func (l *rateLimiter) succeeded() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate == 0 || (l.ceiling > 0 && l.rate >= l.ceiling) {
+		return
+	}
+	l.rate += aimdIncrease
+	switch {
+	case l.ceiling > 0 && l.rate >= l.ceiling:
+		l.rate = l.ceiling
+	case l.ceiling == 0 && l.rate >= l.recoverTo:
+		l.rate = 0
+	}
+}
+
+// currentRate reports the current rate (0 = unthrottled), for logs and tests.
+func (l *rateLimiter) currentRate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate
+}
@@ -2,6 +2,9 @@ package client
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -11,21 +14,21 @@ func TestNew_RateLimiterWiring(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if c.limiter != nil {
-		t.Error("expected no limiter when MaxRPS is 0")
+	if c.limiter == nil || c.limiter.currentRate() != 0 {
+		t.Error("expected an unthrottled adaptive limiter when MaxRPS is 0")
 	}
 
-	c2, err := New(Config{APIBaseURL: "https://x", APIKeyID: "k", APISecretKey: "s", MaxRPS: 50})
+	c2, err := New(Config{APIBaseURL: "https://x", APIKeyID: "k", APISecretKey: "s", MaxRPS: 50, RequestBurst: 5})
 	if err != nil {
 		t.Fatal(err)
 	}
-	if c2.limiter == nil {
-		t.Fatal("expected a limiter when MaxRPS > 0")
+	if c2.limiter.currentRate() != 50 || c2.limiter.burst != 5 {
+		t.Errorf("limiter = rate %v burst %v, want 50/5", c2.limiter.currentRate(), c2.limiter.burst)
 	}
 }
 
 func TestRateLimiter_Paces(t *testing.T) {
-	l := &rateLimiter{interval: 20 * time.Millisecond}
+	l := newRateLimiter(50, 1) // one token every 20ms, no burst
 	start := time.Now()
 	for i := 0; i < 3; i++ {
 		if err := l.wait(context.Background()); err != nil {
@@ -38,14 +41,106 @@ func TestRateLimiter_Paces(t *testing.T) {
 	}
 }
 
+func TestRateLimiter_Burst(t *testing.T) {
+	l := newRateLimiter(1, 5)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("a full bucket of 5 should go out at once, took %v", elapsed)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := l.wait(ctx); err == nil {
+		t.Error("the sixth request should wait ~1s for a token")
+	}
+}
+
 func TestRateLimiter_RespectsContext(t *testing.T) {
-	l := &rateLimiter{interval: time.Hour}
-	if err := l.wait(context.Background()); err != nil { // consume the immediate slot
+	l := newRateLimiter(1.0/3600, 1)
+	if err := l.wait(context.Background()); err != nil { // consume the immediate token
 		t.Fatalf("first wait: %v", err)
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 	if err := l.wait(ctx); err == nil {
-		t.Error("expected a context error when the next slot is far in the future and ctx is cancelled")
+		t.Error("expected a context error when the next token is far in the future and ctx is cancelled")
+	}
+}
+
+func TestRateLimiter_AIMD(t *testing.T) {
+	l := newRateLimiter(10, 1)
+	l.throttled(0)
+	if got := l.currentRate(); got != 5 {
+		t.Fatalf("rate after a 429 = %v, want 5 (halved)", got)
+	}
+	l.throttled(0) // same volley: within the cooldown, no second cut
+	if got := l.currentRate(); got != 5 {
+		t.Fatalf("rate after a second 429 in the cooldown = %v, want 5", got)
+	}
+	for range 100 {
+		l.succeeded()
+	}
+	if got := l.currentRate(); got != 10 {
+		t.Errorf("rate after recovery = %v, want the ceiling 10", got)
+	}
+}
+
+func TestRateLimiter_AdaptsWithoutCeiling(t *testing.T) {
+	l := newRateLimiter(0, 1)
+	for range 8 {
+		if err := l.wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	l.throttled(0)
+	if got := l.currentRate(); got != 4 {
+		t.Fatalf("rate after a 429 = %v, want half the measured 8 req/s", got)
+	}
+	for range 50 {
+		l.succeeded()
+	}
+	if got := l.currentRate(); got != 0 {
+		t.Errorf("rate after recovery = %v, want unthrottled again", got)
+	}
+}
+
+func TestRateLimiter_RetryAfterPausesEveryone(t *testing.T) {
+	l := newRateLimiter(0, 1)
+	l.throttled(150 * time.Millisecond)
+	start := time.Now()
+	if err := l.wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 120*time.Millisecond {
+		t.Errorf("wait returned after %v, want the Retry-After pause honoured", elapsed)
+	}
+}
+
+// TestDoRequest_429LowersRate: a 429 from the panel throttles the client's limiter,
+// and the retried request's success starts the recovery.
+func TestDoRequest_429LowersRate(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":null}`))
+	}))
+	defer srv.Close()
+	c, err := New(Config{APIBaseURL: srv.URL, APIKeyID: "k", APISecretKey: "s", MaxRPS: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Do(context.Background(), http.MethodGet, "/api/v2/x", nil, nil, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if got := c.limiter.currentRate(); got != 10+aimdIncrease {
+		t.Errorf("rate = %v, want halved to 10 then one recovery step", got)
 	}
 }
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// listCacheTTL is how long a list response is reused. It spans one refresh of a
+// large configuration (Terraform refreshes resources ~10 at a time), and is short
+// enough that a long-running apply does not act on a stale list: every mutating
+// request through this client clears the cache anyway.
+const listCacheTTL = 10 * time.Second
+
+// readCache coalesces list GETs. Identical concurrent calls (same operation,
+// region and project) share one in-flight request, and a successful result is
+// reused for listCacheTTL. Errors are never cached.
+type readCache struct {
+	ttl    time.Duration
+	flight singleflight.Group
+
+	mu      sync.Mutex
+	gen     uint64 // bumped by invalidate; results fetched under an older gen are dropped
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{ttl: ttl, entries: map[string]cacheEntry{}}
+}
+
+// invalidate drops every cached list and detaches in-flight ones, so a read that
+// starts after a mutation never observes the pre-mutation list.
+func (rc *readCache) invalidate() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.gen++
+	clear(rc.entries)
+}
+
+// invalidatesReadCache reports whether a request may change what a list returns.
+func invalidatesReadCache(method string) bool {
+	return method != http.MethodGet && method != http.MethodHead
+}
+
+// cachedList returns the list for op in the request's scope, from the cache when
+// fresh, else through one shared fetch. Callers get their own copy of the slice.
+func cachedList[T any](ctx context.Context, c *Client, op string, opts *RequestOpts, fetch func(context.Context) ([]T, error)) ([]T, error) {
+	rc := c.readCache
+	if rc == nil {
+		return fetch(ctx)
+	}
+	region, projectTag, lang := c.scope(opts)
+	key := strings.Join([]string{op, region, projectTag, lang}, "\x00")
+
+	rc.mu.Lock()
+	if e, ok := rc.entries[key]; ok && time.Now().Before(e.expires) {
+		rc.mu.Unlock()
+		list, _ := e.value.([]T)
+		return append([]T(nil), list...), nil
+	}
+	gen := rc.gen
+	rc.mu.Unlock()
+
+	// The flight key carries the generation so that callers arriving after an
+	// invalidation start a new request instead of joining a stale one.
+	v, err, _ := rc.flight.Do(key+"\x00"+strconv.FormatUint(gen, 10), func() (any, error) {
+		list, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rc.mu.Lock()
+		if rc.gen == gen {
+			rc.entries[key] = cacheEntry{value: list, expires: time.Now().Add(rc.ttl)}
+		}
+		rc.mu.Unlock()
+		return list, nil
+	})
+	if err != nil {
+		// The shared fetch ran under the first caller's context. If that caller gave up
+		// but this one did not, fetch again rather than inherit the cancellation.
+		if (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) && ctx.Err() == nil {
+			return fetch(ctx)
+		}
+		return nil, err
+	}
+	list, _ := v.([]T)
+	return append([]T(nil), list...), nil
+}
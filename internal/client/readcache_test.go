@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// listServer serves GET /api/v2/volumes (counting hits, optionally blocking until
+// release is closed) and accepts POST /api/v2/volumes.
+func listServer(t *testing.T, release <-chan struct{}) (*Client, *atomic.Int32) {
+	t.Helper()
+	var lists atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			lists.Add(1)
+			if release != nil {
+				<-release
+			}
+			if r.Header.Get("X-Region") == "fail" {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"success":false,"errors":[{"code":627,"message":"boom"}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success":true,"data":[{"id":1,"name":"a"},{"id":2,"name":"b"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":{"id":3,"name":"c"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	c, err := New(Config{APIBaseURL: srv.URL, APIKeyID: "k", APISecretKey: "s", Region: "UZ-5"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c, &lists
+}
+
+func TestReadCache_ConcurrentCallsShareOneRequest(t *testing.T) {
+	release := make(chan struct{})
+	c, lists := listServer(t, release)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			vols, err := c.GetVolumes(context.Background(), nil)
+			if err == nil && len(vols) != 2 {
+				t.Errorf("got %d volumes, want 2", len(vols))
+			}
+			errs <- err
+		}()
+	}
+	// Let the callers pile up on the in-flight request before it completes.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("GetVolumes: %v", err)
+		}
+	}
+	if n := lists.Load(); n != 1 {
+		t.Errorf("list requests = %d, want 1", n)
+	}
+}
+
+func TestReadCache_ReusedPerScopeUntilTTL(t *testing.T) {
+	c, lists := listServer(t, nil)
+	ctx := context.Background()
+
+	for range 3 {
+		if _, err := c.GetVolumes(ctx, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := lists.Load(); n != 1 {
+		t.Fatalf("list requests = %d, want 1 within the TTL", n)
+	}
+	if _, err := c.GetVolumes(ctx, &RequestOpts{Region: "KZ-1"}); err != nil {
+		t.Fatal(err)
+	}
+	if n := lists.Load(); n != 2 {
+		t.Fatalf("list requests = %d, want a separate request for another region", n)
+	}
+
+	c.readCache = newReadCache(time.Millisecond)
+	for range 2 {
+		time.Sleep(5 * time.Millisecond)
+		if _, err := c.GetVolumes(ctx, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := lists.Load(); n != 4 {
+		t.Errorf("list requests = %d, want one per expired TTL", n)
+	}
+}
+
+func TestReadCache_MutationInvalidates(t *testing.T) {
+	c, lists := listServer(t, nil)
+	ctx := context.Background()
+
+	if _, err := c.GetVolumes(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateVolume(ctx, CreateVolumeRequest{Name: "c"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetVolumes(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := lists.Load(); n != 2 {
+		t.Errorf("list requests = %d, want the create to force a re-list", n)
+	}
+}
+
+func TestReadCache_ErrorsAreNotCached(t *testing.T) {
+	c, lists := listServer(t, nil)
+	opts := &RequestOpts{Region: "fail"}
+	for range 2 {
+		if _, err := c.GetVolumes(context.Background(), opts); err == nil {
+			t.Fatal("want an error")
+		}
+	}
+	if n := lists.Load(); n != 2 {
+		t.Errorf("list requests = %d, want the failure retried", n)
+	}
+}
+
+func TestReadCache_CallersGetCopies(t *testing.T) {
+	c, _ := listServer(t, nil)
+	first, err := c.GetVolumes(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first[0].Name = "mutated"
+	second, err := c.GetVolumes(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second[0].Name != "a" {
+		t.Errorf("cached list was mutated through a caller's slice: %+v", second)
+	}
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// redacted replaces every scrubbed value in a cassette or the HTTP wire log.
+const redacted = "REDACTED"
+
+// redactedJSONKeys are the JSON body fields whose values are secrets: the VM root
+// password and the Kubernetes cluster SSH key pair. clusterConfigSecret (the
+// kubeconfig) is handled separately so that its non-secret fields survive.
+var redactedJSONKeys = map[string]bool{
+	"password":          true,
+	"sshKeyEncoded":     true,
+	"privateKeyEncoded": true,
+}
+
+// redactedKubeconfigKeys are the kubeconfig YAML keys holding credentials.
+var redactedKubeconfigKeys = map[string]bool{
+	"client-key-data": true,
+	"token":           true,
+	"password":        true,
+}
+
+// redactJSON scrubs secret values from a JSON body. A body that is not JSON, or
+// holds no secret, is returned unchanged byte for byte.
+func redactJSON(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if dec.Decode(&v) != nil {
+		return body
+	}
+	if !redactValue(v) {
+		return body
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// redactValue scrubs v in place and reports whether anything was redacted.
+func redactValue(v any) bool {
+	changed := false
+	switch x := v.(type) {
+	case map[string]any:
+		for k, val := range x {
+			s, isString := val.(string)
+			switch {
+			case isString && s != "" && redactedJSONKeys[k]:
+				x[k] = redacted
+				changed = true
+			case isString && s != "" && k == "clusterConfigSecret":
+				x[k] = redactKubeconfig(s)
+				changed = true
+			default:
+				if redactValue(val) {
+					changed = true
+				}
+			}
+		}
+	case []any:
+		for _, val := range x {
+			if redactValue(val) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// redactKubeconfig scrubs the credentials from a (base64) kubeconfig and returns
+// it in the same encoding. The server, CA and client certificate are kept, so a
+// replayed cluster still parses into a kube_config with a host. A secret that is
+// not YAML is redacted whole.
+func redactKubeconfig(secret string) string {
+	raw := decodeMaybeBase64(secret)
+	encoded := raw != nil
+	if !encoded {
+		raw = []byte(secret)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil || len(doc.Content) == 0 {
+		return redacted
+	}
+	redactYAML(&doc)
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return redacted
+	}
+	if encoded {
+		return base64.StdEncoding.EncodeToString(out)
+	}
+	return string(out)
+}
+
+func redactYAML(n *yaml.Node) {
+	if n.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if redactedKubeconfigKeys[n.Content[i].Value] && n.Content[i+1].Kind == yaml.ScalarNode {
+				n.Content[i+1].Value = redacted
+				n.Content[i+1].Tag = "!!str"
+				continue
+			}
+			redactYAML(n.Content[i+1])
+		}
+		return
+	}
+	for _, c := range n.Content {
+		redactYAML(c)
+	}
+}
+
+// redactedHeaders are the request and response headers whose values are secrets.
+var redactedHeaders = map[string]bool{
+	"X-Api-Secret":  true,
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// redactHeaders flattens h for logging, with secret values replaced.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if redactedHeaders[http.CanonicalHeaderKey(k)] {
+			out[k] = redacted
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
@@ -15,6 +15,104 @@ const (
 	retryMaxInterval     = 15 * time.Second
 )
 
+// RetryPolicy overrides the client's retry tuning, from the provider's retry
+// block. A zero field keeps the built-in default of each retry loop, so the
+// zero value behaves exactly as before the block existed.
+type RetryPolicy struct {
+	// MaxAttempts bounds the attempts of one request, the first included, when
+	// it is rate limited (HTTP 429) or hits a transport error. Defaults to
+	// rateLimitMaxRetries+1 and transportMaxRetries+1 respectively.
+	MaxAttempts int
+	// BaseBackoff is the first wait between those attempts; it doubles each
+	// attempt. A Retry-After header still wins for HTTP 429.
+	BaseBackoff time.Duration
+	// MaxBackoff caps a single wait between attempts.
+	MaxBackoff time.Duration
+	// BusyTimeout is how long RetryOnBusy callers keep retrying an HTTP 503
+	// (no capacity). Each call site otherwise picks RetryTimeoutShort or
+	// RetryTimeoutLong.
+	BusyTimeout time.Duration
+	// PollErrorTolerance is how many consecutive polling errors a status wait
+	// tolerates before failing. Each wait otherwise has its own default.
+	PollErrorTolerance int
+	// BreakerThreshold is how many consecutive failed requests open the
+	// circuit breaker; BreakerCooldown is how long it then stays open before a
+	// probe. Default to breakerThreshold and breakerCooldown.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// BusyTimeoutOr returns the policy's 503 retry budget, or d when it is unset.
+func (p RetryPolicy) BusyTimeoutOr(d time.Duration) time.Duration {
+	if p.BusyTimeout > 0 {
+		return p.BusyTimeout
+	}
+	return d
+}
+
+// PollErrorToleranceOr returns the policy's polling error tolerance, or n when
+// it is unset.
+func (p RetryPolicy) PollErrorToleranceOr(n int) int {
+	if p.PollErrorTolerance > 0 {
+		return p.PollErrorTolerance
+	}
+	return n
+}
+
+// rateLimitRetries is how many times a request is retried after an HTTP 429.
+func (p RetryPolicy) rateLimitRetries() int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts - 1
+	}
+	return rateLimitMaxRetries
+}
+
+// transportRetries is how many times a request is retried after a transport error.
+func (p RetryPolicy) transportRetries() int {
+	if p.MaxAttempts > 0 {
+		return p.MaxAttempts - 1
+	}
+	return transportMaxRetries
+}
+
+// rateLimitBackoff returns the first and the largest wait between HTTP 429 retries.
+func (p RetryPolicy) rateLimitBackoff() (base, ceiling time.Duration) {
+	base, ceiling = rateLimitBaseDelay, rateLimitMaxDelay
+	if p.BaseBackoff > 0 {
+		base = p.BaseBackoff
+	}
+	if p.MaxBackoff > 0 {
+		ceiling = p.MaxBackoff
+	}
+	return base, ceiling
+}
+
+// transportBackoff returns the wait before transport retry n (0-based):
+// transportRetryBase (or BaseBackoff) doubled n times, capped at MaxBackoff
+// when one is set.
+func (p RetryPolicy) transportBackoff(n int) time.Duration {
+	base := transportRetryBase
+	if p.BaseBackoff > 0 {
+		base = p.BaseBackoff
+	}
+	wait := base << n
+	if p.MaxBackoff > 0 && (wait > p.MaxBackoff || wait <= 0) {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+// Timeouts are provider-wide operation timeouts (the default_timeouts block).
+// A resource with a timeouts attribute uses them when its own block leaves the
+// operation unset; other resources bound every operation by them. A zero field
+// keeps the resource's built-in default, or no deadline.
+type Timeouts struct {
+	Create time.Duration
+	Read   time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
 // RetryOnBusy retries fn while it returns a genuinely transient API error —
 // an HTTP 503 (Service Unavailable), e.g. the panel's "no compute capacity"
 // (744) or "no IP pool in region" (743) — using exponential backoff with ±25%
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 )
@@ -177,3 +178,33 @@ func TestRetryOnBusy_ExponentialBackoff(t *testing.T) {
 		t.Errorf("expected exponential backoff: gap2 (%v) should be > gap1 (%v)", gap2, gap1)
 	}
 }
+
+func TestRetryPolicy_Defaults(t *testing.T) {
+	var zero RetryPolicy
+	if zero.rateLimitRetries() != rateLimitMaxRetries || zero.transportRetries() != transportMaxRetries {
+		t.Errorf("zero policy retries = %d/%d, want the built-in %d/%d",
+			zero.rateLimitRetries(), zero.transportRetries(), rateLimitMaxRetries, transportMaxRetries)
+	}
+	if zero.BusyTimeoutOr(RetryTimeoutLong) != RetryTimeoutLong || zero.PollErrorToleranceOr(3) != 3 {
+		t.Error("zero policy should keep the call site's busy timeout and poll tolerance")
+	}
+	if got := zero.transportBackoff(2); got != 4*transportRetryBase {
+		t.Errorf("zero policy transportBackoff(2) = %v, want %v", got, 4*transportRetryBase)
+	}
+
+	p := RetryPolicy{MaxAttempts: 4, BaseBackoff: time.Second, MaxBackoff: 3 * time.Second, BusyTimeout: time.Minute, PollErrorTolerance: 7}
+	if p.rateLimitRetries() != 3 || p.transportRetries() != 3 {
+		t.Errorf("retries = %d/%d, want 3/3", p.rateLimitRetries(), p.transportRetries())
+	}
+	if p.BusyTimeoutOr(RetryTimeoutLong) != time.Minute || p.PollErrorToleranceOr(3) != 7 {
+		t.Error("policy busy timeout and poll tolerance should override the call site's")
+	}
+	if got := p.transportBackoff(5); got != 3*time.Second {
+		t.Errorf("transportBackoff(5) = %v, want the 3s max_backoff", got)
+	}
+	for attempt := range 6 {
+		if got := p.retryAfterDelay(http.Header{}, attempt); got > 3*time.Second {
+			t.Errorf("retryAfterDelay(attempt %d) = %v, exceeds max_backoff", attempt, got)
+		}
+	}
+}
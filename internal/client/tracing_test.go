@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestDoRequest_Spans: a request throttled once produces a request span with two
+// attempt spans and a rate_limited backoff span between them, all its children.
+func TestDoRequest_Spans(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":null}`))
+	}))
+	defer srv.Close()
+	c, err := New(Config{APIBaseURL: srv.URL, APIKeyID: "k", APISecretKey: "s", Region: "UZ-5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Do(context.Background(), http.MethodGet, "/api/v2/vms/7?x=1", nil, nil, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+
+	byName := map[string][]sdktrace.ReadOnlySpan{}
+	for _, s := range rec.Ended() {
+		byName[s.Name()] = append(byName[s.Name()], s)
+	}
+	if len(byName["prodata.api GET"]) != 1 || len(byName["prodata.api.attempt"]) != 2 || len(byName["prodata.api.backoff"]) != 1 {
+		t.Fatalf("spans = %v, want 1 request, 2 attempts, 1 backoff", byName)
+	}
+	reqSpan := byName["prodata.api GET"][0]
+	attrs := attribute.NewSet(reqSpan.Attributes()...)
+	if v, _ := attrs.Value("url.path"); v.AsString() != "/api/v2/vms/7" {
+		t.Errorf("url.path = %q, want the path without its query", v.AsString())
+	}
+	if v, _ := attrs.Value(attrAttempts); v.AsInt64() != 2 {
+		t.Errorf("attempts = %d, want 2", v.AsInt64())
+	}
+	if v, _ := attrs.Value("http.response.status_code"); v.AsInt64() != 200 {
+		t.Errorf("status = %d, want 200", v.AsInt64())
+	}
+	backoffAttrs := attribute.NewSet(byName["prodata.api.backoff"][0].Attributes()...)
+	if v, _ := backoffAttrs.Value(attrBackoffReason); v.AsString() != "rate_limited" {
+		t.Errorf("backoff reason = %q, want rate_limited", v.AsString())
+	}
+	for _, s := range append(byName["prodata.api.attempt"], byName["prodata.api.backoff"]...) {
+		if s.Parent().SpanID() != reqSpan.SpanContext().SpanID() {
+			t.Errorf("%s is not a child of the request span", s.Name())
+		}
+	}
+}
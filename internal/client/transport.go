@@ -0,0 +1,105 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// TransportConfig customises how the client reaches the panel: through a proxy,
+// trusting a private CA, and presenting a client certificate. The zero value
+// uses http.DefaultTransport (system roots, proxy from HTTPS_PROXY/NO_PROXY).
+type TransportConfig struct {
+	// CABundle is PEM data with extra CA certificates to trust, on top of the
+	// system roots.
+	CABundle []byte
+	// ClientCertificate and ClientKey are the PEM certificate chain and private
+	// key presented for mutual TLS. Both or neither must be set.
+	ClientCertificate []byte
+	ClientKey         []byte
+	// ProxyURL, when set, sends every request through this proxy instead of the
+	// one named by the proxy environment variables.
+	ProxyURL string
+	// MinTLSVersion is the lowest TLS version accepted (tls.VersionTLS12 or
+	// tls.VersionTLS13). 0 means TLS 1.2.
+	MinTLSVersion uint16
+}
+
+func (tc TransportConfig) isZero() bool {
+	return len(tc.CABundle) == 0 && len(tc.ClientCertificate) == 0 && len(tc.ClientKey) == 0 &&
+		tc.ProxyURL == "" && tc.MinTLSVersion == 0
+}
+
+// newTransport builds the http.RoundTripper for tc. It clones
+// http.DefaultTransport so the connection pooling and timeouts stay the same.
+func newTransport(tc TransportConfig) (http.RoundTripper, error) {
+	if tc.isZero() {
+		return http.DefaultTransport, nil
+	}
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("http.DefaultTransport is a %T, not an *http.Transport", http.DefaultTransport)
+	}
+	t := base.Clone()
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if tc.MinTLSVersion != 0 {
+		tlsConfig.MinVersion = tc.MinTLSVersion
+	}
+	if len(tc.CABundle) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(tc.CABundle) {
+			return nil, fmt.Errorf("ca_bundle: no PEM certificates found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if len(tc.ClientCertificate) > 0 || len(tc.ClientKey) > 0 {
+		if len(tc.ClientCertificate) == 0 || len(tc.ClientKey) == 0 {
+			return nil, fmt.Errorf("client_certificate and client_key must be set together")
+		}
+		cert, err := tls.X509KeyPair(tc.ClientCertificate, tc.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("client_certificate/client_key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	t.TLSClientConfig = tlsConfig
+
+	if tc.ProxyURL != "" {
+		u, err := url.Parse(tc.ProxyURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("proxy_url %q: must be an absolute URL such as http://proxy.example:3128", tc.ProxyURL)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+	return t, nil
+}
+
+// isTLSSetupError reports whether err is a TLS handshake failure caused by
+// configuration — an untrusted or invalid certificate, a missing client
+// certificate, no common TLS version — which a retry cannot fix.
+func isTLSSetupError(err error) bool {
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+		recordErr    tls.RecordHeaderError
+		opErr        *net.OpError
+	)
+	if errors.As(err, &verifyErr) || errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr) || errors.As(err, &recordErr) {
+		return true
+	}
+	// crypto/tls reports a TLS alert, sent or received (e.g. "certificate
+	// required", "protocol version not supported"), as a net.OpError with
+	// these ops.
+	return errors.As(err, &opErr) && (opErr.Op == "remote error" || opErr.Op == "local error")
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // flakyRoundTripper returns a transport error for its first `failures` calls, then a
@@ -84,3 +87,148 @@ func TestDoRequest_GivesUpAfterMaxTransportRetries(t *testing.T) {
 		t.Errorf("calls = %d, want %d (initial + %d retries)", rt.calls, transportMaxRetries+1, transportMaxRetries)
 	}
 }
+
+// TestDoRequest_RetryPolicyMaxAttempts: the retry block's max_attempts bounds
+// transport retries, and its backoff replaces the built-in one.
+func TestDoRequest_RetryPolicyMaxAttempts(t *testing.T) {
+	rt := &flakyRoundTripper{failures: 99, status: 200, body: `{}`}
+	c := newClientWithTransport(t, rt)
+	c.Retry = RetryPolicy{MaxAttempts: 2, BaseBackoff: time.Millisecond}
+	start := time.Now()
+	if _, _, err := c.doRequest(context.Background(), http.MethodGet, "/api/v2/x", nil, nil); err == nil {
+		t.Fatal("expected error after exhausting transport retries")
+	}
+	if rt.calls != 2 {
+		t.Errorf("calls = %d, want 2 (max_attempts)", rt.calls)
+	}
+	if d := time.Since(start); d > 400*time.Millisecond {
+		t.Errorf("took %v, want the 1ms base backoff rather than the built-in 500ms", d)
+	}
+}
+
+// keyEchoRoundTripper records the Idempotency-Key of every call, fails the calls
+// listed in fail with a transport error and, when echo is set, answers like a panel
+// that honours idempotency keys by echoing the key back.
+type keyEchoRoundTripper struct {
+	echo  bool
+	fail  map[int]bool
+	calls int
+	keys  []string
+}
+
+func (k *keyEchoRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	k.calls++
+	key := r.Header.Get(idempotencyKeyHeader)
+	if key == "" {
+		// Not yet known to be honoured: see setIdempotencyKey.
+		key = strings.Join(r.Header[strings.ToLower(idempotencyKeyHeader)], "")
+	}
+	k.keys = append(k.keys, key)
+	if k.fail[k.calls] {
+		return nil, fmt.Errorf("simulated connection reset")
+	}
+	h := make(http.Header)
+	if k.echo && key != "" {
+		h.Set(idempotencyKeyHeader, key)
+	}
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(strings.NewReader(`{"success":true,"data":null}`)),
+		Header:     h,
+	}, nil
+}
+
+// TestDoRequest_IdempotencyKeyOnMutations: every mutating request carries its own
+// key; reads carry none.
+func TestDoRequest_IdempotencyKeyOnMutations(t *testing.T) {
+	rt := &keyEchoRoundTripper{}
+	c := newClientWithTransport(t, rt)
+	for _, m := range []string{http.MethodGet, http.MethodPost, http.MethodPost, http.MethodDelete} {
+		if _, _, err := c.doRequest(context.Background(), m, "/api/v2/x", nil, nil); err != nil {
+			t.Fatalf("%s: %v", m, err)
+		}
+	}
+	if rt.keys[0] != "" {
+		t.Errorf("GET carried Idempotency-Key %q", rt.keys[0])
+	}
+	if len(rt.keys[1]) != 36 || rt.keys[1] == rt.keys[2] || rt.keys[3] == "" {
+		t.Errorf("keys = %q, want a distinct UUID per mutating request", rt.keys[1:])
+	}
+}
+
+// TestDoRequest_RetriesPostOnceKeysAreHonoured: after the panel has echoed a key, a
+// POST that hits a transport error is re-sent with the same key.
+func TestDoRequest_RetriesPostOnceKeysAreHonoured(t *testing.T) {
+	rt := &keyEchoRoundTripper{echo: true, fail: map[int]bool{2: true}}
+	c := newClientWithTransport(t, rt)
+	for range 2 {
+		if _, _, err := c.doRequest(context.Background(), http.MethodPost, "/api/v2/x", nil, nil); err != nil {
+			t.Fatalf("POST: %v", err)
+		}
+	}
+	if rt.calls != 3 {
+		t.Fatalf("calls = %d, want 3 (ok, fail, retry)", rt.calls)
+	}
+	if rt.keys[1] != rt.keys[2] || rt.keys[0] == rt.keys[1] {
+		t.Errorf("keys = %q, want the retry to reuse its request's key", rt.keys)
+	}
+}
+
+// TestDoRequest_NoPostRetryWithoutEcho: a panel that ignores the key never echoes
+// it, so a POST keeps today's no-retry behaviour.
+func TestDoRequest_NoPostRetryWithoutEcho(t *testing.T) {
+	rt := &keyEchoRoundTripper{fail: map[int]bool{2: true}}
+	c := newClientWithTransport(t, rt)
+	if _, _, err := c.doRequest(context.Background(), http.MethodPost, "/api/v2/x", nil, nil); err != nil {
+		t.Fatalf("first POST: %v", err)
+	}
+	if _, _, err := c.doRequest(context.Background(), http.MethodPost, "/api/v2/x", nil, nil); err == nil {
+		t.Fatal("expected the second POST to fail without a retry")
+	}
+	if rt.calls != 2 {
+		t.Errorf("calls = %d, want 2 (no retry)", rt.calls)
+	}
+}
+
+// TestDoRequest_KeyedPostNotReplayedOnDroppedConnection: net/http re-sends a
+// request with an Idempotency-Key header by itself when a reused keep-alive
+// connection closes before answering. Against a panel not known to honour the
+// key, a POST dropped that way must still reach the panel only once.
+func TestDoRequest_KeyedPostNotReplayedOnDroppedConnection(t *testing.T) {
+	var posts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"success":true,"data":null}`))
+			return
+		}
+		_, _ = io.ReadAll(r.Body)
+		posts.Add(1)
+		if r.Header.Get(idempotencyKeyHeader) == "" {
+			t.Error("POST arrived without its Idempotency-Key")
+		}
+		// Drop the connection without answering, as a panel restarting would.
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		_ = conn.Close()
+	}))
+	t.Cleanup(srv.Close)
+	c, err := New(Config{APIBaseURL: srv.URL, APIKeyID: "k", APISecretKey: "s"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	// The GET leaves an idle keep-alive connection for the POST to reuse.
+	if _, _, err := c.doRequest(context.Background(), http.MethodGet, "/api/v2/x", nil, nil); err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	body := map[string]string{"name": "vm"}
+	if _, _, err := c.doRequest(context.Background(), http.MethodPost, "/api/v2/x", body, nil); err == nil {
+		t.Fatal("expected the dropped POST to fail")
+	}
+	if n := posts.Load(); n != 1 {
+		t.Errorf("POST arrived %d times, want 1", n)
+	}
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const okBody = `{"success":true,"data":null}`
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(okBody))
+	})
+}
+
+// serverCAPEM returns the PEM of an httptest TLS server's self-signed certificate.
+func serverCAPEM(srv *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+}
+
+// newClientCert returns a self-signed client certificate and key as PEM.
+func newClientCert(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func newTransportClient(t *testing.T, baseURL string, tc TransportConfig) *Client {
+	t.Helper()
+	c, err := New(Config{APIBaseURL: baseURL, APIKeyID: "k", APISecretKey: "s", Transport: tc})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+func TestTransport_CABundle(t *testing.T) {
+	srv := httptest.NewTLSServer(okHandler())
+	defer srv.Close()
+
+	untrusted := newTransportClient(t, srv.URL, TransportConfig{})
+	if err := untrusted.Do(context.Background(), http.MethodGet, "/x", nil, nil, nil); err == nil {
+		t.Fatal("request to a server with a private CA succeeded without ca_bundle")
+	}
+
+	trusted := newTransportClient(t, srv.URL, TransportConfig{CABundle: serverCAPEM(srv)})
+	if err := trusted.Do(context.Background(), http.MethodGet, "/x", nil, nil, nil); err != nil {
+		t.Fatalf("request with ca_bundle: %v", err)
+	}
+}
+
+func TestTransport_ClientCertificate(t *testing.T) {
+	certPEM, keyPEM := newClientCert(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	srv := httptest.NewUnstartedServer(okHandler())
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	srv.StartTLS()
+	defer srv.Close()
+
+	noCert := newTransportClient(t, srv.URL, TransportConfig{CABundle: serverCAPEM(srv)})
+	if err := noCert.Do(context.Background(), http.MethodGet, "/x", nil, nil, nil); err == nil {
+		t.Fatal("request without a client certificate succeeded against a server requiring one")
+	}
+
+	withCert := newTransportClient(t, srv.URL, TransportConfig{
+		CABundle: serverCAPEM(srv), ClientCertificate: certPEM, ClientKey: keyPEM,
+	})
+	if err := withCert.Do(context.Background(), http.MethodGet, "/x", nil, nil, nil); err != nil {
+		t.Fatalf("request with a client certificate: %v", err)
+	}
+}
+
+func TestTransport_MinTLSVersion(t *testing.T) {
+	srv := httptest.NewUnstartedServer(okHandler())
+	srv.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	tls12 := newTransportClient(t, srv.URL, TransportConfig{CABundle: serverCAPEM(srv)})
+	if err := tls12.Do(context.Background(), http.MethodGet, "/x", nil, nil, nil); err != nil {
+		t.Fatalf("TLS 1.2 request: %v", err)
+	}
+	tls13 := newTransportClient(t, srv.URL, TransportConfig{CABundle: serverCAPEM(srv), MinTLSVersion: tls.VersionTLS13})
+	if err := tls13.Do(context.Background(), http.MethodGet, "/x", nil, nil, nil); err == nil {
+		t.Fatal("request requiring TLS 1.3 succeeded against a TLS 1.2 server")
+	}
+}
+
+func TestTransport_ProxyURL(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL.
+		if r.URL.Host == "panel.invalid" {
+			proxied.Add(1)
+		}
+		_, _ = w.Write([]byte(okBody))
+	}))
+	defer proxy.Close()
+
+	c := newTransportClient(t, "http://panel.invalid", TransportConfig{ProxyURL: proxy.URL})
+	if err := c.Do(context.Background(), http.MethodGet, "/x", nil, nil, nil); err != nil {
+		t.Fatalf("request through proxy: %v", err)
+	}
+	if proxied.Load() != 1 {
+		t.Errorf("proxy saw %d requests for the panel, want 1", proxied.Load())
+	}
+}
+
+func TestNewTransport_InvalidConfig(t *testing.T) {
+	certPEM, keyPEM := newClientCert(t)
+	cases := []struct {
+		name string
+		tc   TransportConfig
+		want string
+	}{
+		{"no PEM in bundle", TransportConfig{CABundle: []byte("not a certificate")}, "no PEM certificates"},
+		{"certificate without key", TransportConfig{ClientCertificate: certPEM}, "must be set together"},
+		{"mismatched pair", TransportConfig{ClientCertificate: certPEM, ClientKey: []byte("junk")}, "client_key"},
+		{"relative proxy", TransportConfig{ProxyURL: "proxy:3128"}, "absolute URL"},
+	}
+	for _, tc := range cases {
+		if _, err := newTransport(tc.tc); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want it to mention %q", tc.name, err, tc.want)
+		}
+	}
+	if _, err := newTransport(TransportConfig{ClientCertificate: certPEM, ClientKey: keyPEM}); err != nil {
+		t.Errorf("valid pair: %v", err)
+	}
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"terraform-provider-prodata/internal/telemetry"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Poll settings shared by the VM waits (WaitForVmStatus and the VM resource's
+// create wait). VM transitions take seconds, so polls start quickly.
+const (
+	VmWaitMinTimeout           = 2 * time.Second
+	VmWaitMaxPollInterval      = 5 * time.Second
+	VmWaitMaxConsecutiveErrors = 2
+)
+
+const (
+	// defaultWaitMinTimeout is the first wait between polls when MinTimeout is unset.
+	defaultWaitMinTimeout = time.Second
+	// waitIntervalFactor is how much the wait between polls grows after each poll.
+	waitIntervalFactor = 2
+)
+
+// Waiter polls an object until it reaches a target state. It is the one polling
+// loop behind every wait in the provider: VM power transitions, VM create, load
+// balancer, Kubernetes cluster and node pool convergence, and the Kubernetes
+// lookups that retry until a pool id or a read-back comes through.
+//
+// Each poll calls Refresh, which returns the object and its state. Then:
+//
+//   - A state in Target ends the wait successfully, once Ready (if set) also
+//     holds. Otherwise polling continues.
+//   - A state in Failure ends the wait with an *UnexpectedStateError.
+//   - With Pending set, any other state also ends the wait with an
+//     *UnexpectedStateError. With Pending nil, any other state keeps polling.
+//   - A Refresh error for which NotFound returns true ends the wait. It is a
+//     success with TargetNotFound (a delete), else a "disappeared" error.
+//   - Any other Refresh error is tolerated up to MaxConsecutiveErrors times in a
+//     row.
+//
+// The wait between polls starts at MinTimeout and doubles after each poll, up to
+// MaxPollInterval (MaxPollInterval below MinTimeout means a fixed MinTimeout).
+// The wait is bounded by ctx and, when set, Timeout; running out of either
+// returns a *WaitTimeoutError.
+type Waiter[T any] struct {
+	// Name is the object in messages, e.g. "VM 12".
+	Name string
+	// Op identifies the wait in logs and traces (span "prodata.wait.<Op>"), e.g. "vm_ready".
+	Op string
+	// ID is the object id, for logs and traces.
+	ID int64
+
+	Refresh func(ctx context.Context) (obj T, state string, err error)
+
+	Pending []string
+	Target  []string
+	Failure []string
+	// Ready, when set, must also hold for a Target state to end the wait. It is
+	// how a wait checks convergence beyond the status (e.g. a resize applied).
+	Ready func(obj T, state string) bool
+
+	NotFound       func(error) bool
+	TargetNotFound bool
+
+	MaxConsecutiveErrors int
+
+	MinTimeout      time.Duration
+	MaxPollInterval time.Duration
+	Timeout         time.Duration
+}
+
+// UnexpectedStateError reports that a waited-on object reached a failure state,
+// or a state the wait did not expect.
+type UnexpectedStateError struct {
+	Name     string
+	State    string
+	Expected []string
+}
+
+func (e *UnexpectedStateError) Error() string {
+	return fmt.Sprintf("%s reached unexpected state %s (waiting for %s)", e.Name, e.State, strings.Join(e.Expected, ", "))
+}
+
+// WaitTimeoutError reports that a wait ran out of time. It unwraps to the
+// context error (context.DeadlineExceeded or context.Canceled).
+type WaitTimeoutError struct {
+	Name      string
+	Expected  []string
+	LastState string
+	Err       error
+}
+
+func (e *WaitTimeoutError) Error() string {
+	last := e.LastState
+	if last == "" {
+		last = "unknown"
+	}
+	return fmt.Sprintf("timed out waiting for %s to reach %s (last state %s): %v", e.Name, strings.Join(e.Expected, ", "), last, e.Err)
+}
+
+func (e *WaitTimeoutError) Unwrap() error { return e.Err }
+
+// Wait polls until the object settles, and returns the last object Refresh
+// returned without error (the zero T if there was none), also on failure.
+func (w *Waiter[T]) Wait(ctx context.Context) (last T, err error) {
+	if w.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.Timeout)
+		defer cancel()
+	}
+	ctx, poll := telemetry.StartPoll(ctx, w.Op,
+		telemetry.AttrResourceID.Int64(w.ID), telemetry.AttrPollTarget.String(strings.Join(w.Target, ",")))
+	defer func() { poll.End(err) }()
+
+	interval := w.MinTimeout
+	if interval <= 0 {
+		interval = defaultWaitMinTimeout
+	}
+	start := time.Now()
+	lastState := ""
+	consecutiveErrs := 0
+
+	for n := 1; ; n++ {
+		obj, state, rerr := w.Refresh(ctx)
+		switch {
+		case rerr == nil:
+			consecutiveErrs = 0
+			last = obj
+			poll.Iteration(state)
+			fields := map[string]any{"op": w.Op, "id": w.ID, "state": state, "poll": n, "elapsed": time.Since(start).Round(time.Second).String()}
+			if state != lastState && lastState != "" {
+				fields["previous_state"] = lastState
+				tflog.Info(ctx, "Waiting for "+w.Name+": state changed", fields)
+			} else {
+				tflog.Debug(ctx, "Waiting for "+w.Name, fields)
+			}
+			lastState = state
+
+			switch {
+			case slices.Contains(w.Target, state):
+				if w.Ready == nil || w.Ready(obj, state) {
+					return last, nil
+				}
+			case slices.Contains(w.Failure, state),
+				w.Pending != nil && !slices.Contains(w.Pending, state):
+				return last, &UnexpectedStateError{Name: w.Name, State: state, Expected: w.Target}
+			}
+		case w.NotFound != nil && w.NotFound(rerr):
+			poll.Iteration("")
+			if w.TargetNotFound {
+				return last, nil
+			}
+			return last, fmt.Errorf("%s disappeared while waiting: %w", w.Name, rerr)
+		case ctx.Err() != nil:
+			poll.Iteration("")
+			return last, &WaitTimeoutError{Name: w.Name, Expected: w.Target, LastState: lastState, Err: ctx.Err()}
+		default:
+			poll.Iteration("")
+			consecutiveErrs++
+			tflog.Warn(ctx, "Transient error waiting for "+w.Name, map[string]any{
+				"op": w.Op, "id": w.ID, "error": rerr.Error(), "consecutive_errors": consecutiveErrs,
+			})
+			if consecutiveErrs > w.MaxConsecutiveErrors {
+				return last, fmt.Errorf("polling %s failed after %d consecutive errors: %w", w.Name, consecutiveErrs, rerr)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, &WaitTimeoutError{Name: w.Name, Expected: w.Target, LastState: lastState, Err: ctx.Err()}
+		case <-time.After(interval):
+		}
+		if interval < w.MaxPollInterval {
+			interval = min(interval*waitIntervalFactor, w.MaxPollInterval)
+		}
+	}
+}
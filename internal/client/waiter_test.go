@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var errGone = errors.New("gone")
+
+// scripted returns a Refresh that yields the given states in order, repeating the
+// last one. A state "!err" yields a transient error and "!gone" yields errGone.
+func scripted(states ...string) (func(context.Context) (int, string, error), *int) {
+	calls := 0
+	return func(context.Context) (int, string, error) {
+		s := states[min(calls, len(states)-1)]
+		calls++
+		switch s {
+		case "!err":
+			return 0, "", errors.New("boom")
+		case "!gone":
+			return 0, "", errGone
+		}
+		return calls, s, nil
+	}, &calls
+}
+
+func testWaiter(refresh func(context.Context) (int, string, error)) *Waiter[int] {
+	return &Waiter[int]{
+		Name:            "thing 1",
+		Op:              "test",
+		Refresh:         refresh,
+		Target:          []string{"READY"},
+		Failure:         []string{"ERROR"},
+		NotFound:        func(err error) bool { return errors.Is(err, errGone) },
+		MinTimeout:      time.Millisecond,
+		MaxPollInterval: 2 * time.Millisecond,
+	}
+}
+
+func TestWaiter_ReachesTarget(t *testing.T) {
+	refresh, calls := scripted("BUILDING", "BUILDING", "READY")
+	last, err := testWaiter(refresh).Wait(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *calls != 3 || last != 3 {
+		t.Errorf("calls = %d, last = %d, want 3 and 3", *calls, last)
+	}
+}
+
+func TestWaiter_FailureState(t *testing.T) {
+	refresh, _ := scripted("BUILDING", "ERROR")
+	last, err := testWaiter(refresh).Wait(context.Background())
+	var stateErr *UnexpectedStateError
+	if !errors.As(err, &stateErr) || stateErr.State != "ERROR" {
+		t.Fatalf("err = %v, want an UnexpectedStateError for ERROR", err)
+	}
+	if last != 2 {
+		t.Errorf("last = %d, want the object read in the failure state", last)
+	}
+}
+
+func TestWaiter_UnexpectedStateWithPending(t *testing.T) {
+	refresh, _ := scripted("BUILDING", "PAUSED")
+	w := testWaiter(refresh)
+	w.Pending = []string{"BUILDING"}
+	_, err := w.Wait(context.Background())
+	var stateErr *UnexpectedStateError
+	if !errors.As(err, &stateErr) || stateErr.State != "PAUSED" {
+		t.Fatalf("err = %v, want an UnexpectedStateError for PAUSED", err)
+	}
+}
+
+func TestWaiter_NotFound(t *testing.T) {
+	refresh, _ := scripted("BUILDING", "!gone")
+	_, err := testWaiter(refresh).Wait(context.Background())
+	if !errors.Is(err, errGone) || !strings.Contains(err.Error(), "disappeared") {
+		t.Fatalf("err = %v, want a disappeared error wrapping errGone", err)
+	}
+
+	refresh, _ = scripted("DELETING", "!gone")
+	w := testWaiter(refresh)
+	w.TargetNotFound = true
+	if _, err := w.Wait(context.Background()); err != nil {
+		t.Fatalf("TargetNotFound: unexpected error: %v", err)
+	}
+}
+
+func TestWaiter_ToleratesTransientErrors(t *testing.T) {
+	refresh, calls := scripted("!err", "!err", "READY")
+	w := testWaiter(refresh)
+	w.MaxConsecutiveErrors = 2
+	if _, err := w.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("calls = %d, want 3", *calls)
+	}
+
+	refresh, calls = scripted("BUILDING", "!err", "!err", "!err")
+	w = testWaiter(refresh)
+	w.MaxConsecutiveErrors = 2
+	_, err := w.Wait(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "3 consecutive errors") {
+		t.Fatalf("err = %v, want a consecutive errors failure", err)
+	}
+	if *calls != 4 {
+		t.Errorf("calls = %d, want 4", *calls)
+	}
+}
+
+func TestWaiter_Timeout(t *testing.T) {
+	refresh, _ := scripted("BUILDING")
+	w := testWaiter(refresh)
+	w.Timeout = 20 * time.Millisecond
+	_, err := w.Wait(context.Background())
+	var timeoutErr *WaitTimeoutError
+	if !errors.As(err, &timeoutErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want a WaitTimeoutError wrapping DeadlineExceeded", err)
+	}
+	if timeoutErr.LastState != "BUILDING" {
+		t.Errorf("last state = %q, want BUILDING", timeoutErr.LastState)
+	}
+}
+
+func TestWaiter_ReadyGatesTarget(t *testing.T) {
+	refresh, calls := scripted("READY")
+	w := testWaiter(refresh)
+	w.Ready = func(n int, _ string) bool { return n >= 3 }
+	if _, err := w.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("calls = %d, want 3 (target reached twice before Ready held)", *calls)
+	}
+}
+
+func TestWaiter_IntervalGrows(t *testing.T) {
+	var at []time.Time
+	w := &Waiter[int]{
+		Name: "thing 1",
+		Refresh: func(context.Context) (int, string, error) {
+			at = append(at, time.Now())
+			if len(at) == 4 {
+				return 0, "READY", nil
+			}
+			return 0, "BUILDING", nil
+		},
+		Target:          []string{"READY"},
+		MinTimeout:      20 * time.Millisecond,
+		MaxPollInterval: 50 * time.Millisecond,
+	}
+	if _, err := w.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Waits of 20ms, 40ms, then capped at 50ms.
+	for i, want := range []time.Duration{20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond} {
+		if got := at[i+1].Sub(at[i]); got < want {
+			t.Errorf("wait %d = %v, want at least %v", i+1, got, want)
+		}
+	}
+}
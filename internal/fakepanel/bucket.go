@@ -0,0 +1,282 @@
+package fakepanel
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// ---- wire types (storage / S3 buckets) ----
+
+type bucket struct {
+	Name         string `json:"name"`
+	CreationDate string `json:"creationDate"`
+	Size         int64  `json:"size"`
+	ObjectCount  int64  `json:"objectCount"`
+
+	scope      scope
+	acl        string
+	versioning *versioningConfiguration
+	objectLock bool
+}
+
+type versioningConfiguration struct {
+	Status string `json:"status"`
+}
+
+type objectLockConfiguration struct {
+	ObjectLockEnabled string `json:"objectLockEnabled"`
+}
+
+const bucketsPrefix = "/panel-main/storage/api/v1/buckets"
+
+func (s *Server) registerBuckets(mux *http.ServeMux) {
+	mux.HandleFunc("POST "+bucketsPrefix, s.createBucket)
+	mux.HandleFunc("GET "+bucketsPrefix, s.listBuckets)
+	mux.HandleFunc("GET "+bucketsPrefix+"/{name}", s.getBucket)
+	mux.HandleFunc("DELETE "+bucketsPrefix+"/{name}", s.deleteBucket)
+	mux.HandleFunc("GET "+bucketsPrefix+"/{name}/acl", s.getBucketACL)
+	mux.HandleFunc("PUT "+bucketsPrefix+"/{name}/acl", s.putBucketACL)
+	mux.HandleFunc("GET "+bucketsPrefix+"/{name}/versioning", s.getBucketVersioning)
+	mux.HandleFunc("PUT "+bucketsPrefix+"/{name}/versioning", s.putBucketVersioning)
+	mux.HandleFunc("GET "+bucketsPrefix+"/{name}/object-locking", s.getBucketObjectLock)
+	mux.HandleFunc("PUT "+bucketsPrefix+"/{name}/object-locking", s.putBucketObjectLock)
+}
+
+// SetBucketObjectCount sets the number of objects stored in a bucket, so tests
+// can exercise the not-empty (738) delete failure. It reports whether the bucket
+// exists.
+func (s *Server) SetBucketObjectCount(name string, n int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.buckets[name]
+	if b == nil {
+		return false
+	}
+	b.ObjectCount = n
+	b.Size = n * 1024
+	return true
+}
+
+func (s *Server) createBucket(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		BucketKey                  string                   `json:"bucketKey"`
+		VersioningConfiguration    *versioningConfiguration `json:"versioningConfiguration"`
+		ObjectLockEnabledForBucket *bool                    `json:"objectLockEnabledForBucket"`
+		ACL                        string                   `json:"acl"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	if body.BucketKey == "" {
+		writeV2Error(w, http.StatusBadRequest, 400, "bucketKey must not be blank")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Bucket names are global across projects, as in S3.
+	if s.buckets[body.BucketKey] != nil {
+		writeV2Error(w, http.StatusConflict, 626, "Bucket with this name already exists")
+		return
+	}
+	b := &bucket{
+		Name:         body.BucketKey,
+		CreationDate: createdAt,
+		scope:        requestScope(r),
+		acl:          body.ACL,
+		versioning:   body.VersioningConfiguration,
+	}
+	if b.acl == "" {
+		b.acl = "PRIVATE"
+	}
+	if body.ObjectLockEnabledForBucket != nil && *body.ObjectLockEnabledForBucket {
+		b.objectLock = true
+		// S3 enables versioning implicitly on an object-lock bucket.
+		b.versioning = &versioningConfiguration{Status: "ENABLED"}
+	}
+	s.buckets[b.Name] = b
+	w.Header().Set("Location", bucketsPrefix+"/"+b.Name)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// lookupBucket returns the named bucket, rendering 628 when it does not exist and
+// 712 when it belongs to another project. The caller holds s.mu.
+func (s *Server) lookupBucket(w http.ResponseWriter, r *http.Request) *bucket {
+	name := r.PathValue("name")
+	b := s.buckets[name]
+	if b == nil {
+		writeV2Error(w, http.StatusNotFound, 628, "Bucket "+name+" not found")
+		return nil
+	}
+	if b.scope != requestScope(r) {
+		writeV2Error(w, http.StatusForbidden, 712, "Bucket "+name+" belongs to another project")
+		return nil
+	}
+	return b
+}
+
+func (s *Server) getBucket(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if b := s.lookupBucket(w, r); b != nil {
+		writeV2(w, http.StatusOK, b)
+	}
+}
+
+func (s *Server) listBuckets(w http.ResponseWriter, r *http.Request) {
+	sc := requestScope(r)
+	q := r.URL.Query()
+	pageSize, _ := strconv.Atoi(q.Get("maxBuckets"))
+	token := q.Get("continuationToken")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := []string{}
+	for name, b := range s.buckets {
+		if b.scope == sc && name > token {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	next := ""
+	if pageSize > 0 && len(names) > pageSize {
+		names = names[:pageSize]
+		next = names[pageSize-1]
+	}
+	page := struct {
+		Buckets           []*bucket `json:"buckets"`
+		ContinuationToken string    `json:"continuationToken,omitempty"`
+	}{Buckets: []*bucket{}, ContinuationToken: next}
+	for _, name := range names {
+		page.Buckets = append(page.Buckets, s.buckets[name])
+	}
+	writeV2(w, http.StatusOK, page)
+}
+
+func (s *Server) deleteBucket(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.lookupBucket(w, r)
+	if b == nil {
+		return
+	}
+	if b.ObjectCount > 0 && r.URL.Query().Get("forceDestroy") != "true" {
+		writeV2Error(w, http.StatusConflict, 738, "Bucket is not empty")
+		return
+	}
+	delete(s.buckets, b.Name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getBucketACL(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.lookupBucket(w, r)
+	if b == nil {
+		return
+	}
+	grants := []map[string]any{{
+		"grantee":    map[string]any{"type": "CanonicalUser", "id": b.scope.project},
+		"permission": "FULL_CONTROL",
+	}}
+	if b.acl == "PUBLIC_READ" || b.acl == "PUBLIC_READ_WRITE" {
+		grants = append(grants, map[string]any{
+			"grantee":    map[string]any{"type": "Group", "uri": "http://acs.amazonaws.com/groups/global/AllUsers"},
+			"permission": "READ",
+		})
+	}
+	if b.acl == "PUBLIC_READ_WRITE" {
+		grants = append(grants, map[string]any{
+			"grantee":    map[string]any{"type": "Group", "uri": "http://acs.amazonaws.com/groups/global/AllUsers"},
+			"permission": "WRITE",
+		})
+	}
+	writeV2(w, http.StatusOK, map[string]any{
+		"owner":  map[string]any{"id": b.scope.project, "displayName": b.scope.project},
+		"grants": grants,
+	})
+}
+
+func (s *Server) putBucketACL(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ACL string `json:"acl"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if b := s.lookupBucket(w, r); b != nil {
+		if body.ACL != "" {
+			b.acl = body.ACL
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) getBucketVersioning(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if b := s.lookupBucket(w, r); b != nil {
+		writeV2(w, http.StatusOK, map[string]any{"versioningConfiguration": b.versioning})
+	}
+}
+
+func (s *Server) putBucketVersioning(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		VersioningConfiguration *versioningConfiguration `json:"versioningConfiguration"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.lookupBucket(w, r)
+	if b == nil {
+		return
+	}
+	if body.VersioningConfiguration == nil {
+		writeV2Error(w, http.StatusBadRequest, 400, "versioningConfiguration is required")
+		return
+	}
+	if b.objectLock && body.VersioningConfiguration.Status != "ENABLED" {
+		writeV2Error(w, http.StatusConflict, 400, "Versioning cannot be suspended on an object-lock bucket")
+		return
+	}
+	b.versioning = body.VersioningConfiguration
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getBucketObjectLock(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.lookupBucket(w, r)
+	if b == nil {
+		return
+	}
+	var olc *objectLockConfiguration
+	if b.objectLock {
+		olc = &objectLockConfiguration{ObjectLockEnabled: "ENABLED"}
+	}
+	writeV2(w, http.StatusOK, map[string]any{"objectLockConfiguration": olc})
+}
+
+func (s *Server) putBucketObjectLock(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ObjectLockConfiguration *objectLockConfiguration `json:"objectLockConfiguration"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b := s.lookupBucket(w, r)
+	if b == nil {
+		return
+	}
+	if !b.objectLock {
+		writeV2Error(w, http.StatusConflict, 400, "Object lock can only be configured on a bucket created with object lock enabled")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,881 @@
+package fakepanel
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+)
+
+// ---- wire types (V2 compute / network) ----
+
+type image struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	IsCustom bool   `json:"isCustom"`
+}
+
+type volume struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Size       int64  `json:"size"`
+	InUse      bool   `json:"inUse"`
+	AttachedID *int64 `json:"attachedId"`
+
+	scope   scope
+	deleted bool
+}
+
+type localNetwork struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	CIDR    string `json:"cidr"`
+	Gateway string `json:"gateway"`
+	Linked  bool   `json:"linked"`
+
+	scope scope
+}
+
+type publicIP struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	IP      string `json:"ip"`
+	Mask    string `json:"mask"`
+	Gateway string `json:"gateway"`
+
+	scope scope
+}
+
+type vm struct {
+	ID             int64  `json:"id"`
+	Guid           string `json:"guid"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	CPUCores       int64  `json:"cpuCores"`
+	RAM            int64  `json:"ram"`
+	DiskSize       int64  `json:"diskSize"`
+	DiskType       string `json:"diskType"`
+	PrivateIP      string `json:"privateIp"`
+	PublicIP       string `json:"publicIp"`
+	PublicIPID     int64  `json:"publicIpId"`
+	LocalNetworkID int64  `json:"localNetworkId"`
+	ImageID        int64  `json:"imageId"`
+	ImageName      string `json:"imageName"`
+	ImageSlug      string `json:"imageSlug"`
+	Description    string `json:"description"`
+
+	scope scope
+	// disks are the VM's attached data disks (the boot disk is synthesized).
+	disks []*vmDisk
+	// bootDiskID is the VmDisk id of the boot disk.
+	bootDiskID int64
+	// settlesTo, when set, replaces Status once a read has reported it; see
+	// SetVmStatus.
+	settlesTo string
+}
+
+type vmDisk struct {
+	ID         int64  `json:"id"`
+	UserDiskID *int64 `json:"userDiskId"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Size       int64  `json:"size"`
+	BootDisk   bool   `json:"bootDisk"`
+}
+
+type createVMRequest struct {
+	Region         string  `json:"region"`
+	ProjectTag     string  `json:"projectTag"`
+	Name           string  `json:"name"`
+	ImageID        int64   `json:"imageId"`
+	CPUCores       int64   `json:"cpuCores"`
+	RAM            int64   `json:"ram"`
+	DiskSize       int64   `json:"diskSize"`
+	DiskType       string  `json:"diskType"`
+	LocalNetworkID int64   `json:"localNetworkId"`
+	PrivateIP      *string `json:"privateIp"`
+	PublicIPID     *int64  `json:"publicIpId"`
+	Password       string  `json:"password"`
+	SSHPublicKey   *string `json:"sshPublicKey"`
+	Description    *string `json:"description"`
+	UserData       *string `json:"userData"`
+}
+
+// scopedBody is the region / projectTag / name shape shared by the V2 create and
+// rename bodies of volumes, local networks and public IPs.
+type scopedBody struct {
+	Region     string `json:"region"`
+	ProjectTag string `json:"projectTag"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Size       int64  `json:"size"`
+	CIDR       string `json:"cidr"`
+	Gateway    string `json:"gateway"`
+}
+
+// publicIPPool is the documentation range public IPs are allocated from.
+const publicIPPool = "203.0.113."
+
+func (s *Server) registerCompute(mux *http.ServeMux) {
+	mux.HandleFunc("GET /panel-main/api/v2/image", s.getImage)
+	mux.HandleFunc("GET /panel-main/api/v2/images", s.listImages)
+
+	mux.HandleFunc("GET /panel-main/api/v2/volumes", s.listVolumes)
+	mux.HandleFunc("POST /panel-main/api/v2/volumes", s.createVolume)
+	mux.HandleFunc("GET /panel-main/api/v2/volumes/{id}", s.getVolume)
+	mux.HandleFunc("PUT /panel-main/api/v2/volumes/{id}", s.updateVolume)
+	mux.HandleFunc("DELETE /panel-main/api/v2/volumes/{id}", s.deleteVolume)
+
+	mux.HandleFunc("GET /panel-main/api/v2/local-networks", s.listNetworks)
+	mux.HandleFunc("POST /panel-main/api/v2/local-networks", s.createNetwork)
+	mux.HandleFunc("GET /panel-main/api/v2/local-networks/{id}", s.getNetwork)
+	mux.HandleFunc("PUT /panel-main/api/v2/local-networks/{id}", s.updateNetwork)
+	mux.HandleFunc("DELETE /panel-main/api/v2/local-networks/{id}", s.deleteNetwork)
+
+	mux.HandleFunc("GET /panel-main/api/v2/public-ips", s.listPublicIPs)
+	mux.HandleFunc("POST /panel-main/api/v2/public-ips", s.createPublicIP)
+	mux.HandleFunc("GET /panel-main/api/v2/public-ips/{id}", s.getPublicIP)
+	mux.HandleFunc("PUT /panel-main/api/v2/public-ips/{id}", s.updatePublicIP)
+	mux.HandleFunc("DELETE /panel-main/api/v2/public-ips/{id}", s.deletePublicIP)
+
+	mux.HandleFunc("GET /panel-main/api/v2/vms", s.listVMs)
+	mux.HandleFunc("POST /panel-main/api/v2/vms", s.createVM)
+	mux.HandleFunc("GET /panel-main/api/v2/vms/{id}", s.getVM)
+	mux.HandleFunc("GET /panel-main/api/v2/vms/{id}/status", s.getVM)
+	mux.HandleFunc("DELETE /panel-main/api/v2/vms/{id}", s.deleteVM)
+	mux.HandleFunc("PATCH /panel-main/api/v2/vms/{id}/name", s.renameVM)
+	mux.HandleFunc("PATCH /panel-main/api/v2/vms/{id}/resources", s.resizeVM)
+	mux.HandleFunc("PATCH /panel-main/api/v2/vms/{id}/disk", s.resizeVMDisk)
+	mux.HandleFunc("POST /panel-main/api/v2/vms/{id}/stop", s.powerVM("STOPPED"))
+	mux.HandleFunc("POST /panel-main/api/v2/vms/{id}/start", s.powerVM("RUNNING"))
+	mux.HandleFunc("POST /panel-main/api/v2/vms/{id}/public-ip", s.attachPublicIP)
+	mux.HandleFunc("DELETE /panel-main/api/v2/vms/{id}/public-ip", s.detachPublicIP)
+	mux.HandleFunc("GET /panel-main/api/v2/vms/{id}/volumes", s.listVMDisks)
+	mux.HandleFunc("POST /panel-main/api/v2/vms/{id}/volumes", s.attachVolume)
+	mux.HandleFunc("DELETE /panel-main/api/v2/vms/{id}/volumes/{diskId}", s.detachVolume)
+}
+
+// ---- images ----
+
+func (s *Server) getImage(w http.ResponseWriter, r *http.Request) {
+	slug, name := r.URL.Query().Get("slug"), r.URL.Query().Get("name")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, img := range s.images {
+		if (slug != "" && img.Slug == slug) || (slug == "" && name != "" && img.Name == name) {
+			writeV2(w, http.StatusOK, img)
+			return
+		}
+	}
+	writeNotFound(w, "Image", slug+name)
+}
+
+func (s *Server) listImages(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeV2(w, http.StatusOK, s.images)
+}
+
+// ---- volumes ----
+
+func (s *Server) listVolumes(w http.ResponseWriter, r *http.Request) {
+	sc := requestScope(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*volume{}
+	for _, v := range s.volumes {
+		if v.scope == sc && !v.deleted {
+			out = append(out, v)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	writeV2(w, http.StatusOK, out)
+}
+
+func (s *Server) createVolume(w http.ResponseWriter, r *http.Request) {
+	var body scopedBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := &volume{
+		ID:    s.newID(),
+		Name:  body.Name,
+		Type:  body.Type,
+		Size:  body.Size,
+		scope: requestScope(r).withBody(body.Region, body.ProjectTag),
+	}
+	s.volumes[v.ID] = v
+	writeV2(w, http.StatusOK, v)
+}
+
+// lookupVolume returns the volume with the path id in the request's scope. Like
+// the real panel, soft-deleted volumes are still returned. The caller holds s.mu.
+func (s *Server) lookupVolume(w http.ResponseWriter, r *http.Request) *volume {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return nil
+	}
+	v := s.volumes[id]
+	if v == nil || v.scope != requestScope(r) {
+		writeNotFound(w, "Volume", id)
+		return nil
+	}
+	return v
+}
+
+func (s *Server) getVolume(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v := s.lookupVolume(w, r); v != nil {
+		writeV2(w, http.StatusOK, v)
+	}
+}
+
+func (s *Server) updateVolume(w http.ResponseWriter, r *http.Request) {
+	var body scopedBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	r = withScope(r, body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v := s.lookupVolume(w, r); v != nil {
+		v.Name = body.Name
+		writeV2(w, http.StatusOK, v)
+	}
+}
+
+func (s *Server) deleteVolume(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.lookupVolume(w, r)
+	if v == nil {
+		return
+	}
+	if v.deleted {
+		writeNotFound(w, "Volume", v.ID)
+		return
+	}
+	if v.InUse {
+		writeV2Error(w, http.StatusConflict, 627, "Volume is attached to a virtual machine")
+		return
+	}
+	v.deleted = true
+	writeV2(w, http.StatusOK, nil)
+}
+
+// ---- local networks ----
+
+func (s *Server) listNetworks(w http.ResponseWriter, r *http.Request) {
+	sc := requestScope(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*localNetwork{}
+	for _, n := range s.networks {
+		if n.scope == sc {
+			n.Linked = s.networkLinked(n.ID)
+			out = append(out, n)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	writeV2(w, http.StatusOK, out)
+}
+
+func (s *Server) createNetwork(w http.ResponseWriter, r *http.Request) {
+	var body scopedBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	sc := requestScope(r).withBody(body.Region, body.ProjectTag)
+	if _, _, err := net.ParseCIDR(body.CIDR); err != nil {
+		writeV2Error(w, http.StatusBadRequest, 400, "invalid cidr "+body.CIDR)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, n := range s.networks {
+		if n.scope == sc && n.Name == body.Name {
+			writeV2Error(w, http.StatusConflict, 614, "Local network with this name already exists")
+			return
+		}
+	}
+	n := &localNetwork{ID: s.newID(), Name: body.Name, CIDR: body.CIDR, Gateway: body.Gateway, scope: sc}
+	s.networks[n.ID] = n
+	writeV2(w, http.StatusOK, n)
+}
+
+// lookupNetwork returns the local network with the path id in the request's
+// scope. The caller holds s.mu.
+func (s *Server) lookupNetwork(w http.ResponseWriter, r *http.Request) *localNetwork {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return nil
+	}
+	n := s.networks[id]
+	if n == nil || n.scope != requestScope(r) {
+		writeNotFound(w, "Local network", id)
+		return nil
+	}
+	n.Linked = s.networkLinked(id)
+	return n
+}
+
+func (s *Server) getNetwork(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n := s.lookupNetwork(w, r); n != nil {
+		writeV2(w, http.StatusOK, n)
+	}
+}
+
+func (s *Server) updateNetwork(w http.ResponseWriter, r *http.Request) {
+	var body scopedBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	r = withScope(r, body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n := s.lookupNetwork(w, r); n != nil {
+		n.Name = body.Name
+		writeV2(w, http.StatusOK, n)
+	}
+}
+
+func (s *Server) deleteNetwork(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := s.lookupNetwork(w, r)
+	if n == nil {
+		return
+	}
+	if n.Linked {
+		writeV2Error(w, http.StatusConflict, 615, "Local network is in use")
+		return
+	}
+	delete(s.networks, n.ID)
+	writeV2(w, http.StatusOK, nil)
+}
+
+// networkLinked reports whether any VM or cluster uses the network. The caller
+// holds s.mu.
+func (s *Server) networkLinked(id int64) bool {
+	for _, v := range s.vms {
+		if v.LocalNetworkID == id {
+			return true
+		}
+	}
+	for _, c := range s.clusters {
+		if c.localNetID == id && c.Status.Name != "DELETED" {
+			return true
+		}
+	}
+	return false
+}
+
+// allocatePrivateIP returns the first host address in the network's CIDR (after
+// the gateway) not used by another VM. The caller holds s.mu.
+func (s *Server) allocatePrivateIP(n *localNetwork) string {
+	_, ipnet, err := net.ParseCIDR(n.CIDR)
+	if err != nil {
+		return ""
+	}
+	used := map[string]bool{n.Gateway: true}
+	for _, v := range s.vms {
+		if v.LocalNetworkID == n.ID {
+			used[v.PrivateIP] = true
+		}
+	}
+	ip := ipnet.IP.To4()
+	if ip == nil {
+		return ""
+	}
+	for host := 2; host < 255; host++ {
+		cand := net.IPv4(ip[0], ip[1], ip[2], ip[3]+byte(host)).String()
+		if !used[cand] && ipnet.Contains(net.ParseIP(cand)) {
+			return cand
+		}
+	}
+	return ""
+}
+
+// ---- public IPs ----
+
+func (s *Server) listPublicIPs(w http.ResponseWriter, r *http.Request) {
+	sc := requestScope(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*publicIP{}
+	for _, p := range s.publicIPs {
+		if p.scope == sc {
+			out = append(out, p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	writeV2(w, http.StatusOK, out)
+}
+
+func (s *Server) createPublicIP(w http.ResponseWriter, r *http.Request) {
+	var body scopedBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	used := map[string]bool{}
+	for _, p := range s.publicIPs {
+		used[p.IP] = true
+	}
+	addr := ""
+	for host := 10; host < 255; host++ {
+		if cand := fmt.Sprintf("%s%d", publicIPPool, host); !used[cand] {
+			addr = cand
+			break
+		}
+	}
+	if addr == "" {
+		writeV2Error(w, http.StatusConflict, 737, "Insufficient free IP addresses")
+		return
+	}
+	p := &publicIP{
+		ID:      s.newID(),
+		Name:    body.Name,
+		IP:      addr,
+		Mask:    "/24",
+		Gateway: publicIPPool + "1",
+		scope:   requestScope(r).withBody(body.Region, body.ProjectTag),
+	}
+	s.publicIPs[p.ID] = p
+	writeV2(w, http.StatusOK, p)
+}
+
+// lookupPublicIP returns the public IP with the path id in the request's scope.
+// The caller holds s.mu.
+func (s *Server) lookupPublicIP(w http.ResponseWriter, r *http.Request) *publicIP {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return nil
+	}
+	p := s.publicIPs[id]
+	if p == nil || p.scope != requestScope(r) {
+		writeNotFound(w, "Public IP", id)
+		return nil
+	}
+	return p
+}
+
+func (s *Server) getPublicIP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p := s.lookupPublicIP(w, r); p != nil {
+		writeV2(w, http.StatusOK, p)
+	}
+}
+
+func (s *Server) updatePublicIP(w http.ResponseWriter, r *http.Request) {
+	var body scopedBody
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	r = withScope(r, body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p := s.lookupPublicIP(w, r); p != nil {
+		p.Name = body.Name
+		writeV2(w, http.StatusOK, p)
+	}
+}
+
+func (s *Server) deletePublicIP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.lookupPublicIP(w, r)
+	if p == nil {
+		return
+	}
+	for _, v := range s.vms {
+		if v.PublicIPID == p.ID {
+			writeV2Error(w, http.StatusConflict, 705, "Public IP is attached to a virtual machine")
+			return
+		}
+	}
+	delete(s.publicIPs, p.ID)
+	writeV2(w, http.StatusOK, nil)
+}
+
+// ---- VMs ----
+
+func (s *Server) listVMs(w http.ResponseWriter, r *http.Request) {
+	sc := requestScope(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*vm{}
+	for _, v := range s.vms {
+		if v.scope == sc {
+			out = append(out, v)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	writeV2(w, http.StatusOK, out)
+}
+
+func (s *Server) createVM(w http.ResponseWriter, r *http.Request) {
+	var body createVMRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	sc := requestScope(r).withBody(body.Region, body.ProjectTag)
+	if body.Name == "" || body.Password == "" || body.CPUCores <= 0 || body.RAM <= 0 || body.DiskSize <= 0 {
+		writeV2Error(w, http.StatusBadRequest, 400, "name, password, cpuCores, ram and diskSize are required")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range s.vms {
+		if v.scope == sc && v.Name == body.Name {
+			writeV2Error(w, http.StatusConflict, 666, "Virtual machine with this name already exists")
+			return
+		}
+	}
+	var img *image
+	for _, i := range s.images {
+		if i.ID == body.ImageID {
+			img = i
+		}
+	}
+	if img == nil {
+		writeNotFound(w, "Image", body.ImageID)
+		return
+	}
+	n := s.networks[body.LocalNetworkID]
+	if n == nil || n.scope != sc {
+		writeNotFound(w, "Local network", body.LocalNetworkID)
+		return
+	}
+
+	v := &vm{
+		ID:             s.newID(),
+		Name:           body.Name,
+		Status:         "RUNNING",
+		CPUCores:       body.CPUCores,
+		RAM:            body.RAM,
+		DiskSize:       body.DiskSize,
+		DiskType:       body.DiskType,
+		LocalNetworkID: n.ID,
+		ImageID:        img.ID,
+		ImageName:      img.Name,
+		ImageSlug:      img.Slug,
+		scope:          sc,
+	}
+	v.Guid = guid(v.ID)
+	v.bootDiskID = s.newID()
+	if body.Description != nil {
+		v.Description = *body.Description
+	}
+	if body.PrivateIP != nil && *body.PrivateIP != "" {
+		v.PrivateIP = *body.PrivateIP
+	} else {
+		v.PrivateIP = s.allocatePrivateIP(n)
+	}
+	if body.PublicIPID != nil && *body.PublicIPID != 0 {
+		p := s.publicIPs[*body.PublicIPID]
+		if p == nil || p.scope != sc {
+			writeNotFound(w, "Public IP", *body.PublicIPID)
+			return
+		}
+		v.PublicIPID, v.PublicIP = p.ID, p.IP
+	}
+	s.vms[v.ID] = v
+	writeV2(w, http.StatusOK, v)
+}
+
+// lookupVM returns the VM with the path id in the request's scope. The caller
+// holds s.mu.
+func (s *Server) lookupVM(w http.ResponseWriter, r *http.Request) *vm {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return nil
+	}
+	v := s.vms[id]
+	if v == nil || v.scope != requestScope(r) {
+		writeNotFound(w, "Virtual machine", id)
+		return nil
+	}
+	return v
+}
+
+func (s *Server) getVM(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v := s.lookupVM(w, r); v != nil {
+		writeV2(w, http.StatusOK, v)
+		if v.settlesTo != "" {
+			v.Status, v.settlesTo = v.settlesTo, ""
+		}
+	}
+}
+
+// SetVmStatus puts a VM into status, e.g. a transitional STOPPING or ERROR, so
+// tests can exercise how the provider handles it. When settlesTo is not empty,
+// the VM reports status to the next read only and then moves to settlesTo, the
+// way the fake converges everything on its first poll. It reports whether the
+// VM exists.
+func (s *Server) SetVmStatus(id int64, status, settlesTo string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.vms[id]
+	if v == nil {
+		return false
+	}
+	v.Status, v.settlesTo = status, settlesTo
+	return true
+}
+
+func (s *Server) deleteVM(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.lookupVM(w, r)
+	if v == nil {
+		return
+	}
+	for _, d := range v.disks {
+		if vol := s.volumes[*d.UserDiskID]; vol != nil {
+			vol.InUse, vol.AttachedID = false, nil
+		}
+	}
+	delete(s.vms, v.ID)
+	writeV2(w, http.StatusOK, nil)
+}
+
+func (s *Server) renameVM(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name string `json:"name"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.lookupVM(w, r)
+	if v == nil {
+		return
+	}
+	for _, o := range s.vms {
+		if o != v && o.scope == v.scope && o.Name == body.Name {
+			writeV2Error(w, http.StatusConflict, 666, "Virtual machine with this name already exists")
+			return
+		}
+	}
+	v.Name = body.Name
+	writeV2(w, http.StatusOK, nil)
+}
+
+func (s *Server) resizeVM(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		CPUCores int64 `json:"cpuCores"`
+		RAM      int64 `json:"ram"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.lookupVM(w, r)
+	if v == nil || !requireStopped(w, v) {
+		return
+	}
+	v.CPUCores, v.RAM = body.CPUCores, body.RAM
+	writeV2(w, http.StatusOK, nil)
+}
+
+func (s *Server) resizeVMDisk(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		DiskSize *int64  `json:"diskSize"`
+		DiskType *string `json:"diskType"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.lookupVM(w, r)
+	if v == nil || !requireStopped(w, v) {
+		return
+	}
+	if body.DiskSize != nil {
+		if *body.DiskSize < v.DiskSize {
+			writeV2Error(w, http.StatusBadRequest, 400, "disk cannot be shrunk")
+			return
+		}
+		v.DiskSize = *body.DiskSize
+	}
+	if body.DiskType != nil {
+		v.DiskType = *body.DiskType
+	}
+	writeV2(w, http.StatusOK, nil)
+}
+
+// requireStopped answers 711 (VM must be stopped) when v is running.
+func requireStopped(w http.ResponseWriter, v *vm) bool {
+	if v.Status == "RUNNING" {
+		writeV2Error(w, http.StatusConflict, 711, "Virtual machine must be stopped")
+		return false
+	}
+	return true
+}
+
+// powerVM returns a handler that moves a VM to status. The transition is instant.
+func (s *Server) powerVM(status string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if v := s.lookupVM(w, r); v != nil {
+			v.Status = status
+			writeV2(w, http.StatusOK, nil)
+		}
+	}
+}
+
+func (s *Server) attachPublicIP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		PublicIPID int64 `json:"publicIpId"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.lookupVM(w, r)
+	if v == nil {
+		return
+	}
+	p := s.publicIPs[body.PublicIPID]
+	if p == nil || p.scope != v.scope {
+		writeNotFound(w, "Public IP", body.PublicIPID)
+		return
+	}
+	for _, o := range s.vms {
+		if o != v && o.PublicIPID == p.ID {
+			writeV2Error(w, http.StatusConflict, 705, "Public IP is attached to another virtual machine")
+			return
+		}
+	}
+	v.PublicIPID, v.PublicIP = p.ID, p.IP
+	writeV2(w, http.StatusOK, v)
+}
+
+func (s *Server) detachPublicIP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.lookupVM(w, r)
+	if v == nil {
+		return
+	}
+	if v.PublicIPID == 0 {
+		writeNotFound(w, "Public IP attachment on virtual machine", v.ID)
+		return
+	}
+	v.PublicIPID, v.PublicIP = 0, ""
+	writeV2(w, http.StatusOK, nil)
+}
+
+func (s *Server) listVMDisks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.lookupVM(w, r)
+	if v == nil {
+		return
+	}
+	out := []*vmDisk{{ID: v.bootDiskID, Name: v.Name + "-boot", Type: v.DiskType, Size: v.DiskSize, BootDisk: true}}
+	out = append(out, v.disks...)
+	writeV2(w, http.StatusOK, out)
+}
+
+func (s *Server) attachVolume(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		VolumeID int64 `json:"volumeId"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.lookupVM(w, r)
+	if v == nil {
+		return
+	}
+	vol := s.volumes[body.VolumeID]
+	if vol == nil || vol.deleted || vol.scope != v.scope {
+		writeNotFound(w, "Volume", body.VolumeID)
+		return
+	}
+	if vol.InUse {
+		writeV2Error(w, http.StatusConflict, 627, "Volume is already attached")
+		return
+	}
+	volID := vol.ID
+	d := &vmDisk{ID: s.newID(), UserDiskID: &volID, Name: vol.Name, Type: vol.Type, Size: vol.Size}
+	v.disks = append(v.disks, d)
+	vmID := v.ID
+	vol.InUse, vol.AttachedID = true, &vmID
+	// The attach endpoint answers with a Volume DTO whose id is the new VmDisk id.
+	writeV2(w, http.StatusOK, &volume{ID: d.ID, Name: vol.Name, Type: vol.Type, Size: vol.Size, InUse: true, AttachedID: &vmID})
+}
+
+func (s *Server) detachVolume(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := s.lookupVM(w, r)
+	if v == nil {
+		return
+	}
+	diskID, ok := pathID(w, r, "diskId")
+	if !ok {
+		return
+	}
+	for i, d := range v.disks {
+		if d.ID != diskID {
+			continue
+		}
+		if !requireStopped(w, v) {
+			return
+		}
+		if vol := s.volumes[*d.UserDiskID]; vol != nil {
+			vol.InUse, vol.AttachedID = false, nil
+		}
+		v.disks = append(v.disks[:i], v.disks[i+1:]...)
+		writeV2(w, http.StatusOK, nil)
+		return
+	}
+	writeNotFound(w, "Volume attachment", diskID)
+}
+
+// withScope applies a region / projectTag update body to the request's scope, so
+// lookups use the same resolution as the real panel (body over query over header).
+func withScope(r *http.Request, body scopedBody) *http.Request {
+	if body.Region == "" && body.ProjectTag == "" {
+		return r
+	}
+	r2 := r.Clone(r.Context())
+	if body.Region != "" {
+		r2.Header.Set("X-Region", body.Region)
+	}
+	if body.ProjectTag != "" {
+		r2.Header.Set("X-Project-Tag", body.ProjectTag)
+	}
+	q := r2.URL.Query()
+	q.Del("region")
+	q.Del("projectTag")
+	r2.URL.RawQuery = q.Encode()
+	return r2
+}
+
+// guid renders a stable, UUID-shaped guid from an object id.
+func guid(id int64) string {
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", id)
+}
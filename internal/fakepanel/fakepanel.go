@@ -0,0 +1,447 @@
+// Package fakepanel is a stateful, in-process stand-in for the ProData panel API,
+// for hermetic tests. It serves the same routes the provider's client calls — the
+// V2 compute/network endpoints, the V1 load-balancer and Kubernetes endpoints and
+// the storage (S3 bucket) endpoints — over an httptest server, and answers with
+// the same envelopes and business error codes the real panel emits, so that
+// parseResponse / parseV1Response and the resource error handling run unmodified.
+//
+// The fake converges instantly: a created VM is RUNNING, a load balancer or
+// cluster is SUCCESS on its first poll. Resources' polling loops therefore return
+// on their first iteration and a full create/read/update/import/destroy cycle
+// runs in well under a second per resource.
+//
+// The wire types here deliberately do NOT reuse the client package's structs: the
+// fake models the panel, and sharing the JSON tags would let a wrong key in the
+// client go unnoticed.
+package fakepanel
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Credentials and scope the fake accepts by default. Tests configure the provider
+// (or client.New) with these; requests carrying any other key or secret are
+// rejected with HTTP 401.
+const (
+	APIKeyID     = "fake-key-id"
+	APISecretKey = "fake-secret-key"
+	Region       = "UZ-5"
+	ProjectTag   = "fake-project"
+)
+
+// contextPath is the servlet context path panel-main is deployed under. client.New
+// appends it to the host-only base URL, so every route the fake serves lives below it.
+const contextPath = "/panel-main"
+
+// Request is one request the fake received, recorded for assertions.
+type Request struct {
+	Method string
+	// Path is the request path below the /panel-main context path, without the query.
+	Path  string
+	Query string
+}
+
+// fault is a queued failure returned instead of the normal response. A drop fault
+// instead lets the request run and then closes the connection without answering.
+type fault struct {
+	method    string
+	path      string
+	remaining int
+	status    int
+	code      int
+	message   string
+	drop      bool
+}
+
+// storedResponse is the first response to an Idempotency-Key, replayed verbatim
+// when the key is sent again.
+type storedResponse struct {
+	status int
+	header http.Header
+	body   []byte
+}
+
+// Server is the fake panel. The zero value is not usable; create one with New.
+type Server struct {
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	nextID   int64
+	requests []Request
+	faults   []*fault
+	// idempotent holds the response to each Idempotency-Key seen on a mutating
+	// request, keyed by method, path and key.
+	idempotent map[string]*storedResponse
+
+	images    []*image
+	vms       map[int64]*vm
+	volumes   map[int64]*volume
+	networks  map[int64]*localNetwork
+	publicIPs map[int64]*publicIP
+	lbs       map[int64]*loadBalancer
+	clusters  map[int64]*cluster
+	pools     map[int64]*nodePool
+	buckets   map[string]*bucket
+
+	kuberVersions []kuberVersion
+	masterConfigs []masterNodeConfig
+
+	// features is what GET /api/v2/capabilities reports; nil makes the
+	// endpoint answer 404, like a panel that predates it.
+	features []string
+}
+
+// New starts a fake panel seeded with a small image catalog, the Kubernetes
+// version list and a 3-tier master flavor ladder per HA mode. The server is
+// closed automatically when the test ends.
+func New(t interface{ Cleanup(func()) }) *Server {
+	s := &Server{
+		nextID:     100,
+		idempotent: map[string]*storedResponse{},
+		vms:        map[int64]*vm{},
+		volumes:    map[int64]*volume{},
+		networks:   map[int64]*localNetwork{},
+		publicIPs:  map[int64]*publicIP{},
+		lbs:        map[int64]*loadBalancer{},
+		clusters:   map[int64]*cluster{},
+		pools:      map[int64]*nodePool{},
+		buckets:    map[string]*bucket{},
+		images: []*image{
+			{ID: 1, Name: "Ubuntu 22.04", Slug: "ubuntu-22.04"},
+			{ID: 2, Name: "Debian 12", Slug: "debian-12"},
+		},
+		kuberVersions: []kuberVersion{
+			{ID: 1, Version: "v1.30.8"},
+			{ID: 2, Version: "v1.31.4"},
+			{ID: 3, Version: "v1.32.0-rc.1", IsDebug: true},
+		},
+		masterConfigs: []masterNodeConfig{
+			{ID: 11, CPU: 2, RAM: 4, SSD: 50},
+			{ID: 12, CPU: 4, RAM: 8, SSD: 80},
+			{ID: 13, CPU: 8, RAM: 16, SSD: 100},
+			{ID: 21, CPU: 2, RAM: 4, SSD: 50, IsHA: true},
+			{ID: 22, CPU: 4, RAM: 8, SSD: 80, IsHA: true},
+			{ID: 23, CPU: 8, RAM: 16, SSD: 100, IsHA: true},
+		},
+		features: []string{
+			"kubernetes.node_ip_range_allocation",
+			"loadbalancer.ccm",
+			"storage.object_lock",
+			"api.idempotency_keys",
+		},
+	}
+
+	mux := http.NewServeMux()
+	s.registerCompute(mux)
+	s.registerLB(mux)
+	s.registerKuber(mux)
+	s.registerBuckets(mux)
+	mux.HandleFunc("GET /panel-main/api/v2/capabilities", s.getCapabilities)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		// An unrouted path must never masquerade as a business 404 (which the
+		// provider reads as "resource gone"), so report it as a loud 501.
+		writeV2Error(w, http.StatusNotImplemented, 0,
+			fmt.Sprintf("fakepanel: no route for %s %s", r.Method, r.URL.Path))
+	})
+
+	s.srv = httptest.NewServer(s.middleware(mux))
+	t.Cleanup(s.srv.Close)
+	return s
+}
+
+// URL returns the host-only base URL to configure as api_base_url. Like the real
+// panel, the routes live under /panel-main, which client.New appends itself.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// Close shuts the server down. It is safe to call more than once.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// SetCapabilities makes the panel report only the given features, as an older
+// panel-main deploy would. With no features it still reports its capabilities,
+// just none of the optional ones.
+func (s *Server) SetCapabilities(features ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.features = append([]string{}, features...)
+}
+
+// DisableCapabilities makes the capabilities endpoint answer 404, like a panel
+// deployed before it existed.
+func (s *Server) DisableCapabilities() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.features = nil
+}
+
+func (s *Server) getCapabilities(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	features := append([]string(nil), s.features...)
+	disabled := s.features == nil
+	s.mu.Unlock()
+	if disabled {
+		// Spring's default answer for an unmapped path, not a V2 envelope.
+		writeJSON(w, http.StatusNotFound, map[string]any{"status": 404, "error": "Not Found", "path": r.URL.Path})
+		return
+	}
+	writeV2(w, http.StatusOK, map[string]any{"version": "fakepanel", "features": features})
+}
+
+// FailNext makes the next n requests matching method and path fail with the given
+// HTTP status and panel error code, rendered as a V2 error envelope (which both
+// parseResponse and parseV1Response understand). path is matched against the
+// request path below /panel-main, without the query string. A code of 0 sends an
+// envelope with no errors, which the client reports as an unexpected response.
+func (s *Server) FailNext(method, path string, n, status, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{
+		method:    method,
+		path:      path,
+		remaining: n,
+		status:    status,
+		code:      code,
+		message:   fmt.Sprintf("fakepanel: injected failure (HTTP %d)", status),
+	})
+}
+
+// DropResponseNext makes the next n requests matching method and path run as
+// usual and then lose their response: the connection is closed before anything is
+// written, as on a network failure after the panel applied the change. Combined
+// with an Idempotency-Key the client's re-send is answered from the stored
+// response, so the change is still applied once.
+func (s *Server) DropResponseNext(method, path string, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{method: method, path: path, remaining: n, drop: true})
+}
+
+// Requests returns a copy of every request received so far, in arrival order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestCount returns how many requests matched method and path (below
+// /panel-main, without the query string).
+func (s *Server) RequestCount(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, r := range s.requests {
+		if r.Method == method && r.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+// middleware records the request, authenticates it and applies queued faults
+// before handing it to the route mux.
+//
+// Like the real panel, it honours the Idempotency-Key header on mutating
+// requests: the first response to a key is stored and replayed for every repeat
+// of that key without running the request again, and responses to keyed requests
+// echo the key back.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := strings.TrimPrefix(r.URL.Path, contextPath)
+		key := r.Header.Get("Idempotency-Key")
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			key = ""
+		}
+		storeKey := r.Method + " " + p + " " + key
+
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: p, Query: r.URL.RawQuery})
+		stored := s.idempotent[storeKey]
+		var f *fault
+		if stored == nil {
+			f = s.takeFault(r.Method, p)
+		}
+		s.mu.Unlock()
+
+		if r.Header.Get("X-API-KEY") != APIKeyID || r.Header.Get("X-API-SECRET") != APISecretKey {
+			writeV2Error(w, http.StatusUnauthorized, 0, "")
+			return
+		}
+		if stored != nil {
+			stored.write(w, key)
+			return
+		}
+		if f != nil && !f.drop {
+			writeV2Error(w, f.status, f.code, f.message)
+			return
+		}
+
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+		resp := &storedResponse{status: rec.Code, header: rec.Header(), body: rec.Body.Bytes()}
+		if key != "" {
+			s.mu.Lock()
+			s.idempotent[storeKey] = resp
+			s.mu.Unlock()
+		}
+		if f != nil {
+			dropConnection(w)
+			return
+		}
+		resp.write(w, key)
+	})
+}
+
+// write sends the stored response, echoing key when the request carried one.
+func (sr *storedResponse) write(w http.ResponseWriter, key string) {
+	for k, v := range sr.header {
+		w.Header()[k] = v
+	}
+	if key != "" {
+		w.Header().Set("Idempotency-Key", key)
+	}
+	w.WriteHeader(sr.status)
+	_, _ = w.Write(sr.body)
+}
+
+// dropConnection closes the client connection without writing a response.
+func dropConnection(w http.ResponseWriter) {
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		panic("fakepanel: response writer cannot be hijacked")
+	}
+	conn, _, err := hj.Hijack()
+	if err != nil {
+		panic(fmt.Sprintf("fakepanel: hijack: %v", err))
+	}
+	conn.Close()
+}
+
+// takeFault pops one use of the first fault matching method and path. The caller
+// holds s.mu.
+func (s *Server) takeFault(method, path string) *fault {
+	for i, f := range s.faults {
+		if f.method != method || f.path != path {
+			continue
+		}
+		f.remaining--
+		if f.remaining <= 0 {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+		}
+		return f
+	}
+	return nil
+}
+
+// newID allocates the next object id. IDs are unique across every object type,
+// which catches a resource passing one kind of id where another is expected. The
+// caller holds s.mu.
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+// ---- scope ----
+
+// scope is the region + project an object belongs to or a request targets.
+type scope struct {
+	region  string
+	project string
+}
+
+// requestScope resolves a request's scope the way panel-main does: the X-Region /
+// X-Project-Tag headers, overridden by the region / projectTag query parameters.
+func requestScope(r *http.Request) scope {
+	sc := scope{region: r.Header.Get("X-Region"), project: r.Header.Get("X-Project-Tag")}
+	q := r.URL.Query()
+	if v := q.Get("region"); v != "" {
+		sc.region = v
+	}
+	if v := q.Get("projectTag"); v != "" {
+		sc.project = v
+	}
+	return sc
+}
+
+// withBody overrides the scope with the region / projectTag fields of a create or
+// update body, when present.
+func (sc scope) withBody(region, project string) scope {
+	if region != "" {
+		sc.region = region
+	}
+	if project != "" {
+		sc.project = project
+	}
+	return sc
+}
+
+// ---- envelopes ----
+
+// writeV2 renders a V2 success envelope {"success":true,"data":...}.
+func writeV2(w http.ResponseWriter, status int, data any) {
+	writeJSON(w, status, map[string]any{"success": true, "data": data, "errors": []any{}})
+}
+
+// writeV2Error renders a V2 error envelope, as panel-main's ApiExceptionHandler
+// does. A zero code sends an empty errors list.
+func writeV2Error(w http.ResponseWriter, status, code int, message string) {
+	errs := []map[string]any{}
+	if code != 0 {
+		errs = append(errs, map[string]any{"code": code, "message": message})
+	}
+	writeJSON(w, status, map[string]any{"success": false, "data": nil, "errors": errs})
+}
+
+// writeV1 renders a V1 success envelope {"error":0,"errMessage":null,"data":...}.
+func writeV1(w http.ResponseWriter, data any) {
+	writeJSON(w, http.StatusOK, map[string]any{"error": 0, "errMessage": nil, "data": data})
+}
+
+// writeV1Error renders a legacy V1 business failure. panel-main sends these at
+// HTTP 500 with the error field set to 500 and the message as the only detail.
+func writeV1Error(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusInternalServerError, map[string]any{"error": 500, "errMessage": message, "data": nil})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeNotFound renders the V2 generic not-found (code 601).
+func writeNotFound(w http.ResponseWriter, what string, id any) {
+	writeV2Error(w, http.StatusNotFound, 601, fmt.Sprintf("%s %v not found", what, id))
+}
+
+// decodeBody decodes a JSON request body into v, answering 400 on malformed JSON.
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	b, err := io.ReadAll(r.Body)
+	if err == nil && len(b) > 0 {
+		err = json.Unmarshal(b, v)
+	}
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, 400, "malformed request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// pathID parses the named int64 path value, answering 400 when it is not numeric.
+func pathID(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, 400, fmt.Sprintf("invalid %s %q", name, r.PathValue(name)))
+		return 0, false
+	}
+	return id, true
+}
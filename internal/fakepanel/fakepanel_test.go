@@ -0,0 +1,390 @@
+package fakepanel_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/fakepanel"
+)
+
+// newClient returns a client wired to a fresh fake panel with its default scope.
+func newClient(t *testing.T) (*fakepanel.Server, *client.Client) {
+	t.Helper()
+	s := fakepanel.New(t)
+	c, err := client.New(client.Config{
+		APIBaseURL:   s.URL(),
+		APIKeyID:     fakepanel.APIKeyID,
+		APISecretKey: fakepanel.APISecretKey,
+		Region:       fakepanel.Region,
+		ProjectTag:   fakepanel.ProjectTag,
+	})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	return s, c
+}
+
+func TestAuth_WrongSecretRejected(t *testing.T) {
+	s := fakepanel.New(t)
+	c, err := client.New(client.Config{APIBaseURL: s.URL(), APIKeyID: fakepanel.APIKeyID, APISecretKey: "wrong"})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	_, err = c.GetImages(context.Background(), nil)
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("err = %v, want HTTP 401", err)
+	}
+}
+
+func TestUnroutedPathIsNotANotFound(t *testing.T) {
+	_, c := newClient(t)
+	err := c.Do(context.Background(), http.MethodGet, "/api/v2/nope", nil, nil, nil)
+	if err == nil {
+		t.Fatal("expected an error for an unrouted path")
+	}
+	if client.IsNotFound(err) {
+		t.Errorf("unrouted path reported as not-found (%v); it must fail loudly instead", err)
+	}
+}
+
+func TestVmLifecycle(t *testing.T) {
+	_, c := newClient(t)
+	ctx := context.Background()
+
+	net, err := c.CreateLocalNetwork(ctx, client.CreateLocalNetworkRequest{Name: "net", CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("create network: %v", err)
+	}
+	ip, err := c.CreatePublicIP(ctx, client.CreatePublicIPRequest{Name: "ip"})
+	if err != nil {
+		t.Fatalf("create public ip: %v", err)
+	}
+	vm, err := c.CreateVm(ctx, client.CreateVmRequest{
+		Name: "vm", ImageID: 1, CPUCores: 2, RAM: 4, DiskSize: 20, DiskType: "SSD",
+		LocalNetworkID: net.ID, PublicIPID: &ip.ID, Password: "Passw0rd!",
+	})
+	if err != nil {
+		t.Fatalf("create vm: %v", err)
+	}
+	if vm.Status != "RUNNING" || vm.PrivateIP != "10.0.0.2" || vm.PublicIP != ip.IP || vm.Guid == "" {
+		t.Errorf("unexpected vm: %+v", vm)
+	}
+
+	_, err = c.CreateVm(ctx, client.CreateVmRequest{
+		Name: "vm", ImageID: 1, CPUCores: 1, RAM: 1, DiskSize: 10, LocalNetworkID: net.ID, Password: "x",
+	})
+	if !client.IsAPIError(err, 666) {
+		t.Errorf("duplicate name: err = %v, want code 666", err)
+	}
+
+	if err := c.UpdateVmResources(ctx, vm.ID, client.UpdateVmResourcesRequest{CPUCores: 4, RAM: 8}, nil); !client.IsAPIError(err, 711) {
+		t.Errorf("resize while running: err = %v, want code 711", err)
+	}
+	if err := c.StopVm(ctx, vm.ID, nil); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if err := c.UpdateVmResources(ctx, vm.ID, client.UpdateVmResourcesRequest{CPUCores: 4, RAM: 8}, nil); err != nil {
+		t.Fatalf("resize while stopped: %v", err)
+	}
+	got, err := c.GetVm(ctx, vm.ID, nil)
+	if err != nil {
+		t.Fatalf("get vm: %v", err)
+	}
+	if got.Status != "STOPPED" || got.CPUCores != 4 || got.RAM != 8 {
+		t.Errorf("unexpected vm after resize: %+v", got)
+	}
+
+	if err := c.DeleteVm(ctx, vm.ID, nil); err != nil {
+		t.Fatalf("delete vm: %v", err)
+	}
+	if _, err := c.GetVm(ctx, vm.ID, nil); !client.IsNotFound(err) {
+		t.Errorf("get deleted vm: err = %v, want not-found", err)
+	}
+}
+
+func TestVm_OtherScopeIsNotFound(t *testing.T) {
+	_, c := newClient(t)
+	ctx := context.Background()
+	net, err := c.CreateLocalNetwork(ctx, client.CreateLocalNetworkRequest{Name: "net", CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("create network: %v", err)
+	}
+	vm, err := c.CreateVm(ctx, client.CreateVmRequest{
+		Name: "vm", ImageID: 1, CPUCores: 1, RAM: 1, DiskSize: 10, LocalNetworkID: net.ID, Password: "x",
+	})
+	if err != nil {
+		t.Fatalf("create vm: %v", err)
+	}
+	if _, err := c.GetVm(ctx, vm.ID, &client.RequestOpts{ProjectTag: "other"}); !client.IsNotFound(err) {
+		t.Errorf("cross-project get: err = %v, want not-found", err)
+	}
+}
+
+func TestVolume_SoftDeleteStillReadableById(t *testing.T) {
+	_, c := newClient(t)
+	ctx := context.Background()
+	v, err := c.CreateVolume(ctx, client.CreateVolumeRequest{Name: "data", Type: "SSD", Size: 10})
+	if err != nil {
+		t.Fatalf("create volume: %v", err)
+	}
+	if err := c.DeleteVolume(ctx, v.ID, nil); err != nil {
+		t.Fatalf("delete volume: %v", err)
+	}
+	if _, err := c.GetVolume(ctx, v.ID, nil); err != nil {
+		t.Errorf("by-id get of a soft-deleted volume: %v, want success", err)
+	}
+	list, err := c.GetVolumes(ctx, nil)
+	if err != nil {
+		t.Fatalf("list volumes: %v", err)
+	}
+	if len(list) != 0 {
+		t.Errorf("list = %+v, want the soft-deleted volume dropped", list)
+	}
+}
+
+func TestVolumeAttach_ReturnsVmDiskID(t *testing.T) {
+	_, c := newClient(t)
+	ctx := context.Background()
+	net, _ := c.CreateLocalNetwork(ctx, client.CreateLocalNetworkRequest{Name: "net", CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"})
+	vm, err := c.CreateVm(ctx, client.CreateVmRequest{
+		Name: "vm", ImageID: 1, CPUCores: 1, RAM: 1, DiskSize: 10, LocalNetworkID: net.ID, Password: "x",
+	})
+	if err != nil {
+		t.Fatalf("create vm: %v", err)
+	}
+	vol, _ := c.CreateVolume(ctx, client.CreateVolumeRequest{Name: "data", Type: "SSD", Size: 10})
+
+	att, err := c.AttachVolume(ctx, vm.ID, client.AttachVolumeRequest{VolumeID: vol.ID}, nil)
+	if err != nil {
+		t.Fatalf("attach: %v", err)
+	}
+	if att.ID == vol.ID {
+		t.Errorf("attach returned the volume id %d, want a distinct VmDisk id", att.ID)
+	}
+	disks, err := c.GetVmVolumes(ctx, vm.ID, nil)
+	if err != nil {
+		t.Fatalf("list disks: %v", err)
+	}
+	if len(disks) != 2 || !disks[0].BootDisk || disks[1].ID != att.ID || *disks[1].UserDiskID != vol.ID {
+		t.Errorf("unexpected disks: %+v", disks)
+	}
+	if err := c.DetachVolume(ctx, vm.ID, att.ID, nil); !client.IsAPIError(err, 711) {
+		t.Errorf("detach while running: err = %v, want code 711", err)
+	}
+}
+
+func TestLocalNetwork_DuplicateName(t *testing.T) {
+	_, c := newClient(t)
+	ctx := context.Background()
+	req := client.CreateLocalNetworkRequest{Name: "net", CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"}
+	if _, err := c.CreateLocalNetwork(ctx, req); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := c.CreateLocalNetwork(ctx, req); !client.IsAPIError(err, 614) {
+		t.Errorf("duplicate: err = %v, want code 614", err)
+	}
+}
+
+func TestLoadBalancerLifecycle(t *testing.T) {
+	_, c := newClient(t)
+	ctx := context.Background()
+	net, _ := c.CreateLocalNetwork(ctx, client.CreateLocalNetworkRequest{Name: "net", CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"})
+	vm, err := c.CreateVm(ctx, client.CreateVmRequest{
+		Name: "web", ImageID: 1, CPUCores: 1, RAM: 1, DiskSize: 10, LocalNetworkID: net.ID, Password: "x",
+	})
+	if err != nil {
+		t.Fatalf("create vm: %v", err)
+	}
+	req := client.LoadBalancerRequest{
+		Name: "lb", IsPublic: true, Protocol: "TCP", UserNetID: net.ID,
+		Backends: []client.LbBackendRef{{UserVmID: vm.Guid}},
+		Ports:    []client.LbPortReq{{BalancerPort: 80, BackendPort: 8080}},
+	}
+	created, err := c.CreateLoadBalancerFrontend(ctx, req, nil)
+	if err != nil {
+		t.Fatalf("create lb: %v", err)
+	}
+	if created.Status != client.LbStatusNew {
+		t.Errorf("create status = %q, want NEW", created.Status)
+	}
+	lb, err := c.GetLoadBalancer(ctx, created.ID, nil)
+	if err != nil {
+		t.Fatalf("get lb: %v", err)
+	}
+	if lb.Status != client.LbStatusSuccess || lb.PublicIP == "" || len(lb.Backends) != 1 || lb.Backends[0].Guid != vm.Guid {
+		t.Errorf("unexpected lb: %+v", lb)
+	}
+
+	if _, err := c.CreateLoadBalancerFrontend(ctx, req, nil); !client.IsAPIError(err, 701) {
+		t.Errorf("duplicate name: err = %v, want code 701", err)
+	}
+	bad := req
+	bad.Name = "other"
+	bad.Backends = []client.LbBackendRef{{UserVmID: "no-such-guid"}}
+	if _, err := c.CreateLoadBalancerFrontend(ctx, bad, nil); !client.IsAPIError(err, 748) {
+		t.Errorf("unknown backend: err = %v, want code 748", err)
+	}
+
+	if err := c.DeleteLoadBalancerFrontend(ctx, lb.ID, nil); err != nil {
+		t.Fatalf("delete lb: %v", err)
+	}
+	if _, err := c.GetLoadBalancer(ctx, lb.ID, nil); !client.IsNotFound(err) {
+		t.Errorf("get deleted lb: err = %v, want not-found", err)
+	}
+}
+
+func TestClusterLifecycle(t *testing.T) {
+	_, c := newClient(t)
+	ctx := context.Background()
+	net, _ := c.CreateLocalNetwork(ctx, client.CreateLocalNetworkRequest{Name: "net", CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"})
+	req := client.CreateClusterRequest{
+		ClusterName: "k8s", KuberVersion: "v1.31.4", NodePoolName: "default",
+		WorkerCPU: 2, WorkerRAM: 4, WorkerDiskSize: 50, WorkerReplicas: 2,
+		LocalNetID: net.ID, MasterNodeConfigID: 11, PodSubnet: "10.244.0.0/16",
+	}
+	created, err := c.CreateCluster(ctx, req, nil)
+	if err != nil {
+		t.Fatalf("create cluster: %v", err)
+	}
+	if created.Status != client.ClusterStatusNew || created.WorkerNodeCount != 0 {
+		t.Errorf("unexpected create response: %+v", created)
+	}
+
+	cl, err := c.GetCluster(ctx, created.ID, nil)
+	if err != nil {
+		t.Fatalf("get cluster: %v", err)
+	}
+	if cl.Status != client.ClusterStatusSuccess || cl.WorkerNodeCount != 2 || cl.NodeIPRange == "" {
+		t.Errorf("unexpected cluster: %+v", cl)
+	}
+	kc := client.ParseKubeConfig(cl.Kubeconfig)
+	if kc == nil || kc.Host != cl.APIEndpoint || kc.ClientKey == "" {
+		t.Errorf("unexpected kubeconfig: %+v", kc)
+	}
+
+	if _, err := c.CreateCluster(ctx, req, nil); err == nil || client.ErrorDetail(err) == err.Error() {
+		t.Errorf("duplicate name: err = %v, want the recognised duplicate-name failure", err)
+	}
+	bad := req
+	bad.ClusterName = "other"
+	bad.KuberVersion = "v0.0.1"
+	if _, err := c.CreateCluster(ctx, bad, nil); !client.IsVersionUnavailable(err) {
+		t.Errorf("unknown version: err = %v, want code 757", err)
+	}
+
+	pools, err := c.ListNodePools(ctx, cl.ID, nil)
+	if err != nil {
+		t.Fatalf("list pools: %v", err)
+	}
+	if len(pools) != 2 || pools[0].Name != "default" {
+		t.Fatalf("unexpected pools: %+v", pools)
+	}
+	if err := c.DeleteNodePool(ctx, pools[0].ID, nil); !client.IsLastWorkerPool(err) {
+		t.Errorf("delete last worker pool: err = %v, want code 756", err)
+	}
+
+	if err := c.DeleteCluster(ctx, cl.ID, nil); err != nil {
+		t.Fatalf("delete cluster: %v", err)
+	}
+	cl, err = c.GetCluster(ctx, cl.ID, nil)
+	if err != nil || cl.Status != client.ClusterStatusDeleted {
+		t.Errorf("get deleted cluster = %+v, %v; want status DELETED", cl, err)
+	}
+	if _, err := c.GetNodePool(ctx, pools[0].ID, nil); !client.IsKuberNotFound(err) {
+		t.Errorf("get pool of a deleted cluster: err = %v, want kuber not-found", err)
+	}
+	if _, err := c.GetCluster(ctx, 999999, nil); !client.IsKuberNotFound(err) {
+		t.Errorf("get unknown cluster: err = %v, want kuber not-found", err)
+	}
+}
+
+func TestBucket_OwnershipAndNotEmpty(t *testing.T) {
+	s, c := newClient(t)
+	ctx := context.Background()
+	if err := c.CreateBucket(ctx, client.CreateBucketRequest{BucketKey: "b1", Acl: "PRIVATE"}, nil); err != nil {
+		t.Fatalf("create bucket: %v", err)
+	}
+	if err := c.CreateBucket(ctx, client.CreateBucketRequest{BucketKey: "b1"}, nil); !client.IsAPIError(err, 626) {
+		t.Errorf("duplicate: err = %v, want code 626", err)
+	}
+	if _, err := c.GetBucket(ctx, "b1", &client.RequestOpts{ProjectTag: "other"}); !client.IsAPIError(err, 712) {
+		t.Errorf("cross-project get: err = %v, want code 712", err)
+	}
+	if vc, err := c.GetBucketVersioning(ctx, "b1", nil); err != nil || vc != nil {
+		t.Errorf("versioning = %+v, %v; want nil (never configured)", vc, err)
+	}
+
+	s.SetBucketObjectCount("b1", 3)
+	if err := c.DeleteBucket(ctx, "b1", nil); !client.IsAPIError(err, 738) {
+		t.Errorf("delete non-empty: err = %v, want code 738", err)
+	}
+	s.SetBucketObjectCount("b1", 0)
+	if err := c.DeleteBucket(ctx, "b1", nil); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := c.GetBucket(ctx, "b1", nil); !client.IsNotFound(err) {
+		t.Errorf("get deleted bucket: err = %v, want not-found", err)
+	}
+}
+
+func TestBucket_ListPaginates(t *testing.T) {
+	_, c := newClient(t)
+	ctx := context.Background()
+	for _, name := range []string{"a", "b", "c"} {
+		if err := c.CreateBucket(ctx, client.CreateBucketRequest{BucketKey: name}, nil); err != nil {
+			t.Fatalf("create %s: %v", name, err)
+		}
+	}
+	got, err := c.ListBuckets(ctx, 2, nil)
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("listed %d buckets, want 3", len(got))
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	s, c := newClient(t)
+	s.FailNext(http.MethodGet, "/api/v2/images", 1, http.StatusConflict, 999)
+	if _, err := c.GetImages(context.Background(), nil); !client.IsAPIError(err, 999) {
+		t.Errorf("first call: err = %v, want injected code 999", err)
+	}
+	if _, err := c.GetImages(context.Background(), nil); err != nil {
+		t.Errorf("second call: %v, want success once the fault is spent", err)
+	}
+	if n := s.RequestCount(http.MethodGet, "/api/v2/images"); n != 2 {
+		t.Errorf("RequestCount = %d, want 2", n)
+	}
+}
+
+func TestIdempotencyKey_DroppedCreateAppliedOnce(t *testing.T) {
+	s, c := newClient(t)
+	ctx := context.Background()
+	// The first create's echoed key tells the client the panel deduplicates.
+	if _, err := c.CreateVolume(ctx, client.CreateVolumeRequest{Name: "a", Type: "SSD", Size: 10}); err != nil {
+		t.Fatalf("create volume: %v", err)
+	}
+	s.DropResponseNext(http.MethodPost, "/api/v2/volumes", 1)
+	v, err := c.CreateVolume(ctx, client.CreateVolumeRequest{Name: "b", Type: "SSD", Size: 10})
+	if err != nil {
+		t.Fatalf("create volume after a dropped response: %v", err)
+	}
+	if v.Name != "b" {
+		t.Errorf("volume = %+v, want the replayed create of b", v)
+	}
+	list, err := c.GetVolumes(ctx, nil)
+	if err != nil {
+		t.Fatalf("list volumes: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("list = %+v, want the re-sent create applied once", list)
+	}
+	if n := s.RequestCount(http.MethodPost, "/api/v2/volumes"); n != 3 {
+		t.Errorf("RequestCount = %d, want 3", n)
+	}
+}
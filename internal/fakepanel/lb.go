@@ -0,0 +1,264 @@
+package fakepanel
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+)
+
+// ---- wire types (V1 load balancer) ----
+
+type statusDTO struct {
+	Name string `json:"name"`
+}
+
+type lbBackend struct {
+	IP     string    `json:"ip"`
+	Status statusDTO `json:"status"`
+	UserVM struct {
+		Guid string `json:"guid"`
+	} `json:"userVM"`
+}
+
+type lbPort struct {
+	SrcPort int32 `json:"srcPort"`
+	DstPort int32 `json:"dstPort"`
+}
+
+type loadBalancer struct {
+	ID                   int64       `json:"id"`
+	Name                 string      `json:"name"`
+	Description          string      `json:"description"`
+	IsPublic             bool        `json:"isPublic"`
+	Status               statusDTO   `json:"status"`
+	UserNet              int64       `json:"userNet"`
+	DateCreated          string      `json:"dateCreated"`
+	LocalUserVip         string      `json:"localUserVip"`
+	ProvisioningPublicIP string      `json:"provisioningPublicIp"`
+	Protocol             string      `json:"protocol"`
+	Backends             []lbBackend `json:"backends"`
+	Ports                []lbPort    `json:"ports"`
+	Source               string      `json:"source"`
+	Region               string      `json:"region"`
+	ProjectTag           string      `json:"projectTag"`
+
+	scope scope
+}
+
+type lbRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"isPublic"`
+	Protocol    string `json:"protocol"`
+	UserNetID   int64  `json:"userNetId"`
+	NodePoolID  int64  `json:"nodePoolId"`
+	Backends    []struct {
+		UserVMID string `json:"userVmId"`
+	} `json:"backends"`
+	Ports []struct {
+		BalancerPort int32 `json:"balancerPort"`
+		BackendPort  int32 `json:"backendPort"`
+	} `json:"ports"`
+}
+
+// createdAt is the fixed creation timestamp the fake stamps on every object, so
+// that responses (and recorded state) are deterministic.
+const createdAt = "2026-01-01T00:00:00Z"
+
+func (s *Server) registerLB(mux *http.ServeMux) {
+	mux.HandleFunc("POST /panel-main/api/loadbalancer/createLoadbalancer", s.createLB("FRONTEND"))
+	mux.HandleFunc("POST /panel-main/api/ccm/loadbalancer/create", s.createLB("CCM"))
+	mux.HandleFunc("GET /panel-main/api/loadbalancer/getLoadBalancer", s.getLB)
+	mux.HandleFunc("GET /panel-main/api/loadbalancer/getUserLoadBalancers", s.listLBs)
+	mux.HandleFunc("POST /panel-main/api/loadbalancer/configureLoadbalancer/{id}", s.configureLB)
+	mux.HandleFunc("POST /panel-main/api/ccm/loadbalancer/configureLoadbalancer/{id}", s.configureLB)
+	mux.HandleFunc("POST /panel-main/api/loadbalancer/deleteLoadbalancer/{id}", s.deleteLB)
+	mux.HandleFunc("POST /panel-main/api/ccm/loadbalancer/deleteLoadbalancer/{id}", s.deleteLB)
+}
+
+// writeLBNotFound renders the load-balancer not-found error (736), which panel-main
+// sends as a V2 envelope at HTTP 404 even on the V1 endpoints.
+func writeLBNotFound(w http.ResponseWriter) {
+	writeV2Error(w, http.StatusNotFound, 736, "Load balancer not found.")
+}
+
+// createLB returns the create handler for a Frontend- or CCM-source load
+// balancer. Like the real panel, the create response is the NEW snapshot; the
+// stored balancer is already SUCCESS, so the first poll converges.
+func (s *Server) createLB(source string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body lbRequest
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		sc := requestScope(r)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, lb := range s.lbs {
+			if lb.scope.region == sc.region && lb.Name == body.Name {
+				writeV2Error(w, http.StatusBadRequest, 701, "Load balancer name already exists")
+				return
+			}
+		}
+
+		lb := &loadBalancer{
+			ID:          s.newID(),
+			Name:        body.Name,
+			Description: body.Description,
+			IsPublic:    body.IsPublic,
+			Protocol:    body.Protocol,
+			Source:      source,
+			DateCreated: createdAt,
+			Region:      sc.region,
+			ProjectTag:  sc.project,
+			Backends:    []lbBackend{},
+			scope:       sc,
+		}
+		if source == "CCM" {
+			p := s.pools[body.NodePoolID]
+			if p == nil || s.clusters[p.ClusterID].scope != sc {
+				writeV1Error(w, "NodePool not found!")
+				return
+			}
+			lb.UserNet = s.clusters[p.ClusterID].localNetID
+		} else {
+			n := s.networks[body.UserNetID]
+			if n == nil || n.scope != sc {
+				writeNotFound(w, "Local network", body.UserNetID)
+				return
+			}
+			lb.UserNet = n.ID
+			if !s.setLBBackends(w, lb, body) {
+				return
+			}
+		}
+		setLBPorts(lb, body)
+
+		created := *lb
+		created.Status = statusDTO{Name: "NEW"}
+
+		lb.Status = statusDTO{Name: "SUCCESS"}
+		lb.LocalUserVip = s.allocatePrivateIP(s.networks[lb.UserNet])
+		if lb.IsPublic {
+			lb.ProvisioningPublicIP = fmt.Sprintf("198.51.100.%d", lb.ID%250+1)
+		}
+		s.lbs[lb.ID] = lb
+		writeV1(w, &created)
+	}
+}
+
+// setLBBackends resolves the request's backend VM guids in the balancer's scope,
+// answering 748 for an unknown guid. The caller holds s.mu.
+func (s *Server) setLBBackends(w http.ResponseWriter, lb *loadBalancer, body lbRequest) bool {
+	backends := []lbBackend{}
+	for _, ref := range body.Backends {
+		var found *vm
+		for _, v := range s.vms {
+			if v.Guid == ref.UserVMID && v.scope == lb.scope {
+				found = v
+			}
+		}
+		if found == nil {
+			writeV2Error(w, http.StatusBadRequest, 748, "Backend VM not found: "+ref.UserVMID)
+			return false
+		}
+		b := lbBackend{IP: found.PrivateIP, Status: statusDTO{Name: "SUCCESS"}}
+		b.UserVM.Guid = found.Guid
+		backends = append(backends, b)
+	}
+	lb.Backends = backends
+	return true
+}
+
+func setLBPorts(lb *loadBalancer, body lbRequest) {
+	lb.Ports = []lbPort{}
+	for _, p := range body.Ports {
+		lb.Ports = append(lb.Ports, lbPort{SrcPort: p.BalancerPort, DstPort: p.BackendPort})
+	}
+}
+
+// lookupLB returns the balancer with the given id in the request's scope, or
+// renders 736. The caller holds s.mu.
+func (s *Server) lookupLB(w http.ResponseWriter, r *http.Request, id int64) *loadBalancer {
+	lb := s.lbs[id]
+	if lb == nil || lb.scope != requestScope(r) {
+		writeLBNotFound(w)
+		return nil
+	}
+	return lb
+}
+
+func (s *Server) getLB(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.URL.Query().Get("loadBalancerId"), 10, 64)
+	if err != nil {
+		writeV2Error(w, http.StatusBadRequest, 400, "invalid loadBalancerId")
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lb := s.lookupLB(w, r, id); lb != nil {
+		writeV1(w, lb)
+	}
+}
+
+func (s *Server) listLBs(w http.ResponseWriter, r *http.Request) {
+	sc := requestScope(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []*loadBalancer{}
+	for _, lb := range s.lbs {
+		if lb.scope == sc {
+			out = append(out, lb)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	writeV1(w, out)
+}
+
+func (s *Server) configureLB(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	var body lbRequest
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lb := s.lookupLB(w, r, id)
+	if lb == nil {
+		return
+	}
+	for _, o := range s.lbs {
+		if o != lb && o.scope.region == lb.scope.region && o.Name == body.Name {
+			writeV2Error(w, http.StatusBadRequest, 701, "Load balancer name already exists")
+			return
+		}
+	}
+	if lb.Source == "FRONTEND" && !s.setLBBackends(w, lb, body) {
+		return
+	}
+	lb.Name = body.Name
+	lb.Description = body.Description
+	if body.Protocol != "" {
+		lb.Protocol = body.Protocol
+	}
+	setLBPorts(lb, body)
+	writeV1(w, lb)
+}
+
+func (s *Server) deleteLB(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r, "id")
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if lb := s.lookupLB(w, r, id); lb != nil {
+		delete(s.lbs, id)
+		writeV1(w, nil)
+	}
+}
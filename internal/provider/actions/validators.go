@@ -0,0 +1,44 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// PositiveDuration returns a string validator that requires a positive Go
+// duration such as "10m", so a bad timeout fails at validate time rather than
+// partway through an invoke.
+func PositiveDuration() validator.String {
+	return positiveDurationValidator{}
+}
+
+type positiveDurationValidator struct{}
+
+func (v positiveDurationValidator) Description(_ context.Context) string {
+	return `value must be a positive duration such as "30s" or "10m"`
+}
+
+func (v positiveDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v positiveDurationValidator) ValidateString(
+	_ context.Context,
+	req validator.StringRequest,
+	resp *validator.StringResponse,
+) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if d, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid duration",
+			fmt.Sprintf("%q is not a positive duration such as \"10m\".", req.ConfigValue.ValueString()),
+		)
+	}
+}
@@ -0,0 +1,223 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ action.Action              = &VmPowerAction{}
+	_ action.ActionWithConfigure = &VmPowerAction{}
+)
+
+// vmPowerDefaultTimeout bounds each stop or start wait, matching the stop/start
+// cycle VmResource.Update runs around a resize.
+const vmPowerDefaultTimeout = 5 * time.Minute
+
+// vmPowerOp is the power operation a VmPowerAction performs.
+type vmPowerOp string
+
+const (
+	vmPowerStop   vmPowerOp = "stop"
+	vmPowerStart  vmPowerOp = "start"
+	vmPowerReboot vmPowerOp = "reboot"
+)
+
+// VmPowerAction stops, starts or reboots a VM. The three actions share one
+// implementation and schema; op selects what Invoke does.
+type VmPowerAction struct {
+	c  *client.Client
+	op vmPowerOp
+}
+
+type VmPowerActionModel struct {
+	VmID       types.Int64  `tfsdk:"vm_id"`
+	Region     types.String `tfsdk:"region"`
+	ProjectTag types.String `tfsdk:"project_tag"`
+	Timeout    types.String `tfsdk:"timeout"`
+}
+
+func NewVmStopAction() action.Action {
+	return &VmPowerAction{op: vmPowerStop}
+}
+
+func NewVmStartAction() action.Action {
+	return &VmPowerAction{op: vmPowerStart}
+}
+
+func NewVmRebootAction() action.Action {
+	return &VmPowerAction{op: vmPowerReboot}
+}
+
+func (a *VmPowerAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_" + string(a.op)
+}
+
+func (a *VmPowerAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	var description string
+	switch a.op {
+	case vmPowerStop:
+		description = "Stops a virtual machine and waits until it is `STOPPED`. A VM that is not running is left as it is."
+	case vmPowerStart:
+		description = "Starts a virtual machine and waits until it is `RUNNING`. A VM that is already running is left as it is."
+	case vmPowerReboot:
+		description = "Reboots a virtual machine: stops it if it is running, then starts it and waits until it is `RUNNING`."
+	}
+	resp.Schema = schema.Schema{
+		MarkdownDescription: description + " A VM that is starting or stopping is first allowed to settle, " +
+			"and one in `ERROR` is refused. Invoke it with `terraform apply -invoke` or from a resource's " +
+			"`action_trigger`. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"vm_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the virtual machine.",
+				Required:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region ID override. If omitted, uses the provider default.",
+				Optional:            true,
+			},
+			"project_tag": schema.StringAttribute{
+				MarkdownDescription: "Project tag override. If omitted, uses the provider default.",
+				Optional:            true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for each status change, as a Go duration (e.g. `10m`). " +
+					"Defaults to `5m`.",
+				Optional: true,
+				Validators: []validator.String{
+					PositiveDuration(),
+				},
+			},
+		},
+	}
+}
+
+func (a *VmPowerAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	a.c = c
+}
+
+func (a *VmPowerAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_vm_"+string(a.op), "invoke")
+	defer op.End(nil, &resp.Diagnostics)
+
+	var data VmPowerActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := vmPowerDefaultTimeout
+	if !data.Timeout.IsNull() {
+		// PositiveDuration has already rejected anything but a positive duration.
+		timeout, _ = time.ParseDuration(data.Timeout.ValueString())
+	}
+
+	opts := &client.RequestOpts{}
+	if !data.Region.IsNull() && data.Region.ValueString() != "" {
+		opts.Region = data.Region.ValueString()
+	}
+	if !data.ProjectTag.IsNull() && data.ProjectTag.ValueString() != "" {
+		opts.ProjectTag = data.ProjectTag.ValueString()
+	}
+	vmID := data.VmID.ValueInt64()
+
+	// GetVmStatus (not GetVm) so an ERROR-status VM is still readable, as in
+	// VmResource.stopIfRunning.
+	vm, err := a.c.GetVmStatus(ctx, vmID, opts)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("vm_id"), "Unable to read virtual machine", client.ErrorDetail(err))
+		return
+	}
+	tflog.Debug(ctx, "Invoking VM power action", map[string]any{"id": vmID, "op": string(a.op), "status": vm.Status})
+
+	progress := func(format string, args ...any) {
+		if resp.SendProgress != nil {
+			resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf(format, args...)})
+		}
+	}
+
+	// Acting on a VM mid-transition (STARTING, STOPPING) would race the panel's
+	// own operation, so let it settle first. ERROR is refused: a start or stop
+	// will not repair it.
+	switch vm.Status {
+	case "RUNNING", "STOPPED":
+	case "ERROR":
+		resp.Diagnostics.AddAttributeError(path.Root("vm_id"), "Virtual machine is in ERROR",
+			fmt.Sprintf("VM %d reports status ERROR; the %s action does not run against it. "+
+				"Check the VM in the panel, and contact support if it stays in ERROR.", vmID, a.op))
+		return
+	default:
+		progress("VM %d is %s; waiting for it to settle", vmID, vm.Status)
+		settled, err := a.c.WaitForVmSettled(ctx, vmID, timeout, opts)
+		if err != nil {
+			resp.Diagnostics.AddError("Virtual machine did not settle",
+				fmt.Sprintf("VM %d did not reach RUNNING or STOPPED: %s", vmID, err))
+			return
+		}
+		vm = settled
+	}
+
+	stop := a.op == vmPowerStop || a.op == vmPowerReboot
+	start := a.op == vmPowerStart || a.op == vmPowerReboot
+
+	if stop {
+		if vm.Status == "RUNNING" {
+			progress("Stopping VM %d", vmID)
+			if err := a.c.StopVm(ctx, vmID, opts); err != nil {
+				resp.Diagnostics.AddError("Unable to stop virtual machine", client.ErrorDetail(err))
+				return
+			}
+			if err := a.c.WaitForVmStatus(ctx, vmID, "STOPPED", timeout, opts); err != nil {
+				resp.Diagnostics.AddError("Virtual machine did not stop",
+					fmt.Sprintf("VM %d was asked to stop but did not reach STOPPED: %s", vmID, err))
+				return
+			}
+			progress("VM %d is STOPPED", vmID)
+			vm.Status = "STOPPED"
+		} else if !start {
+			progress("VM %d is %s, not running; nothing to stop", vmID, vm.Status)
+		}
+	}
+
+	if start {
+		if vm.Status == "RUNNING" {
+			progress("VM %d is already RUNNING", vmID)
+			return
+		}
+		progress("Starting VM %d", vmID)
+		if err := a.c.StartVm(ctx, vmID, opts); err != nil {
+			resp.Diagnostics.AddError("Unable to start virtual machine", client.ErrorDetail(err))
+			return
+		}
+		if err := a.c.WaitForVmStatus(ctx, vmID, "RUNNING", timeout, opts); err != nil {
+			resp.Diagnostics.AddError("Virtual machine did not start",
+				fmt.Sprintf("VM %d was asked to start but did not reach RUNNING: %s", vmID, err))
+			return
+		}
+		progress("VM %d is RUNNING", vmID)
+	}
+}
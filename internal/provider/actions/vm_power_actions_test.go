@@ -0,0 +1,183 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/fakepanel"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// invoke runs a power action against the fake panel for vmID, returning the
+// progress messages it sent.
+func invoke(t *testing.T, c *client.Client, newAction func() action.Action, vmID int64, timeout string) ([]string, action.InvokeResponse) {
+	t.Helper()
+	ctx := context.Background()
+	a := newAction()
+	a.(action.ActionWithConfigure).Configure(ctx, action.ConfigureRequest{ProviderData: c}, &action.ConfigureResponse{})
+
+	var schemaResp action.SchemaResponse
+	a.Schema(ctx, action.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	timeoutVal := tftypes.NewValue(tftypes.String, nil)
+	if timeout != "" {
+		timeoutVal = tftypes.NewValue(tftypes.String, timeout)
+	}
+	raw := tftypes.NewValue(typ, map[string]tftypes.Value{
+		"vm_id":       tftypes.NewValue(tftypes.Number, vmID),
+		"region":      tftypes.NewValue(tftypes.String, nil),
+		"project_tag": tftypes.NewValue(tftypes.String, nil),
+		"timeout":     timeoutVal,
+	})
+
+	var progress []string
+	resp := action.InvokeResponse{
+		SendProgress: func(e action.InvokeProgressEvent) { progress = append(progress, e.Message) },
+	}
+	a.Invoke(ctx, action.InvokeRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}}, &resp)
+	return progress, resp
+}
+
+func TestVmPowerActions(t *testing.T) {
+	s := fakepanel.New(t)
+	c, err := client.New(client.Config{
+		APIBaseURL:   s.URL(),
+		APIKeyID:     fakepanel.APIKeyID,
+		APISecretKey: fakepanel.APISecretKey,
+		Region:       fakepanel.Region,
+		ProjectTag:   fakepanel.ProjectTag,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	network, err := c.CreateLocalNetwork(ctx, client.CreateLocalNetworkRequest{
+		Region: fakepanel.Region, ProjectTag: fakepanel.ProjectTag,
+		Name: "power-net", CIDR: "10.50.0.0/24", Gateway: "10.50.0.1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm, err := c.CreateVm(ctx, client.CreateVmRequest{
+		Name: "power", ImageID: 1, CPUCores: 1, RAM: 1, DiskSize: 20, DiskType: "SSD",
+		LocalNetworkID: network.ID, Password: "FakePanel123",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := func() string {
+		t.Helper()
+		got, err := c.GetVmStatus(ctx, vm.ID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got.Status
+	}
+	stopPath := fmt.Sprintf("/api/v2/vms/%d/stop", vm.ID)
+	startPath := fmt.Sprintf("/api/v2/vms/%d/start", vm.ID)
+
+	if _, resp := invoke(t, c, NewVmStopAction, vm.ID, ""); resp.Diagnostics.HasError() {
+		t.Fatalf("stop: %v", resp.Diagnostics)
+	}
+	if got := status(); got != "STOPPED" {
+		t.Fatalf("after stop: status %s", got)
+	}
+
+	// Stopping a stopped VM is a no-op.
+	progress, resp := invoke(t, c, NewVmStopAction, vm.ID, "")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("second stop: %v", resp.Diagnostics)
+	}
+	if n := s.RequestCount(http.MethodPost, stopPath); n != 1 {
+		t.Errorf("panel saw %d stops, want 1", n)
+	}
+	if len(progress) != 1 || !strings.Contains(progress[0], "nothing to stop") {
+		t.Errorf("progress = %q", progress)
+	}
+
+	if _, resp := invoke(t, c, NewVmStartAction, vm.ID, "1m"); resp.Diagnostics.HasError() {
+		t.Fatalf("start: %v", resp.Diagnostics)
+	}
+	if got := status(); got != "RUNNING" {
+		t.Fatalf("after start: status %s", got)
+	}
+
+	// Reboot of a running VM is a stop then a start.
+	if _, resp := invoke(t, c, NewVmRebootAction, vm.ID, ""); resp.Diagnostics.HasError() {
+		t.Fatalf("reboot: %v", resp.Diagnostics)
+	}
+	if got := status(); got != "RUNNING" {
+		t.Errorf("after reboot: status %s", got)
+	}
+	if n := s.RequestCount(http.MethodPost, stopPath); n != 2 {
+		t.Errorf("panel saw %d stops, want 2", n)
+	}
+	if n := s.RequestCount(http.MethodPost, startPath); n != 2 {
+		t.Errorf("panel saw %d starts, want 2", n)
+	}
+
+	// A VM mid-transition settles before the action acts on it.
+	s.SetVmStatus(vm.ID, "STOPPING", "STOPPED")
+	progress, resp = invoke(t, c, NewVmStartAction, vm.ID, "")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("start while stopping: %v", resp.Diagnostics)
+	}
+	if got := status(); got != "RUNNING" {
+		t.Errorf("after start while stopping: status %s", got)
+	}
+	if len(progress) == 0 || !strings.Contains(progress[0], "waiting for it to settle") {
+		t.Errorf("progress = %q", progress)
+	}
+	s.SetVmStatus(vm.ID, "STARTING", "RUNNING")
+	if _, resp := invoke(t, c, NewVmStartAction, vm.ID, ""); resp.Diagnostics.HasError() {
+		t.Fatalf("start while starting: %v", resp.Diagnostics)
+	}
+	if n := s.RequestCount(http.MethodPost, startPath); n != 3 {
+		t.Errorf("panel saw %d starts, want 3: a VM that settles RUNNING needs no start", n)
+	}
+
+	// ERROR is refused without a start.
+	s.SetVmStatus(vm.ID, "ERROR", "")
+	_, resp = invoke(t, c, NewVmRebootAction, vm.ID, "")
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Virtual machine is in ERROR" {
+		t.Errorf("reboot of an ERROR VM: %v", resp.Diagnostics)
+	}
+	if n := s.RequestCount(http.MethodPost, startPath); n != 3 {
+		t.Errorf("panel saw %d starts after the refused reboot, want 3", n)
+	}
+
+	if _, resp := invoke(t, c, NewVmStopAction, 999999, ""); !resp.Diagnostics.HasError() {
+		t.Error("a missing VM should fail")
+	}
+}
+
+func TestVmPowerActions_timeoutValidated(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp action.SchemaResponse
+	NewVmStartAction().Schema(ctx, action.SchemaRequest{}, &schemaResp)
+	validators := schemaResp.Schema.Attributes["timeout"].(schema.StringAttribute).Validators
+
+	for value, valid := range map[string]bool{"10m": true, "90s": true, "soon": false, "0s": false, "-1m": false} {
+		resp := validator.StringResponse{}
+		for _, v := range validators {
+			v.ValidateString(ctx, validator.StringRequest{
+				Path:        path.Root("timeout"),
+				ConfigValue: types.StringValue(value),
+			}, &resp)
+		}
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("timeout %q: valid = %t, diagnostics %v", value, valid, resp.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultProfile is the profile used when none is named in the provider block or
+// in PRODATA_PROFILE.
+const defaultProfile = "default"
+
+// credentialsProfile is one named profile of the shared credentials file. Every
+// field is optional; an empty field falls through to the next source in the
+// precedence chain (see resolveSetting).
+type credentialsProfile struct {
+	APIBaseURL   string `yaml:"api_base_url"`
+	APIKeyID     string `yaml:"api_key_id"`
+	APISecretKey string `yaml:"api_secret_key"`
+	Region       string `yaml:"region"`
+	ProjectTag   string `yaml:"project_tag"`
+	// CredentialProcess is a command printing the API key pair as JSON.
+	CredentialProcess string `yaml:"credential_process"`
+	// Connection settings for panels behind a proxy or a private CA.
+	CABundle          string `yaml:"ca_bundle"`
+	ClientCertificate string `yaml:"client_certificate"`
+	ClientKey         string `yaml:"client_key"`
+	ProxyURL          string `yaml:"proxy_url"`
+	TLSMinVersion     string `yaml:"tls_min_version"`
+}
+
+// set assigns the profile field named by an INI key.
+func (p *credentialsProfile) set(key, value string) error {
+	switch key {
+	case "api_base_url":
+		p.APIBaseURL = value
+	case "api_key_id":
+		p.APIKeyID = value
+	case "api_secret_key":
+		p.APISecretKey = value
+	case "region":
+		p.Region = value
+	case "project_tag":
+		p.ProjectTag = value
+	case "credential_process":
+		p.CredentialProcess = value
+	case "ca_bundle":
+		p.CABundle = value
+	case "client_certificate":
+		p.ClientCertificate = value
+	case "client_key":
+		p.ClientKey = value
+	case "proxy_url":
+		p.ProxyURL = value
+	case "tls_min_version":
+		p.TLSMinVersion = value
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return nil
+}
+
+// credentialsFilePath returns PRODATA_CREDENTIALS_FILE, else ~/.prodata/credentials.
+func credentialsFilePath() (string, error) {
+	if p := os.Getenv("PRODATA_CREDENTIALS_FILE"); p != "" {
+		return p, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("locate home directory: %w", err)
+	}
+	return filepath.Join(home, ".prodata", "credentials"), nil
+}
+
+// loadProfile returns the named profile from the shared credentials file. A
+// missing file or profile is an error only when the profile was asked for
+// explicitly; the implicit "default" profile is optional, so a user relying on
+// attributes or environment variables never needs the file.
+func loadProfile(name string, explicit bool) (credentialsProfile, error) {
+	path, err := credentialsFilePath()
+	if err != nil {
+		if explicit {
+			return credentialsProfile{}, err
+		}
+		return credentialsProfile{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return credentialsProfile{}, nil
+		}
+		return credentialsProfile{}, fmt.Errorf("read credentials file: %w", err)
+	}
+	profiles, err := parseCredentialsFile(data)
+	if err != nil {
+		return credentialsProfile{}, fmt.Errorf("parse credentials file %s: %w", path, err)
+	}
+	p, ok := profiles[name]
+	if !ok && explicit {
+		return credentialsProfile{}, fmt.Errorf("profile %q not found in credentials file %s", name, path)
+	}
+	return p, nil
+}
+
+// parseCredentialsFile parses the shared credentials file, which is either INI
+// (`[name]` sections of `key = value` lines, as in the AWS CLI) or YAML (a map of
+// profile name to fields). A file whose first meaningful line opens a section is
+// INI; anything else is YAML.
+func parseCredentialsFile(data []byte) (map[string]credentialsProfile, error) {
+	if isINI(data) {
+		return parseCredentialsINI(data)
+	}
+	profiles := map[string]credentialsProfile{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&profiles); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func isINI(data []byte) bool {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		return strings.HasPrefix(line, "[")
+	}
+	return false
+}
+
+func parseCredentialsINI(data []byte) (map[string]credentialsProfile, error) {
+	profiles := map[string]credentialsProfile{}
+	var section string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "["):
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated section header", n)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			// "[profile staging]" is accepted as well, as in ~/.aws/config.
+			section = strings.TrimSpace(strings.TrimPrefix(section, "profile "))
+			if section == "" {
+				return nil, fmt.Errorf("line %d: empty section name", n)
+			}
+			if _, ok := profiles[section]; !ok {
+				profiles[section] = credentialsProfile{}
+			}
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, fmt.Errorf("line %d: expected key = value", n)
+			}
+			if section == "" {
+				return nil, fmt.Errorf("line %d: key outside a [profile] section", n)
+			}
+			value = strings.TrimSpace(value)
+			if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+				value = value[1 : len(value)-1]
+			}
+			p := profiles[section]
+			if err := p.set(strings.TrimSpace(key), value); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			profiles[section] = p
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// resolveSetting applies the provider's precedence chain to one setting:
+//
+//  1. the provider block attribute;
+//  2. the profile, when it was named in the provider block;
+//  3. the PRODATA_* environment variable;
+//  4. the profile, when it came from PRODATA_PROFILE or is the implicit default.
+//
+// Naming a profile in configuration is a deliberate per-provider (per-alias)
+// choice, so it outranks ambient environment variables; a profile selected from
+// the environment does not.
+func resolveSetting(attr, envVar, profileValue string, profileFromConfig bool) string {
+	if attr != "" {
+		return attr
+	}
+	if profileFromConfig && profileValue != "" {
+		return profileValue
+	}
+	if v := os.Getenv(envVar); v != "" {
+		return v
+	}
+	return profileValue
+}
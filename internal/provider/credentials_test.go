@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+
+	"terraform-provider-prodata/internal/fakepanel"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const credentialsINI = `
+# Shared ProData credentials.
+[default]
+api_base_url   = https://my.pro-data.tech
+api_key_id     = default-key
+api_secret_key = "default-secret"
+region         = UZ-5
+
+[profile staging]
+api_key_id         = staging-key
+project_tag        = 'staging-42'
+credential_process = vault-creds staging
+`
+
+const credentialsYAML = `
+default:
+  api_base_url: https://my.pro-data.tech
+  api_key_id: default-key
+  api_secret_key: default-secret
+  region: UZ-5
+staging:
+  api_key_id: staging-key
+  project_tag: staging-42
+  credential_process: vault-creds staging
+`
+
+func TestParseCredentialsFile_INIAndYAML(t *testing.T) {
+	for name, data := range map[string]string{"ini": credentialsINI, "yaml": credentialsYAML} {
+		t.Run(name, func(t *testing.T) {
+			profiles, err := parseCredentialsFile([]byte(data))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			want := map[string]credentialsProfile{
+				"default": {APIBaseURL: "https://my.pro-data.tech", APIKeyID: "default-key", APISecretKey: "default-secret", Region: "UZ-5"},
+				"staging": {APIKeyID: "staging-key", ProjectTag: "staging-42", CredentialProcess: "vault-creds staging"},
+			}
+			if fmt.Sprint(profiles) != fmt.Sprint(want) {
+				t.Errorf("profiles = %+v, want %+v", profiles, want)
+			}
+		})
+	}
+}
+
+func TestParseCredentialsFile_Errors(t *testing.T) {
+	cases := map[string]string{
+		"ini unknown key":    "[default]\napi_key = x\n",
+		"ini no section":     "[default\napi_key_id = x\n",
+		"ini missing equals": "[default]\napi_key_id x\n",
+		"yaml unknown key":   "default:\n  api_key: x\n",
+		"yaml not a map":     "- a\n- b\n",
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseCredentialsFile([]byte(data)); err == nil {
+				t.Error("parse succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestLoadProfile_MissingIsOnlyAnErrorWhenExplicit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	t.Setenv("PRODATA_CREDENTIALS_FILE", path)
+
+	if _, err := loadProfile(defaultProfile, false); err != nil {
+		t.Errorf("implicit default with no file: %v", err)
+	}
+	if _, err := loadProfile("staging", true); err == nil {
+		t.Error("explicit profile with no file succeeded, want an error")
+	}
+
+	if err := os.WriteFile(path, []byte(credentialsINI), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadProfile("prod", true); err == nil || !strings.Contains(err.Error(), `profile "prod" not found`) {
+		t.Errorf("err = %v, want profile not found", err)
+	}
+	p, err := loadProfile("staging", true)
+	if err != nil || p.APIKeyID != "staging-key" {
+		t.Errorf("staging = %+v, %v", p, err)
+	}
+}
+
+func TestResolveSetting_Precedence(t *testing.T) {
+	t.Setenv("PRODATA_REGION", "env")
+	cases := []struct {
+		name              string
+		attr, profile     string
+		profileFromConfig bool
+		want              string
+	}{
+		{"attribute wins", "attr", "profile", true, "attr"},
+		{"configured profile beats env", "", "profile", true, "profile"},
+		{"env beats env-selected profile", "", "profile", false, "env"},
+		{"configured profile without the field falls to env", "", "", true, "env"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := resolveSetting(tc.attr, "PRODATA_REGION", tc.profile, tc.profileFromConfig); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	t.Setenv("PRODATA_REGION", "")
+	if got := resolveSetting("", "PRODATA_REGION", "profile", false); got != "profile" {
+		t.Errorf("with no env, got %q, want the default profile's value", got)
+	}
+}
+
+// TestUnitProvider_profile configures the provider from a named profile only and
+// applies a resource against the fake panel; an unknown profile fails Configure.
+func TestUnitProvider_profile(t *testing.T) {
+	s := testFakePanel(t)
+	path := filepath.Join(t.TempDir(), "credentials")
+	data := fmt.Sprintf("[fake]\napi_base_url = %s\napi_key_id = %s\napi_secret_key = %s\nregion = %s\nproject_tag = %s\n",
+		s.URL(), fakepanel.APIKeyID, fakepanel.APISecretKey, fakepanel.Region, fakepanel.ProjectTag)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PRODATA_CREDENTIALS_FILE", path)
+	// Ambient credentials must lose to the profile named in configuration.
+	t.Setenv("PRODATA_API_SECRET_KEY", "wrong-secret")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `provider "prodata" { profile = "nope" }` + testAccLocalNetworkConfig("net", "10.60.0.0/24", "10.60.0.1"),
+				ExpectError: regexp.MustCompile(`profile "nope" not found`),
+			},
+			{
+				Config: `provider "prodata" { profile = "fake" }` + testAccLocalNetworkConfig("net", "10.60.0.0/24", "10.60.0.1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("prodata_local_network.test", tfjsonpath.New("project_tag"), knownvalue.StringExact(fakepanel.ProjectTag)),
+				},
+			},
+		},
+	})
+}
+
+// TestUnitProvider_credentialProcess takes the key pair from a credential_process
+// only; a failing process is reported by Configure with its stderr.
+func TestUnitProvider_credentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a POSIX shell")
+	}
+	s := testFakePanel(t)
+	t.Setenv("PRODATA_API_KEY_ID", "")
+	t.Setenv("PRODATA_API_SECRET_KEY", "")
+	t.Setenv("PRODATA_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "none"))
+	config := func(process string) string {
+		return fmt.Sprintf(`
+provider "prodata" {
+  api_base_url       = %q
+  region             = %q
+  project_tag        = %q
+  credential_process = %q
+}
+`, s.URL(), fakepanel.Region, fakepanel.ProjectTag, process) + testAccLocalNetworkConfig("net", "10.61.0.0/24", "10.61.0.1")
+	}
+	process := fmt.Sprintf(`echo '{"api_key_id":%q,"api_secret_key":%q}'`, fakepanel.APIKeyID, fakepanel.APISecretKey)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`echo "vault is sealed" >&2; exit 1`),
+				ExpectError: regexp.MustCompile(`vault is sealed`),
+			},
+			{
+				Config: config(process),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("prodata_local_network.test", tfjsonpath.New("name"), knownvalue.StringExact("net")),
+				},
+			},
+		},
+	})
+}
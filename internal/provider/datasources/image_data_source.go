@@ -140,7 +140,7 @@ func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	image, err := d.client.GetImage(ctx, query)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read Image", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Image", client.ErrorDetail(err))
 		return
 	}
 
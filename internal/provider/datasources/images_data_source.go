@@ -123,7 +123,7 @@ func (d *ImagesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	images, err := d.client.GetImages(ctx, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to List Images", err.Error())
+		resp.Diagnostics.AddError("Unable to List Images", client.ErrorDetail(err))
 		return
 	}
 
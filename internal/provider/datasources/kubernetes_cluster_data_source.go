@@ -231,7 +231,7 @@ func (d *K8sClusterDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		tflog.Debug(ctx, "Reading Kubernetes cluster by id", map[string]any{"id": id})
 		found, err := d.c.GetCluster(ctx, id, opts)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to read Kubernetes cluster", client.KuberErrorDetail(err))
+			resp.Diagnostics.AddError("Unable to read Kubernetes cluster", client.ErrorDetail(err))
 			return
 		}
 		if found.Status == client.ClusterStatusDeleted {
@@ -245,7 +245,7 @@ func (d *K8sClusterDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		tflog.Debug(ctx, "Reading Kubernetes cluster by name", map[string]any{"name": name})
 		clusters, err := d.c.ListClusters(ctx, opts)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to list Kubernetes clusters", client.KuberErrorDetail(err))
+			resp.Diagnostics.AddError("Unable to list Kubernetes clusters", client.ErrorDetail(err))
 			return
 		}
 		want := strings.ToLower(name)
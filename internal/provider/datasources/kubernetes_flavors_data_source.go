@@ -148,7 +148,7 @@ func (d *K8sFlavorsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	for _, ha := range haValues {
 		got, err := d.c.GetMasterNodeConfigs(ctx, ha, opts)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to list Kubernetes flavors", client.KuberErrorDetail(err))
+			resp.Diagnostics.AddError("Unable to list Kubernetes flavors", client.ErrorDetail(err))
 			return
 		}
 		configs = append(configs, got...)
@@ -164,7 +164,7 @@ func (d *K8sNodePoolDataSource) Read(ctx context.Context, req datasource.ReadReq
 		tflog.Debug(ctx, "Reading node pool by id", map[string]any{"id": id, "cluster_id": clusterID})
 		found, err := d.c.GetNodePool(ctx, id, opts)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to read node pool", client.KuberErrorDetail(err))
+			resp.Diagnostics.AddError("Unable to read node pool", client.ErrorDetail(err))
 			return
 		}
 		if found.ClusterID != clusterID {
@@ -178,7 +178,7 @@ func (d *K8sNodePoolDataSource) Read(ctx context.Context, req datasource.ReadReq
 		tflog.Debug(ctx, "Reading node pool by name", map[string]any{"name": name, "cluster_id": clusterID})
 		pools, err := d.c.ListNodePools(ctx, clusterID, opts)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to list node pools", client.KuberErrorDetail(err))
+			resp.Diagnostics.AddError("Unable to list node pools", client.ErrorDetail(err))
 			return
 		}
 		want := strings.ToLower(name)
@@ -118,7 +118,7 @@ func (d *K8sVersionsDataSource) Read(ctx context.Context, req datasource.ReadReq
 	tflog.Debug(ctx, "Listing Kubernetes versions", map[string]any{"include_debug": includeDebug})
 	versions, err := d.c.ListKuberVersions(ctx, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to list Kubernetes versions", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to list Kubernetes versions", client.ErrorDetail(err))
 		return
 	}
 
@@ -185,7 +185,7 @@ func (d *LbDataSource) Read(ctx context.Context, req datasource.ReadRequest, res
 
 	lb, err := d.c.GetLoadBalancer(ctx, id, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to read load balancer", client.LBErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to read load balancer", client.ErrorDetail(err))
 		return
 	}
 	// The by-id endpoint returns soft-deleted load balancers; don't hand back a
@@ -146,7 +146,7 @@ func (d *LbsDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 
 	lbs, err := d.c.ListLoadBalancers(ctx, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to list load balancers", client.LBErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to list load balancers", client.ErrorDetail(err))
 		return
 	}
 
@@ -119,7 +119,7 @@ func (d *LocalNetworkDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	network, err := d.client.GetLocalNetwork(ctx, networkID, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read Local Network", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Local Network", client.ErrorDetail(err))
 		return
 	}
 
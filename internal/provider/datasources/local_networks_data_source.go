@@ -128,7 +128,7 @@ func (d *LocalNetworksDataSource) Read(ctx context.Context, req datasource.ReadR
 
 	networks, err := d.client.GetLocalNetworks(ctx, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to List Local Networks", err.Error())
+		resp.Diagnostics.AddError("Unable to List Local Networks", client.ErrorDetail(err))
 		return
 	}
 
@@ -119,7 +119,7 @@ func (d *PublicIPDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	ip, err := d.client.GetPublicIP(ctx, ipID, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read Public IP", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Public IP", client.ErrorDetail(err))
 		return
 	}
 
@@ -128,7 +128,7 @@ func (d *PublicIPsDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	ips, err := d.client.GetPublicIPs(ctx, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to List Public IPs", err.Error())
+		resp.Diagnostics.AddError("Unable to List Public IPs", client.ErrorDetail(err))
 		return
 	}
 
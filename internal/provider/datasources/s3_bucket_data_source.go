@@ -132,7 +132,7 @@ func (d *S3BucketDataSource) Read(ctx context.Context, req datasource.ReadReques
 		// 712 (cross-project) is surfaced as an error, NOT silently as no-data — same
 		// rule as the resource Read (no silent state drop / no silent empty result for
 		// someone else's bucket with the same name).
-		resp.Diagnostics.AddError("Unable to Read S3 Bucket", err.Error())
+		resp.Diagnostics.AddError("Unable to Read S3 Bucket", client.ErrorDetail(err))
 		return
 	}
 
@@ -156,14 +156,14 @@ func (d *S3BucketDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	vc, err := d.c.GetBucketVersioning(ctx, name, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read Bucket Versioning", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Bucket Versioning", client.ErrorDetail(err))
 		return
 	}
 	data.Versioning = types.BoolValue(versioningFromConfig(vc))
 
 	olc, err := d.c.GetObjectLockConfiguration(ctx, name, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read Bucket Object Lock", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Bucket Object Lock", client.ErrorDetail(err))
 		return
 	}
 	data.ObjectLockEnabled = types.BoolValue(objectLockFromConfig(olc))
@@ -133,7 +133,7 @@ func (d *S3BucketsDataSource) Read(ctx context.Context, req datasource.ReadReque
 	// pageSize=0 lets the server pick its default; ListBuckets follows continuationToken until empty.
 	buckets, err := d.c.ListBuckets(ctx, 0, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to List S3 Buckets", err.Error())
+		resp.Diagnostics.AddError("Unable to List S3 Buckets", client.ErrorDetail(err))
 		return
 	}
 
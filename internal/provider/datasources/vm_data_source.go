@@ -156,7 +156,7 @@ func (d *VmDataSource) Read(ctx context.Context, req datasource.ReadRequest, res
 
 	vm, err := d.client.GetVm(ctx, vmID, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read Virtual Machine", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Virtual Machine", client.ErrorDetail(err))
 		return
 	}
 
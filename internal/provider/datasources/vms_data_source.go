@@ -165,7 +165,7 @@ func (d *VmsDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 
 	vms, err := d.client.GetVms(ctx, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to List Virtual Machines", err.Error())
+		resp.Diagnostics.AddError("Unable to List Virtual Machines", client.ErrorDetail(err))
 		return
 	}
 
@@ -124,7 +124,7 @@ func (d *VolumeDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	volume, err := d.client.GetVolume(ctx, volumeID, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read Volume", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Volume", client.ErrorDetail(err))
 		return
 	}
 	// The by-id endpoint returns soft-deleted volumes (success:true) while the list
@@ -133,7 +133,7 @@ func (d *VolumesDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	volumes, err := d.client.GetVolumes(ctx, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to List Volumes", err.Error())
+		resp.Diagnostics.AddError("Unable to List Volumes", client.ErrorDetail(err))
 		return
 	}
 
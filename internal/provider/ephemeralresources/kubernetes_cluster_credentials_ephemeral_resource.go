@@ -0,0 +1,154 @@
+package ephemeralresources
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/tfutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = &K8sClusterCredentialsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &K8sClusterCredentialsEphemeralResource{}
+)
+
+type K8sClusterCredentialsEphemeralResource struct {
+	c *client.Client
+}
+
+// K8sClusterCredentialsModel is the cluster's kubeconfig, parsed the same way as
+// prodata_kubernetes_cluster's kube_config but never written to plan or state.
+type K8sClusterCredentialsModel struct {
+	ID         types.Int64  `tfsdk:"id"`
+	Region     types.String `tfsdk:"region"`
+	ProjectTag types.String `tfsdk:"project_tag"`
+
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+	Token                types.String `tfsdk:"token"`
+	RawConfig            types.String `tfsdk:"raw_config"`
+}
+
+func NewK8sClusterCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &K8sClusterCredentialsEphemeralResource{}
+}
+
+func (e *K8sClusterCredentialsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubernetes_cluster_credentials"
+}
+
+func (e *K8sClusterCredentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Read a Kubernetes cluster's connection credentials for the duration of a Terraform run " +
+			"only. Unlike `kube_config` on `prodata_kubernetes_cluster`, the values are never stored in plan or " +
+			"state, so they can configure the `kubernetes` or `helm` provider without persisting the client key " +
+			"or token. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Cluster ID.",
+				Required:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region ID override. If omitted, uses the provider default.",
+				Optional:            true,
+			},
+			"project_tag": schema.StringAttribute{
+				MarkdownDescription: "Project tag override. If omitted, uses the provider default.",
+				Optional:            true,
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "Kubernetes API server URL.",
+				Computed:            true,
+			},
+			"cluster_ca_certificate": schema.StringAttribute{
+				MarkdownDescription: "Cluster CA certificate, base64 as in the kubeconfig (wrap in `base64decode()`).",
+				Computed:            true,
+			},
+			"client_certificate": schema.StringAttribute{
+				MarkdownDescription: "Client certificate, base64 as in the kubeconfig (wrap in `base64decode()`).",
+				Computed:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "Client private key, base64 as in the kubeconfig (wrap in `base64decode()`).",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Bearer token, when the kubeconfig uses token authentication.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"raw_config": schema.StringAttribute{
+				MarkdownDescription: "The full kubeconfig as plain YAML.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (e *K8sClusterCredentialsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	e.c = c
+}
+
+func (e *K8sClusterCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data K8sClusterCredentialsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &client.RequestOpts{}
+	if !data.Region.IsNull() && data.Region.ValueString() != "" {
+		opts.Region = data.Region.ValueString()
+	}
+	if !data.ProjectTag.IsNull() && data.ProjectTag.ValueString() != "" {
+		opts.ProjectTag = data.ProjectTag.ValueString()
+	}
+
+	id := data.ID.ValueInt64()
+	tflog.Debug(ctx, "Opening Kubernetes cluster credentials", map[string]any{"id": id})
+	cl, err := e.c.GetCluster(ctx, id, opts)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Unable to read Kubernetes cluster", client.ErrorDetail(err))
+		return
+	}
+	if cl.Status == client.ClusterStatusDeleted {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Kubernetes cluster is deleted",
+			fmt.Sprintf("Cluster %d has been deleted.", id))
+		return
+	}
+	kc := client.ParseKubeConfig(cl.Kubeconfig)
+	if kc == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Kubernetes cluster has no kubeconfig yet",
+			fmt.Sprintf("Cluster %d is %s and has not been issued a kubeconfig. Retry once it is ready.", id, cl.Status))
+		return
+	}
+
+	data.Host = tfutil.StringOrNull(kc.Host)
+	data.ClusterCACertificate = tfutil.StringOrNull(kc.ClusterCACertificate)
+	data.ClientCertificate = tfutil.StringOrNull(kc.ClientCertificate)
+	data.ClientKey = tfutil.StringOrNull(kc.ClientKey)
+	data.Token = tfutil.StringOrNull(kc.Token)
+	data.RawConfig = tfutil.StringOrNull(kc.Raw)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
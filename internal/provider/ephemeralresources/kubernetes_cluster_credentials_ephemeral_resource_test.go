@@ -0,0 +1,148 @@
+package ephemeralresources
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/fakepanel"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// newFakeCluster starts a fake panel holding one SUCCESS cluster and returns it
+// with a client bound to the panel.
+func newFakeCluster(t *testing.T) (*fakepanel.Server, *client.Client, *client.Cluster) {
+	t.Helper()
+	s := fakepanel.New(t)
+	c, err := client.New(client.Config{
+		APIBaseURL:   s.URL(),
+		APIKeyID:     fakepanel.APIKeyID,
+		APISecretKey: fakepanel.APISecretKey,
+		Region:       fakepanel.Region,
+		ProjectTag:   fakepanel.ProjectTag,
+	})
+	if err != nil {
+		t.Fatalf("create client: %v", err)
+	}
+	ctx := context.Background()
+	network, err := c.CreateLocalNetwork(ctx, client.CreateLocalNetworkRequest{Name: "net", CIDR: "10.0.0.0/24", Gateway: "10.0.0.1"})
+	if err != nil {
+		t.Fatalf("create network: %v", err)
+	}
+	created, err := c.CreateCluster(ctx, client.CreateClusterRequest{
+		ClusterName: "k8s", KuberVersion: "v1.31.4", NodePoolName: "default",
+		WorkerCPU: 2, WorkerRAM: 4, WorkerDiskSize: 50, WorkerReplicas: 2,
+		LocalNetID: network.ID, MasterNodeConfigID: 11, PodSubnet: "10.244.0.0/16",
+	}, nil)
+	if err != nil {
+		t.Fatalf("create cluster: %v", err)
+	}
+	cl, err := c.GetCluster(ctx, created.ID, nil)
+	if err != nil {
+		t.Fatalf("get cluster: %v", err)
+	}
+	return s, c, cl
+}
+
+// open calls the credentials resource's Open the way the framework does, for
+// the cluster with the given id.
+func open(t *testing.T, c *client.Client, id int64) ephemeral.OpenResponse {
+	t.Helper()
+	ctx := context.Background()
+	e := NewK8sClusterCredentialsEphemeralResource()
+	var configureResp ephemeral.ConfigureResponse
+	e.(ephemeral.EphemeralResourceWithConfigure).Configure(ctx, ephemeral.ConfigureRequest{ProviderData: c}, &configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure: %v", configureResp.Diagnostics)
+	}
+	var schemaResp ephemeral.SchemaResponse
+	e.Schema(ctx, ephemeral.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	vals := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		vals[name] = tftypes.NewValue(attrType, nil)
+	}
+	vals["id"] = tftypes.NewValue(tftypes.Number, id)
+	req := ephemeral.OpenRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, vals)},
+	}
+	resp := ephemeral.OpenResponse{
+		Result: tfsdk.EphemeralResultData{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, nil)},
+	}
+	e.Open(ctx, req, &resp)
+	return resp
+}
+
+func TestK8sClusterCredentials_Open(t *testing.T) {
+	_, c, cl := newFakeCluster(t)
+	resp := open(t, c, cl.ID)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var got K8sClusterCredentialsModel
+	if diags := resp.Result.Get(context.Background(), &got); diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	// Every connection field comes from the cluster's kubeconfig as ParseKubeConfig
+	// reads it; the fake's kubeconfig uses certificates, so token stays null.
+	kc := client.ParseKubeConfig(cl.Kubeconfig)
+	if kc == nil || kc.Host == "" || kc.ClientKey == "" {
+		t.Fatalf("fake cluster kubeconfig = %+v", kc)
+	}
+	want := map[string][2]string{
+		"host":                   {got.Host.ValueString(), kc.Host},
+		"cluster_ca_certificate": {got.ClusterCACertificate.ValueString(), kc.ClusterCACertificate},
+		"client_certificate":     {got.ClientCertificate.ValueString(), kc.ClientCertificate},
+		"client_key":             {got.ClientKey.ValueString(), kc.ClientKey},
+		"raw_config":             {got.RawConfig.ValueString(), kc.Raw},
+	}
+	for name, v := range want {
+		if v[0] != v[1] {
+			t.Errorf("%s = %q, want %q", name, v[0], v[1])
+		}
+	}
+	if got.Host.ValueString() != cl.APIEndpoint {
+		t.Errorf("host = %s, want the cluster's API endpoint %s", got.Host, cl.APIEndpoint)
+	}
+	if !got.Token.IsNull() {
+		t.Errorf("token = %s, want null", got.Token)
+	}
+	if got.ID.ValueInt64() != cl.ID {
+		t.Errorf("id = %s, want %d", got.ID, cl.ID)
+	}
+}
+
+func TestK8sClusterCredentials_OpenDeleted(t *testing.T) {
+	_, c, cl := newFakeCluster(t)
+	if err := c.DeleteCluster(context.Background(), cl.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+	resp := open(t, c, cl.ID)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Kubernetes cluster is deleted" {
+		t.Errorf("diagnostics = %v, want the deleted-cluster error", resp.Diagnostics)
+	}
+}
+
+func TestK8sClusterCredentials_OpenWithoutKubeconfig(t *testing.T) {
+	s, c, cl := newFakeCluster(t)
+	if !s.SetClusterProvisioning(cl.ID) {
+		t.Fatal("cluster not found in the fake")
+	}
+	resp := open(t, c, cl.ID)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Kubernetes cluster has no kubeconfig yet" {
+		t.Errorf("diagnostics = %v, want the no-kubeconfig error", resp.Diagnostics)
+	}
+}
+
+func TestK8sClusterCredentials_OpenMissing(t *testing.T) {
+	_, c, _ := newFakeCluster(t)
+	resp := open(t, c, 999999)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Unable to read Kubernetes cluster" {
+		t.Errorf("diagnostics = %v, want the read error", resp.Diagnostics)
+	}
+}
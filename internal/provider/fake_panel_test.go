@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/fakepanel"
+)
+
+// testFakePanel starts an in-process fake panel for a hermetic resource test. The
+// tests drive the real Terraform CLI through resource.UnitTest, so they run without
+// TF_ACC and without credentials, but they still need a terraform binary. When none
+// is available (TF_ACC_TERRAFORM_PATH unset and nothing on PATH) the test is skipped
+// rather than letting terraform-plugin-testing download one, keeping `go test ./...`
+// offline; CI installs one so they always run there. The CRUD tests of the resources
+// package cover the same lifecycles without the CLI.
+func testFakePanel(t *testing.T) *fakepanel.Server {
+	t.Helper()
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("terraform CLI not found; set TF_ACC_TERRAFORM_PATH to run hermetic resource tests")
+		}
+	}
+	return fakepanel.New(t)
+}
+
+// testFakeProviderConfig renders a provider block pointing at the fake panel. The
+// configuration is explicit (not via PRODATA_* environment variables) so that it
+// always wins over a developer's real credentials and tests stay isolated.
+func testFakeProviderConfig(s *fakepanel.Server) string {
+	return fmt.Sprintf(`
+provider "prodata" {
+  api_base_url   = %q
+  api_key_id     = %q
+  api_secret_key = %q
+  region         = %q
+  project_tag    = %q
+}
+`, s.URL(), fakepanel.APIKeyID, fakepanel.APISecretKey, fakepanel.Region, fakepanel.ProjectTag)
+}
+
+// testFakeClient returns a client bound to the fake panel, for CheckDestroy
+// assertions and out-of-band changes.
+func testFakeClient(t *testing.T, s *fakepanel.Server) *client.Client {
+	t.Helper()
+	c, err := client.New(client.Config{
+		APIBaseURL:   s.URL(),
+		APIKeyID:     fakepanel.APIKeyID,
+		APISecretKey: fakepanel.APISecretKey,
+		Region:       fakepanel.Region,
+		ProjectTag:   fakepanel.ProjectTag,
+		UserAgent:    "terraform-provider-prodata/fakepanel",
+	})
+	if err != nil {
+		t.Fatalf("create fake panel client: %v", err)
+	}
+	return c
+}
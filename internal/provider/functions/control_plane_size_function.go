@@ -0,0 +1,100 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-prodata/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ControlPlaneSizeFunction{}
+
+type ControlPlaneSizeFunction struct{}
+
+// FlavorModel is one element of the flavors argument: the subset of a
+// prodata_kubernetes_flavors entry the size mapping needs. Terraform drops the
+// entry's other attributes when converting the argument.
+type FlavorModel struct {
+	ID               int64 `tfsdk:"id"`
+	VCPU             int64 `tfsdk:"vcpu"`
+	RAM              int64 `tfsdk:"ram"`
+	DiskSize         int64 `tfsdk:"disk_size"`
+	HighAvailability bool  `tfsdk:"high_availability"`
+}
+
+func flavorAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":                types.Int64Type,
+		"vcpu":              types.Int64Type,
+		"ram":               types.Int64Type,
+		"disk_size":         types.Int64Type,
+		"high_availability": types.BoolType,
+	}
+}
+
+func NewControlPlaneSizeFunction() function.Function {
+	return &ControlPlaneSizeFunction{}
+}
+
+func (f *ControlPlaneSizeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "control_plane_size"
+}
+
+func (f *ControlPlaneSizeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Map a master flavor to its control-plane size",
+		MarkdownDescription: "Returns the control-plane size (`small`, `medium` or `large`) of a master flavor, " +
+			"ranked by capacity within its HA mode in the given catalog — the value " +
+			"`prodata_kubernetes_cluster.control_plane_size` takes. Pass the `flavors` of a " +
+			"`prodata_kubernetes_flavors` data source. Returns null when the flavor's HA mode is not a clean " +
+			"3-tier ladder in the catalog, and fails when the flavor is not in it.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "flavors",
+				MarkdownDescription: "Master flavor catalog, e.g. `data.prodata_kubernetes_flavors.all.flavors`.",
+				ElementType:         types.ObjectType{AttrTypes: flavorAttrTypes()},
+			},
+			function.Int64Parameter{
+				Name:                "flavor_id",
+				MarkdownDescription: "Master flavor ID, as in `master_flavor_id`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ControlPlaneSizeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var flavors []FlavorModel
+	var flavorID int64
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &flavors, &flavorID))
+	if resp.Error != nil {
+		return
+	}
+
+	configs := make([]client.MasterNodeConfig, 0, len(flavors))
+	found := false
+	for _, fl := range flavors {
+		configs = append(configs, client.MasterNodeConfig{
+			ID:   fl.ID,
+			CPU:  int(fl.VCPU),
+			RAM:  int(fl.RAM),
+			SSD:  int(fl.DiskSize),
+			IsHA: fl.HighAvailability,
+		})
+		found = found || fl.ID == flavorID
+	}
+	if !found {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("flavor %d is not in the flavors catalog", flavorID))
+		return
+	}
+
+	size := types.StringNull()
+	if s, ok := client.SizeClassByID(configs)[flavorID]; ok {
+		size = types.StringValue(s)
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, size))
+}
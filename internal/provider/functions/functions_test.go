@@ -0,0 +1,164 @@
+package functions
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// run calls f the way the framework does: with the given arguments and a
+// result seeded with an unknown of the definition's return type.
+func run(t *testing.T, f function.Function, args ...attr.Value) function.RunResponse {
+	t.Helper()
+	ctx := context.Background()
+	var def function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &def)
+	if def.Diagnostics.HasError() {
+		t.Fatalf("definition: %v", def.Diagnostics)
+	}
+	result, err := def.Definition.Return.NewResultData(ctx)
+	if err != nil {
+		t.Fatalf("result data: %v", err)
+	}
+	out := function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, &out)
+	return out
+}
+
+const testKubeconfig = `apiVersion: v1
+current-context: admin@k8s
+clusters:
+- name: k8s
+  cluster:
+    server: https://10.0.0.5:6443
+    certificate-authority-data: Q0E=
+users:
+- name: admin
+  user:
+    client-certificate-data: Q0VSVA==
+    client-key-data: S0VZ
+contexts:
+- name: admin@k8s
+  context:
+    cluster: k8s
+    user: admin
+`
+
+func TestParseKubeconfigFunction(t *testing.T) {
+	secret := base64.StdEncoding.EncodeToString([]byte(testKubeconfig))
+	resp := run(t, NewParseKubeconfigFunction(), types.StringValue(secret))
+	if resp.Error != nil {
+		t.Fatal(resp.Error)
+	}
+	obj, ok := resp.Result.Value().(types.Object)
+	if !ok {
+		t.Fatalf("result is %T, want an object", resp.Result.Value())
+	}
+	attrs := obj.Attributes()
+	want := map[string]string{
+		"host":                   "https://10.0.0.5:6443",
+		"cluster_ca_certificate": "Q0E=",
+		"client_certificate":     "Q0VSVA==",
+		"client_key":             "S0VZ",
+		"raw_config":             testKubeconfig,
+	}
+	for name, v := range want {
+		if got := attrs[name].(types.String).ValueString(); got != v {
+			t.Errorf("%s = %q, want %q", name, got, v)
+		}
+	}
+	if !attrs["token"].IsNull() {
+		t.Errorf("token = %v, want null", attrs["token"])
+	}
+
+	if resp := run(t, NewParseKubeconfigFunction(), types.StringValue("  ")); resp.Error == nil {
+		t.Error("an empty kubeconfig should fail")
+	}
+}
+
+func TestNodeIPRangeFunction(t *testing.T) {
+	resp := run(t, NewNodeIPRangeFunction(), types.StringValue("10.0.0.10-10.0.1.9"))
+	if resp.Error != nil {
+		t.Fatal(resp.Error)
+	}
+	attrs := resp.Result.Value().(types.Object).Attributes()
+	if got := attrs["start"].(types.String).ValueString(); got != "10.0.0.10" {
+		t.Errorf("start = %q", got)
+	}
+	if got := attrs["end"].(types.String).ValueString(); got != "10.0.1.9" {
+		t.Errorf("end = %q", got)
+	}
+	if got := attrs["size"].(types.Int64).ValueInt64(); got != 256 {
+		t.Errorf("size = %d, want 256", got)
+	}
+
+	for _, bad := range []string{
+		"10.0.0.10",              // not a range
+		"10.0.0.10 - 10.0.0.20",  // spaces
+		"10.0.0.300-10.0.0.310",  // octet out of range
+		"10.0.0.010-10.0.0.20",   // leading zero
+		"10.0.0.20-10.0.0.10",    // reversed
+		"fd00::1-fd00::2",        // IPv6
+		"10.0.0.10-10.0.0.20/24", // trailing prefix
+	} {
+		if resp := run(t, NewNodeIPRangeFunction(), types.StringValue(bad)); resp.Error == nil {
+			t.Errorf("%q: want an error", bad)
+		}
+	}
+}
+
+func TestControlPlaneSizeFunction(t *testing.T) {
+	flavor := func(id, cpu, ram int64, ha bool) attr.Value {
+		return types.ObjectValueMust(flavorAttrTypes(), map[string]attr.Value{
+			"id":                types.Int64Value(id),
+			"vcpu":              types.Int64Value(cpu),
+			"ram":               types.Int64Value(ram),
+			"disk_size":         types.Int64Value(50),
+			"high_availability": types.BoolValue(ha),
+		})
+	}
+	flavors := types.ListValueMust(types.ObjectType{AttrTypes: flavorAttrTypes()}, []attr.Value{
+		flavor(13, 8, 16, false),
+		flavor(11, 2, 4, false),
+		flavor(12, 4, 8, false),
+		flavor(21, 2, 4, true), // a lone HA flavor is no ladder
+	})
+
+	cases := []struct {
+		id      int64
+		want    string // "" means null
+		wantErr string
+	}{
+		{id: 11, want: "small"},
+		{id: 12, want: "medium"},
+		{id: 13, want: "large"},
+		{id: 21},
+		{id: 99, wantErr: "not in the flavors catalog"},
+	}
+	for _, tc := range cases {
+		resp := run(t, NewControlPlaneSizeFunction(), flavors, types.Int64Value(tc.id))
+		if tc.wantErr != "" {
+			if resp.Error == nil || !strings.Contains(resp.Error.Error(), tc.wantErr) {
+				t.Errorf("flavor %d: error = %v, want %q", tc.id, resp.Error, tc.wantErr)
+			}
+			continue
+		}
+		if resp.Error != nil {
+			t.Errorf("flavor %d: %v", tc.id, resp.Error)
+			continue
+		}
+		got := resp.Result.Value().(types.String)
+		if tc.want == "" {
+			if !got.IsNull() {
+				t.Errorf("flavor %d: size = %v, want null", tc.id, got)
+			}
+		} else if got.ValueString() != tc.want {
+			t.Errorf("flavor %d: size = %q, want %q", tc.id, got.ValueString(), tc.want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package functions
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &NodeIPRangeFunction{}
+
+// nodeIPRangePattern is the shape prodata_kubernetes_cluster's node_ip_range
+// validator accepts; the function additionally checks that both ends are real
+// IPv4 addresses in ascending order.
+var nodeIPRangePattern = regexp.MustCompile(`^(\d{1,3}\.){3}\d{1,3}-(\d{1,3}\.){3}\d{1,3}$`)
+
+type NodeIPRangeFunction struct{}
+
+type NodeIPRangeModel struct {
+	Start types.String `tfsdk:"start"`
+	End   types.String `tfsdk:"end"`
+	Size  types.Int64  `tfsdk:"size"`
+}
+
+func nodeIPRangeAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"start": types.StringType,
+		"end":   types.StringType,
+		"size":  types.Int64Type,
+	}
+}
+
+func NewNodeIPRangeFunction() function.Function {
+	return &NodeIPRangeFunction{}
+}
+
+func (f *NodeIPRangeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "node_ip_range"
+}
+
+func (f *NodeIPRangeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate and split a Kubernetes node IP range",
+		MarkdownDescription: "Validates a `start-end` IPv4 range as `prodata_kubernetes_cluster.node_ip_range` " +
+			"expects it (e.g. `10.0.0.10-10.0.0.20`) and splits it into its first and last address and the " +
+			"number of addresses it spans, inclusive. Fails when the value is malformed or `start` is after `end`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "range",
+				MarkdownDescription: "IPv4 range as `start-end`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: nodeIPRangeAttrTypes(),
+		},
+	}
+}
+
+func (f *NodeIPRangeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	start, end, err := splitNodeIPRange(value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, NodeIPRangeModel{
+		Start: types.StringValue(start.String()),
+		End:   types.StringValue(end.String()),
+		Size:  types.Int64Value(int64(ipv4Uint(end)-ipv4Uint(start)) + 1),
+	}))
+}
+
+// splitNodeIPRange parses a `start-end` IPv4 range, rejecting octets above 255,
+// leading zeros and a start after the end.
+func splitNodeIPRange(s string) (netip.Addr, netip.Addr, error) {
+	if !nodeIPRangePattern.MatchString(s) {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("%q must be an IPv4 range as start-end, e.g. 10.0.0.10-10.0.0.20", s)
+	}
+	lo, hi, _ := strings.Cut(s, "-")
+	start, err := netip.ParseAddr(lo)
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid start address %q: %s", lo, err)
+	}
+	end, err := netip.ParseAddr(hi)
+	if err != nil {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("invalid end address %q: %s", hi, err)
+	}
+	if end.Less(start) {
+		return netip.Addr{}, netip.Addr{}, fmt.Errorf("start address %s is after end address %s", start, end)
+	}
+	return start, end, nil
+}
+
+func ipv4Uint(a netip.Addr) uint32 {
+	b := a.As4()
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
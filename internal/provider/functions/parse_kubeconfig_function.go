@@ -0,0 +1,88 @@
+package functions
+
+import (
+	"context"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/tfutil"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ParseKubeconfigFunction{}
+
+type ParseKubeconfigFunction struct{}
+
+// KubeconfigModel is the object parse_kubeconfig returns. It has the same
+// attributes as prodata_kubernetes_cluster's kube_config, so module authors can
+// switch between the two without changing how the fields are consumed.
+type KubeconfigModel struct {
+	Host                 types.String `tfsdk:"host"`
+	ClusterCACertificate types.String `tfsdk:"cluster_ca_certificate"`
+	ClientCertificate    types.String `tfsdk:"client_certificate"`
+	ClientKey            types.String `tfsdk:"client_key"`
+	Token                types.String `tfsdk:"token"`
+	RawConfig            types.String `tfsdk:"raw_config"`
+}
+
+func kubeconfigAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"host":                   types.StringType,
+		"cluster_ca_certificate": types.StringType,
+		"client_certificate":     types.StringType,
+		"client_key":             types.StringType,
+		"token":                  types.StringType,
+		"raw_config":             types.StringType,
+	}
+}
+
+func NewParseKubeconfigFunction() function.Function {
+	return &ParseKubeconfigFunction{}
+}
+
+func (f *ParseKubeconfigFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_kubeconfig"
+}
+
+func (f *ParseKubeconfigFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a kubeconfig into its connection fields",
+		MarkdownDescription: "Parses a kubeconfig, base64-encoded as the panel returns it or as plain YAML, into the " +
+			"connection fields of its current context (or of its first cluster and user when it has none). " +
+			"The certificate fields stay base64 as they appear in the kubeconfig: wrap them in `base64decode()` " +
+			"when configuring the `kubernetes` or `helm` provider. Fields the kubeconfig does not carry are null.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "kubeconfig",
+				MarkdownDescription: "Kubeconfig, base64-encoded or plain YAML.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: kubeconfigAttrTypes(),
+		},
+	}
+}
+
+func (f *ParseKubeconfigFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var secret string
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &secret))
+	if resp.Error != nil {
+		return
+	}
+
+	kc := client.ParseKubeConfig(secret)
+	if kc == nil {
+		resp.Error = function.NewArgumentFuncError(0, "kubeconfig is empty")
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, KubeconfigModel{
+		Host:                 tfutil.StringOrNull(kc.Host),
+		ClusterCACertificate: tfutil.StringOrNull(kc.ClusterCACertificate),
+		ClientCertificate:    tfutil.StringOrNull(kc.ClientCertificate),
+		ClientKey:            tfutil.StringOrNull(kc.ClientKey),
+		Token:                tfutil.StringOrNull(kc.Token),
+		RawConfig:            tfutil.StringOrNull(kc.Raw),
+	}))
+}
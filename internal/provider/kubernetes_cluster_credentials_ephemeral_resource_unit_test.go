@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestUnitK8sClusterCredentials_ephemeral opens the ephemeral credentials of a
+// fake cluster and passes them through the echo provider, which is the only way
+// a test can observe an ephemeral value.
+func TestUnitK8sClusterCredentials_ephemeral(t *testing.T) {
+	s := testFakePanel(t)
+	cluster := testFakeProviderConfig(s) + testAccK8sClusterConfig("k8s")
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"prodata": testAccProtoV6ProviderFactories["prodata"],
+			"echo":    echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: cluster,
+			},
+			{ // Before the success step: the post-test destroy reuses the last config.
+				Config: cluster + `
+ephemeral "prodata_kubernetes_cluster_credentials" "missing" {
+  id = 999999
+}
+
+provider "echo" {
+  data = ephemeral.prodata_kubernetes_cluster_credentials.missing
+}
+
+resource "echo" "creds" {}
+`,
+				ExpectError: regexp.MustCompile(`Unable to read Kubernetes cluster`),
+			},
+			{
+				Config: cluster + `
+ephemeral "prodata_kubernetes_cluster_credentials" "test" {
+  id = prodata_kubernetes_cluster.test.id
+}
+
+provider "echo" {
+  data = ephemeral.prodata_kubernetes_cluster_credentials.test
+}
+
+resource "echo" "creds" {}
+`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.creds", tfjsonpath.New("data").AtMapKey("host"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.creds", tfjsonpath.New("data").AtMapKey("client_key"), knownvalue.NotNull()),
+				},
+			},
+		},
+	})
+}
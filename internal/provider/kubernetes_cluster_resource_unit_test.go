@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"terraform-provider-prodata/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// TestUnitK8sCluster_lifecycle runs the prodata_kubernetes_cluster create/import/
+// destroy cycle against the fake panel, through the versions and flavors data
+// sources. The fake's cluster is SUCCESS with a kubeconfig on its first poll, so
+// the 30s poll loops never sleep.
+func TestUnitK8sCluster_lifecycle(t *testing.T) {
+	s := testFakePanel(t)
+	resourceName := "prodata_kubernetes_cluster.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(s) + testAccK8sClusterConfig("k8s"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("status"), knownvalue.StringExact("SUCCESS")),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("kubernetes_version"), knownvalue.StringExact("v1.31.4")),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("kube_config").AtMapKey("host"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("default_node_pool").AtMapKey("id"), knownvalue.NotNull()),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: false, // write-once create-time inputs are not read back.
+			},
+		},
+	})
+}
+
+// TestUnitK8sCluster_nodeIPRangeNeedsPanelSupport: omitting node_ip_range on a
+// panel that reports it cannot allocate one fails the plan, before anything is
+// created; setting it explicitly still works there.
+func TestUnitK8sCluster_nodeIPRangeNeedsPanelSupport(t *testing.T) {
+	s := testFakePanel(t)
+	s.SetCapabilities(client.FeatureCCMLoadBalancer, client.FeatureObjectLock)
+	config := testAccK8sClusterConfig("k8s")
+	omitted := strings.Replace(config, `node_ip_range      = "10.30.0.10-10.30.0.20"`, "", 1)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testFakeProviderConfig(s) + omitted,
+				ExpectError: regexp.MustCompile(`(?s)Feature not supported by this panel.*kubernetes\.node_ip_range_allocation`),
+			},
+			{
+				Config: testFakeProviderConfig(s) + config,
+			},
+		},
+	})
+	if n := s.RequestCount("POST", "/api/kubernetes/createCluster"); n != 1 {
+		t.Errorf("panel saw %d cluster creates, want 1 (none for the refused plan)", n)
+	}
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-prodata/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// TestUnitLb_lifecycle runs the prodata_lb create/update/import/destroy cycle
+// against the fake panel, with a VM backend referenced by guid.
+func TestUnitLb_lifecycle(t *testing.T) {
+	s := testFakePanel(t)
+	resourceName := "prodata_lb.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(s) + testUnitLbConfig("first"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("status"), knownvalue.StringExact(client.LbStatusSuccess)),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("source"), knownvalue.StringExact(client.LbSourceFrontend)),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("private_ip"), knownvalue.NotNull()),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{ // Update the description in place.
+				Config: testFakeProviderConfig(s) + testUnitLbConfig("second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply:             []plancheck.PlanCheck{plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate)},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("description"), knownvalue.StringExact("second")),
+				},
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+		},
+	})
+}
+
+func testUnitLbConfig(description string) string {
+	return fmt.Sprintf(`
+resource "prodata_local_network" "test" {
+  name    = "lb-net"
+  cidr    = "10.50.0.0/24"
+  gateway = "10.50.0.1"
+}
+
+resource "prodata_vm" "backend" {
+  name             = "lb-backend"
+  image_id         = 1
+  cpu_cores        = 1
+  ram              = 2
+  disk_size        = 20
+  disk_type        = "SSD"
+  local_network_id = prodata_local_network.test.id
+  password         = "FakePanel123"
+}
+
+resource "prodata_lb" "test" {
+  name        = "test-lb"
+  description = %[1]q
+  type        = "internal"
+  protocol    = "TCP"
+  network_id  = prodata_local_network.test.id
+
+  port = [
+    { port = 80, target_port = 8080 },
+  ]
+
+  backend_group = {
+    vm_ids = [prodata_vm.backend.guid]
+  }
+}
+`, description)
+}
+
+// TestUnitLb_nodePoolBackendNeedsPanelSupport: a node pool (CCM) backend on a
+// panel that does not report CCM load balancers fails the plan.
+func TestUnitLb_nodePoolBackendNeedsPanelSupport(t *testing.T) {
+	s := testFakePanel(t)
+	s.SetCapabilities()
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(s) + `
+resource "prodata_lb" "test" {
+  name       = "ccm-lb"
+  type       = "internal"
+  protocol   = "TCP"
+  network_id = 1
+
+  port = [
+    { port = 80, target_port = 30080 },
+  ]
+
+  backend_group = {
+    node_pool_id = 1
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`(?s)Feature not supported by this panel.*loadbalancer\.ccm`),
+			},
+		},
+	})
+}
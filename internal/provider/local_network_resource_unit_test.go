@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// TestUnitLocalNetwork_lifecycle runs the prodata_local_network
+// create/rename/import/destroy cycle against the fake panel.
+func TestUnitLocalNetwork_lifecycle(t *testing.T) {
+	s := testFakePanel(t)
+	resourceName := "prodata_local_network.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(s) + testAccLocalNetworkConfig("net", "10.10.0.0/24", "10.10.0.1"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("cidr"), knownvalue.StringExact("10.10.0.0/24")),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("gateway"), knownvalue.StringExact("10.10.0.1")),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{ // Rename in place.
+				Config: testFakeProviderConfig(s) + testAccLocalNetworkConfig("net-renamed", "10.10.0.0/24", "10.10.0.1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply:             []plancheck.PlanCheck{plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate)},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -2,22 +2,47 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/provider/actions"
 	"terraform-provider-prodata/internal/provider/datasources"
+	"terraform-provider-prodata/internal/provider/ephemeralresources"
+	"terraform-provider-prodata/internal/provider/functions"
 	"terraform-provider-prodata/internal/provider/resources"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-var _ provider.Provider = &ProDataProvider{}
+var (
+	_ provider.Provider                       = &ProDataProvider{}
+	_ provider.ProviderWithFunctions          = &ProDataProvider{}
+	_ provider.ProviderWithEphemeralResources = &ProDataProvider{}
+	_ provider.ProviderWithActions            = &ProDataProvider{}
+	_ provider.ProviderWithListResources      = &ProDataProvider{}
+)
+
+// capabilitiesProbeTimeout bounds the capabilities probe in Configure, so an
+// unresponsive panel delays the run by at most this much before the features
+// are treated as unknown.
+const capabilitiesProbeTimeout = 15 * time.Second
 
 type ProDataProvider struct {
 	version string
@@ -29,6 +54,23 @@ type ProDataProviderModel struct {
 	APISecretKey types.String `tfsdk:"api_secret_key"`
 	Region       types.String `tfsdk:"region"`
 	ProjectTag   types.String `tfsdk:"project_tag"`
+	Profile      types.String `tfsdk:"profile"`
+
+	CredentialProcess types.String `tfsdk:"credential_process"`
+
+	MaxRequestsPerSecond types.Float64 `tfsdk:"max_requests_per_second"`
+	RequestBurst         types.Int64   `tfsdk:"request_burst"`
+	MaxConcurrentWrites  types.Int64   `tfsdk:"max_concurrent_writes"`
+	MaxConcurrentReads   types.Int64   `tfsdk:"max_concurrent_reads"`
+
+	CABundle          types.String `tfsdk:"ca_bundle"`
+	ClientCertificate types.String `tfsdk:"client_certificate"`
+	ClientKey         types.String `tfsdk:"client_key"`
+	ProxyURL          types.String `tfsdk:"proxy_url"`
+	TLSMinVersion     types.String `tfsdk:"tls_min_version"`
+
+	Retry           *RetryModel           `tfsdk:"retry"`
+	DefaultTimeouts *DefaultTimeoutsModel `tfsdk:"default_timeouts"`
 }
 
 func New(version string) func() provider.Provider {
@@ -72,6 +114,156 @@ func (p *ProDataProvider) Schema(ctx context.Context, req provider.SchemaRequest
 					"Can also be set via `PRODATA_PROJECT_TAG` environment variable.",
 				Optional: true,
 			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Named profile to read from the shared credentials file " +
+					"(`~/.prodata/credentials`, or `PRODATA_CREDENTIALS_FILE`). A profile named here " +
+					"outranks `PRODATA_*` environment variables; explicit attributes outrank both. " +
+					"Can also be set via `PRODATA_PROFILE` environment variable (then ranked below the " +
+					"other environment variables). Defaults to `default` when that profile exists.",
+				Optional: true,
+			},
+			"credential_process": schema.StringAttribute{
+				MarkdownDescription: "External command that prints the API key pair as JSON " +
+					"(`{\"api_key_id\": \"...\", \"api_secret_key\": \"...\", \"expiration\": \"<RFC 3339>\"}`), " +
+					"run through the system shell. Used when `api_key_id` or `api_secret_key` is not otherwise " +
+					"set, and re-run shortly before the reported `expiration`. The secret never enters " +
+					"configuration, state or the environment. Can also be set via " +
+					"`PRODATA_CREDENTIAL_PROCESS` environment variable or a credentials profile.",
+				Optional: true,
+			},
+			"max_requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Ceiling on outbound API requests per second, to pre-empt rate " +
+					"limiting on large applies. Unset or `0` means no ceiling. With or without it, the " +
+					"provider halves its request rate when the API answers HTTP 429 (pausing for any " +
+					"`Retry-After`) and recovers gradually afterward. Can also be set via " +
+					"`PRODATA_MAX_RPS` environment variable.",
+				Optional:   true,
+				Validators: []validator.Float64{float64validator.AtLeast(0)},
+			},
+			"request_burst": schema.Int64Attribute{
+				MarkdownDescription: "Number of requests that may be sent back to back before pacing at " +
+					"the current rate applies. Defaults to `1` (strict pacing). Can also be set via " +
+					"`PRODATA_REQUEST_BURST` environment variable.",
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"max_concurrent_writes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of mutating API requests (create, update, delete) in " +
+					"flight at once, across all resources. Long synchronous creates (VM, load balancer, " +
+					"Kubernetes) hold their slot until the panel answers. Unset means no cap. Can also be " +
+					"set via `PRODATA_MAX_CONCURRENT_WRITES` environment variable.",
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"max_concurrent_reads": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of read (GET) API requests in flight at once, " +
+					"independent of `max_concurrent_writes`. Unset means no cap. Can also be set via " +
+					"`PRODATA_MAX_CONCURRENT_READS` environment variable.",
+				Optional:   true,
+				Validators: []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"ca_bundle": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificates to trust in addition to the system roots, " +
+					"for a panel whose certificate is issued by a private CA. Either the PEM data itself or " +
+					"the path to a PEM file. Can also be set via `PRODATA_CA_BUNDLE` environment variable " +
+					"or a credentials profile.",
+				Optional: true,
+			},
+			"client_certificate": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded client certificate (chain) presented to the panel for " +
+					"mutual TLS, as PEM data or a file path. Requires `client_key`. Can also be set via " +
+					"`PRODATA_CLIENT_CERTIFICATE` environment variable or a credentials profile.",
+				Optional: true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded private key for `client_certificate`, as PEM data or a " +
+					"file path. Can also be set via `PRODATA_CLIENT_KEY` environment variable or a " +
+					"credentials profile.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "HTTP(S) proxy for all panel requests, e.g. " +
+					"`http://proxy.example:3128`. When unset, the standard `HTTPS_PROXY` and `NO_PROXY` " +
+					"environment variables apply. Can also be set via `PRODATA_PROXY_URL` environment " +
+					"variable or a credentials profile.",
+				Optional: true,
+			},
+			"tls_min_version": schema.StringAttribute{
+				MarkdownDescription: "Lowest TLS version accepted from the panel: `1.2` (default) or " +
+					"`1.3`. Can also be set via `PRODATA_TLS_MIN_VERSION` environment variable or a " +
+					"credentials profile.",
+				Optional:   true,
+				Validators: []validator.String{stringvalidator.OneOf("1.2", "1.3")},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"retry": schema.SingleNestedBlock{
+				MarkdownDescription: "Retry tuning, e.g. more patience on a shared CI runner and less on a " +
+					"laptop. Every attribute is optional and keeps its built-in default when unset. Each can " +
+					"also be set via a `PRODATA_RETRY_*` environment variable named after it.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						MarkdownDescription: "Attempts per API request, the first included, when it is rate " +
+							"limited (HTTP 429) or hits a transient network error. Defaults to `11` for rate " +
+							"limiting and `4` for network errors. Can also be set via " +
+							"`PRODATA_RETRY_MAX_ATTEMPTS` environment variable.",
+						Optional:   true,
+						Validators: []validator.Int64{int64validator.AtLeast(1)},
+					},
+					"base_backoff": schema.StringAttribute{
+						MarkdownDescription: "First wait between those attempts, doubling each attempt, e.g. " +
+							"`1s`. Defaults to `2s` for rate limiting and `500ms` for network errors. A " +
+							"`Retry-After` header still wins. Can also be set via " +
+							"`PRODATA_RETRY_BASE_BACKOFF` environment variable.",
+						Optional: true,
+					},
+					"max_backoff": schema.StringAttribute{
+						MarkdownDescription: "Longest single wait between attempts, e.g. `30s`. Defaults to " +
+							"`60s`. Can also be set via `PRODATA_RETRY_MAX_BACKOFF` environment variable.",
+						Optional: true,
+					},
+					"busy_timeout": schema.StringAttribute{
+						MarkdownDescription: "How long a create keeps retrying while the panel answers HTTP 503 " +
+							"(no compute capacity, no IP pool), e.g. `10m`. Defaults to `2m` or `5m` depending " +
+							"on the resource. Can also be set via `PRODATA_RETRY_BUSY_TIMEOUT` environment " +
+							"variable.",
+						Optional: true,
+					},
+					"poll_error_tolerance": schema.Int64Attribute{
+						MarkdownDescription: "Consecutive errors a status wait (VM, load balancer, Kubernetes) " +
+							"tolerates before failing. Defaults to `1`–`3` depending on the resource. Can also " +
+							"be set via `PRODATA_RETRY_POLL_ERROR_TOLERANCE` environment variable.",
+						Optional:   true,
+						Validators: []validator.Int64{int64validator.AtLeast(1)},
+					},
+					"circuit_breaker_threshold": schema.Int64Attribute{
+						MarkdownDescription: "Consecutive failed requests (network errors, or server errors " +
+							"without a panel response) after which the provider stops contacting the panel and " +
+							"fails requests fast with a \"panel unavailable\" error. Defaults to `10`. Can also " +
+							"be set via `PRODATA_RETRY_CIRCUIT_BREAKER_THRESHOLD` environment variable.",
+						Optional:   true,
+						Validators: []validator.Int64{int64validator.AtLeast(1)},
+					},
+					"circuit_breaker_cooldown": schema.StringAttribute{
+						MarkdownDescription: "How long requests fail fast before a single probe request " +
+							"tests whether the panel has recovered, e.g. `1m`. Defaults to `30s`. Can also be " +
+							"set via `PRODATA_RETRY_CIRCUIT_BREAKER_COOLDOWN` environment variable.",
+						Optional: true,
+					},
+				},
+			},
+			"default_timeouts": schema.SingleNestedBlock{
+				MarkdownDescription: "Operation timeouts for every resource, e.g. `create = \"45m\"`. A " +
+					"resource's own `timeouts` block outranks these; resources without one are bounded by " +
+					"them. Unset operations keep each resource's built-in default.",
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{MarkdownDescription: "Default create timeout.", Optional: true},
+					"read":   schema.StringAttribute{MarkdownDescription: "Default read timeout.", Optional: true},
+					"update": schema.StringAttribute{MarkdownDescription: "Default update timeout.", Optional: true},
+					"delete": schema.StringAttribute{MarkdownDescription: "Default delete timeout.", Optional: true},
+				},
+			},
 		},
 	}
 }
@@ -84,51 +276,51 @@ func (p *ProDataProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
-	// Build config: explicit config takes precedence, env vars as fallback.
-	cfg := client.Config{}
-
-	if !data.APIBaseURL.IsNull() && !data.APIBaseURL.IsUnknown() {
-		cfg.APIBaseURL = data.APIBaseURL.ValueString()
-	} else {
-		cfg.APIBaseURL = os.Getenv("PRODATA_API_BASE_URL")
+	// Pick the profile: the provider block, else PRODATA_PROFILE, else "default".
+	profileName, profileFromConfig := stringValue(data.Profile), true
+	if profileName == "" {
+		profileName, profileFromConfig = os.Getenv("PRODATA_PROFILE"), false
 	}
-
-	if !data.APIKeyID.IsNull() && !data.APIKeyID.IsUnknown() {
-		cfg.APIKeyID = data.APIKeyID.ValueString()
-	} else {
-		cfg.APIKeyID = os.Getenv("PRODATA_API_KEY_ID")
+	explicitProfile := profileName != ""
+	if !explicitProfile {
+		profileName = defaultProfile
 	}
-
-	if !data.APISecretKey.IsNull() && !data.APISecretKey.IsUnknown() {
-		cfg.APISecretKey = data.APISecretKey.ValueString()
-	} else {
-		cfg.APISecretKey = os.Getenv("PRODATA_API_SECRET_KEY")
+	profile, err := loadProfile(profileName, explicitProfile)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("profile"), "Invalid credentials profile", err.Error())
+		return
 	}
 
-	if !data.Region.IsNull() && !data.Region.IsUnknown() {
-		cfg.Region = data.Region.ValueString()
-	} else {
-		cfg.Region = os.Getenv("PRODATA_REGION")
+	// Build config: explicit config takes precedence, then a profile named in config,
+	// then env vars, then a profile from PRODATA_PROFILE or the default profile.
+	cfg := client.Config{
+		APIBaseURL:   resolveSetting(stringValue(data.APIBaseURL), "PRODATA_API_BASE_URL", profile.APIBaseURL, profileFromConfig),
+		APIKeyID:     resolveSetting(stringValue(data.APIKeyID), "PRODATA_API_KEY_ID", profile.APIKeyID, profileFromConfig),
+		APISecretKey: resolveSetting(stringValue(data.APISecretKey), "PRODATA_API_SECRET_KEY", profile.APISecretKey, profileFromConfig),
+		Region:       resolveSetting(stringValue(data.Region), "PRODATA_REGION", profile.Region, profileFromConfig),
+		ProjectTag:   resolveSetting(stringValue(data.ProjectTag), "PRODATA_PROJECT_TAG", profile.ProjectTag, profileFromConfig),
 	}
 
-	if !data.ProjectTag.IsNull() && !data.ProjectTag.IsUnknown() {
-		cfg.ProjectTag = data.ProjectTag.ValueString()
-	} else if env := os.Getenv("PRODATA_PROJECT_TAG"); env != "" {
-		cfg.ProjectTag = env
+	// A credential_process supplies both halves of the key pair whenever either is
+	// missing; statically configured keys win when both are present.
+	credentialProcess := resolveSetting(stringValue(data.CredentialProcess), "PRODATA_CREDENTIAL_PROCESS", profile.CredentialProcess, profileFromConfig)
+	if credentialProcess != "" && (cfg.APIKeyID == "" || cfg.APISecretKey == "") {
+		cfg.APIKeyID, cfg.APISecretKey = "", ""
+		cfg.CredentialSource = client.CredentialProcess(credentialProcess)
 	}
 
 	// Validate required fields.
 	if cfg.APIBaseURL == "" {
 		resp.Diagnostics.AddAttributeError(path.Root("api_base_url"), "Missing API Base URL",
-			"Set api_base_url in config or PRODATA_API_BASE_URL environment variable.")
+			"Set api_base_url in config, the PRODATA_API_BASE_URL environment variable, or a credentials profile.")
 	}
-	if cfg.APIKeyID == "" {
+	if cfg.APIKeyID == "" && cfg.CredentialSource == nil {
 		resp.Diagnostics.AddAttributeError(path.Root("api_key_id"), "Missing API Key ID",
-			"Set api_key_id in config or PRODATA_API_KEY_ID environment variable.")
+			"Set api_key_id in config, the PRODATA_API_KEY_ID environment variable, a credentials profile, or credential_process.")
 	}
-	if cfg.APISecretKey == "" {
+	if cfg.APISecretKey == "" && cfg.CredentialSource == nil {
 		resp.Diagnostics.AddAttributeError(path.Root("api_secret_key"), "Missing API Secret Key",
-			"Set api_secret_key in config or PRODATA_API_SECRET_KEY environment variable.")
+			"Set api_secret_key in config, the PRODATA_API_SECRET_KEY environment variable, a credentials profile, or credential_process.")
 	}
 	if resp.Diagnostics.HasError() {
 		return
@@ -136,23 +328,132 @@ func (p *ProDataProvider) Configure(ctx context.Context, req provider.ConfigureR
 
 	cfg.UserAgent = "terraform-provider-prodata/" + p.version
 
-	// Optional client-side request pacing. PRODATA_MAX_RPS > 0 caps outbound requests
-	// per second to pre-empt server-side 429s on bulk applies; unset/0/invalid leaves it
-	// off (the reactive 429 backoff still applies). Env-only knob — no schema surface.
-	if v := os.Getenv("PRODATA_MAX_RPS"); v != "" {
+	// Client-side request pacing: a ceiling (0 = none) and a burst, from the attributes,
+	// else PRODATA_MAX_RPS / PRODATA_REQUEST_BURST (an invalid env value is ignored).
+	// The limiter adapts to 429s regardless.
+	if !data.MaxRequestsPerSecond.IsNull() && !data.MaxRequestsPerSecond.IsUnknown() {
+		cfg.MaxRPS = data.MaxRequestsPerSecond.ValueFloat64()
+	} else if v := os.Getenv("PRODATA_MAX_RPS"); v != "" {
 		if rps, perr := strconv.ParseFloat(v, 64); perr == nil && rps > 0 {
 			cfg.MaxRPS = rps
 		}
 	}
+	cfg.RequestBurst = intSetting(data.RequestBurst, "PRODATA_REQUEST_BURST")
+
+	// In-flight request caps, separately for mutating requests and reads: the
+	// attributes, else PRODATA_MAX_CONCURRENT_WRITES / PRODATA_MAX_CONCURRENT_READS
+	// (an invalid env value is ignored). Unset means no cap.
+	cfg.MaxConcurrentWrites = intSetting(data.MaxConcurrentWrites, "PRODATA_MAX_CONCURRENT_WRITES")
+	cfg.MaxConcurrentReads = intSetting(data.MaxConcurrentReads, "PRODATA_MAX_CONCURRENT_READS")
+
+	// Connection settings: proxy, private CA, mTLS client certificate and minimum
+	// TLS version. PEM values may be given inline or as a file path.
+	transport := transportSettings{
+		CABundle:          resolveSetting(stringValue(data.CABundle), "PRODATA_CA_BUNDLE", profile.CABundle, profileFromConfig),
+		ClientCertificate: resolveSetting(stringValue(data.ClientCertificate), "PRODATA_CLIENT_CERTIFICATE", profile.ClientCertificate, profileFromConfig),
+		ClientKey:         resolveSetting(stringValue(data.ClientKey), "PRODATA_CLIENT_KEY", profile.ClientKey, profileFromConfig),
+		ProxyURL:          resolveSetting(stringValue(data.ProxyURL), "PRODATA_PROXY_URL", profile.ProxyURL, profileFromConfig),
+		TLSMinVersion:     resolveSetting(stringValue(data.TLSMinVersion), "PRODATA_TLS_MIN_VERSION", profile.TLSMinVersion, profileFromConfig),
+	}
+	tc, attr, err := transport.clientTransport()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root(attr), "Invalid connection setting", fmt.Sprintf("%s: %s", attr, err))
+		return
+	}
+	cfg.Transport = tc
+
+	// Retry tuning: the retry block, else PRODATA_RETRY_* environment variables.
+	var retryModel RetryModel
+	if data.Retry != nil {
+		retryModel = *data.Retry
+	}
+	retry := retrySettings{
+		MaxAttempts:        resolveSetting(int64String(retryModel.MaxAttempts), "PRODATA_RETRY_MAX_ATTEMPTS", "", false),
+		BaseBackoff:        resolveSetting(stringValue(retryModel.BaseBackoff), "PRODATA_RETRY_BASE_BACKOFF", "", false),
+		MaxBackoff:         resolveSetting(stringValue(retryModel.MaxBackoff), "PRODATA_RETRY_MAX_BACKOFF", "", false),
+		BusyTimeout:        resolveSetting(stringValue(retryModel.BusyTimeout), "PRODATA_RETRY_BUSY_TIMEOUT", "", false),
+		PollErrorTolerance: resolveSetting(int64String(retryModel.PollErrorTolerance), "PRODATA_RETRY_POLL_ERROR_TOLERANCE", "", false),
+
+		CircuitBreakerThreshold: resolveSetting(int64String(retryModel.CircuitBreakerThreshold), "PRODATA_RETRY_CIRCUIT_BREAKER_THRESHOLD", "", false),
+		CircuitBreakerCooldown:  resolveSetting(stringValue(retryModel.CircuitBreakerCooldown), "PRODATA_RETRY_CIRCUIT_BREAKER_COOLDOWN", "", false),
+	}
+	if cfg.Retry, attr, err = retry.clientRetry(); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("retry").AtName(attr), "Invalid retry setting", fmt.Sprintf("%s: %s", attr, err))
+		return
+	}
+	if data.DefaultTimeouts != nil {
+		timeouts := timeoutSettings{
+			Create: stringValue(data.DefaultTimeouts.Create),
+			Read:   stringValue(data.DefaultTimeouts.Read),
+			Update: stringValue(data.DefaultTimeouts.Update),
+			Delete: stringValue(data.DefaultTimeouts.Delete),
+		}
+		if cfg.DefaultTimeouts, attr, err = timeouts.clientTimeouts(); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("default_timeouts").AtName(attr), "Invalid default timeout", fmt.Sprintf("%s: %s", attr, err))
+			return
+		}
+	}
+
+	// Optional HTTP record/replay, for turning a run into a regression fixture or a
+	// reproducible bug-report trace. PRODATA_CASSETTE_MODE=record|record_new|replay
+	// selects the mode and PRODATA_CASSETTE the file. Env-only knob — no schema
+	// surface.
+	cfg.CassetteMode = os.Getenv("PRODATA_CASSETTE_MODE")
+	cfg.CassettePath = os.Getenv("PRODATA_CASSETTE")
 
 	c, err := client.New(cfg)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create client", err.Error())
 		return
 	}
+	if err := c.EnsureCredentials(ctx); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("credential_process"), "Failed to obtain API credentials", err.Error())
+		return
+	}
+
+	// Newer features need a matching panel-main deploy. Learn which ones this
+	// panel has, so resources can refuse them at plan time rather than fail
+	// mid-apply. Best effort: on failure the features stay unknown and are
+	// allowed, as before the panel reported them.
+	probeCtx, cancel := context.WithTimeout(ctx, capabilitiesProbeTimeout)
+	err = c.NegotiateCapabilities(probeCtx)
+	cancel()
+	if err != nil {
+		tflog.Warn(ctx, "Could not read the panel's capabilities; newer features will not be checked at plan time", map[string]any{
+			"error": err.Error(),
+		})
+	} else if caps := c.Capabilities(); caps != nil {
+		tflog.Debug(ctx, "Panel capabilities", map[string]any{
+			"version":  caps.Version,
+			"features": caps.Features,
+		})
+	}
 
 	resp.DataSourceData = c
 	resp.ResourceData = c
+	resp.EphemeralResourceData = c
+	resp.ActionData = c
+	resp.ListResourceData = c
+}
+
+// intSetting returns a configured positive integer, else the value of envVar
+// when it is a positive integer, else 0.
+func intSetting(v types.Int64, envVar string) int {
+	if !v.IsNull() && !v.IsUnknown() {
+		return int(v.ValueInt64())
+	}
+	if n, err := strconv.Atoi(os.Getenv(envVar)); err == nil && n > 0 {
+		return n
+	}
+	return 0
+}
+
+// stringValue returns a configured string, or "" when it is null or unknown.
+func stringValue(v types.String) string {
+	if v.IsNull() || v.IsUnknown() {
+		return ""
+	}
+	return v.ValueString()
 }
 
 func (p *ProDataProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -170,6 +471,18 @@ func (p *ProDataProvider) Resources(ctx context.Context) []func() resource.Resou
 	}
 }
 
+func (p *ProDataProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		resources.NewVolumeListResource,
+		resources.NewLocalNetworkListResource,
+		resources.NewPublicIPListResource,
+		resources.NewVmListResource,
+		resources.NewS3BucketListResource,
+		resources.NewLbListResource,
+		resources.NewK8sClusterListResource,
+	}
+}
+
 func (p *ProDataProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		datasources.NewImageDataSource,
@@ -192,3 +505,25 @@ func (p *ProDataProvider) DataSources(ctx context.Context) []func() datasource.D
 		datasources.NewK8sFlavorsDataSource,
 	}
 }
+
+func (p *ProDataProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		ephemeralresources.NewK8sClusterCredentialsEphemeralResource,
+	}
+}
+
+func (p *ProDataProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		functions.NewParseKubeconfigFunction,
+		functions.NewNodeIPRangeFunction,
+		functions.NewControlPlaneSizeFunction,
+	}
+}
+
+func (p *ProDataProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		actions.NewVmStopAction,
+		actions.NewVmStartAction,
+		actions.NewVmRebootAction,
+	}
+}
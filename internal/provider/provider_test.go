@@ -1,8 +1,10 @@
 package provider
 
 import (
+	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"terraform-provider-prodata/internal/client"
@@ -26,11 +28,19 @@ const accNameCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
 
 // accName returns a unique, length-bounded, lowercase name. 18 chars total
 // (prefix 6 + 12 random) — within the S3 bucket 24-char limit and the LB 63-char
-// limit, with no leading/trailing/consecutive separators.
+// limit, with no leading/trailing/consecutive separators. Under a cassette
+// (PRODATA_CASSETTE_MODE) the suffix is a sequence number instead of random.
 func accName() string {
+	if os.Getenv("PRODATA_CASSETTE_MODE") != "" {
+		// A cassette matches requests by body, so names must be the same on every run.
+		return accResourcePrefix + fmt.Sprintf("rec%09d", accNameSeq.Add(1))
+	}
 	return accResourcePrefix + acctest.RandStringFromCharSet(12, accNameCharset)
 }
 
+// accNameSeq numbers the deterministic names used while recording or replaying.
+var accNameSeq atomic.Int64
+
 // testAccProtoV6ProviderFactories wires the in-process provider for acceptance
 // tests under the "prodata" name (the canonical terraform-plugin-framework pattern).
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
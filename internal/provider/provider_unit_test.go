@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"terraform-provider-prodata/internal/fakepanel"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testFakeProviderConfigWith renders a fake-panel provider block with extra
+// provider arguments appended.
+func testFakeProviderConfigWith(s *fakepanel.Server, extra string) string {
+	return fmt.Sprintf(`
+provider "prodata" {
+  api_base_url   = %q
+  api_key_id     = %q
+  api_secret_key = %q
+  region         = %q
+  project_tag    = %q
+%s
+}
+`, s.URL(), fakepanel.APIKeyID, fakepanel.APISecretKey, fakepanel.Region, fakepanel.ProjectTag, extra)
+}
+
+// TestUnitProvider_rateLimit applies through a paced, bursty client and rejects an
+// invalid burst at validate time.
+func TestUnitProvider_rateLimit(t *testing.T) {
+	s := testFakePanel(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testFakeProviderConfigWith(s, "  request_burst = 0") + testAccPublicIPConfig("ip"),
+				ExpectError: regexp.MustCompile(`request_burst`),
+			},
+			{
+				Config: testFakeProviderConfigWith(s, "  max_requests_per_second = 50\n  request_burst = 5") + testAccPublicIPConfig("ip"),
+			},
+		},
+	})
+}
+
+// TestUnitProvider_concurrency applies with both in-flight caps at 1 and rejects
+// a cap of 0 at validate time.
+func TestUnitProvider_concurrency(t *testing.T) {
+	s := testFakePanel(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testFakeProviderConfigWith(s, "  max_concurrent_writes = 0") + testAccPublicIPConfig("ip"),
+				ExpectError: regexp.MustCompile(`max_concurrent_writes`),
+			},
+			{
+				Config: testFakeProviderConfigWith(s, "  max_concurrent_writes = 1\n  max_concurrent_reads = 1") + testAccPublicIPConfig("ip"),
+			},
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package resources
+
+import (
+	"fmt"
+
+	"terraform-provider-prodata/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// requireFeature fails the plan at attr when the panel reports its capabilities
+// and feature is not among them, so a configuration that needs a newer
+// panel-main deploy is refused before anything is created. use describes what
+// the configuration asks for and alternative how to do without the feature.
+//
+// A panel whose capabilities are unknown (it predates the endpoint, the probe
+// failed, or the provider is not configured yet) passes: the request is sent
+// and the panel's own error, if any, surfaces at apply as it always has.
+func requireFeature(c *client.Client, feature string, attr path.Path, use, alternative string, diags *diag.Diagnostics) {
+	if c == nil || c.Supports(feature) != client.SupportNo {
+		return
+	}
+	version := "unknown"
+	if caps := c.Capabilities(); caps != nil && caps.Version != "" {
+		version = caps.Version
+	}
+	diags.AddAttributeError(attr,
+		"Feature not supported by this panel",
+		fmt.Sprintf("%s needs panel-main support for %q, which the panel at this endpoint (version %s) "+
+			"does not report. Applying would fail partway. Upgrade panel-main, or %s.",
+			use, feature, version, alternative),
+	)
+}
@@ -0,0 +1,294 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/fakepanel"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// crudHarness drives a resource's CRUD and import methods directly against the
+// fake panel, the way the framework's server does. Unlike the TestUnit* tests of
+// the provider package it needs no terraform binary, so the lifecycle is covered
+// by a plain offline `go test ./...`. M is the resource's model.
+type crudHarness[M any] struct {
+	t        *testing.T
+	ctx      context.Context
+	r        resource.Resource
+	schema   schema.Schema
+	identity identityschema.Schema
+}
+
+func newCRUDHarness[M any](t *testing.T, c *client.Client, r resource.Resource) *crudHarness[M] {
+	t.Helper()
+	ctx := context.Background()
+	var configureResp resource.ConfigureResponse
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: c}, &configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure: %v", configureResp.Diagnostics)
+	}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	var identityResp resource.IdentitySchemaResponse
+	r.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identityResp)
+	return &crudHarness[M]{t: t, ctx: ctx, r: r, schema: schemaResp.Schema, identity: identityResp.IdentitySchema}
+}
+
+func (h *crudHarness[M]) check(op string, diags diag.Diagnostics) {
+	h.t.Helper()
+	if diags.HasError() {
+		h.t.Fatalf("%s: %v", op, diags)
+	}
+}
+
+func (h *crudHarness[M]) nullState() tfsdk.State {
+	return tfsdk.State{Schema: h.schema, Raw: tftypes.NewValue(h.schema.Type().TerraformType(h.ctx), nil)}
+}
+
+func (h *crudHarness[M]) nullIdentity() *tfsdk.ResourceIdentity {
+	return &tfsdk.ResourceIdentity{Schema: h.identity, Raw: tftypes.NewValue(h.identity.Type().TerraformType(h.ctx), nil)}
+}
+
+// plan renders m as a plan, with the config Terraform would have sent alongside
+// it: the plan with every unknown (computed) value null.
+func (h *crudHarness[M]) plan(m *M) (tfsdk.Plan, tfsdk.Config) {
+	h.t.Helper()
+	plan := tfsdk.Plan{Schema: h.schema, Raw: h.nullState().Raw}
+	h.check("plan", plan.Set(h.ctx, m))
+	raw, err := tftypes.Transform(plan.Raw, func(_ *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		h.t.Fatalf("config: %v", err)
+	}
+	return plan, tfsdk.Config{Schema: h.schema, Raw: raw}
+}
+
+func (h *crudHarness[M]) model(state tfsdk.State) M {
+	h.t.Helper()
+	var m M
+	h.check("get state", state.Get(h.ctx, &m))
+	return m
+}
+
+func (h *crudHarness[M]) create(m *M) (tfsdk.State, *tfsdk.ResourceIdentity) {
+	h.t.Helper()
+	plan, config := h.plan(m)
+	resp := resource.CreateResponse{State: h.nullState(), Identity: h.nullIdentity()}
+	h.r.Create(h.ctx, resource.CreateRequest{Plan: plan, Config: config}, &resp)
+	h.check("create", resp.Diagnostics)
+	return resp.State, resp.Identity
+}
+
+// read refreshes state; removed reports that the resource dropped itself from
+// state, as it does once the object is gone.
+func (h *crudHarness[M]) read(state tfsdk.State, identity *tfsdk.ResourceIdentity) (refreshed tfsdk.State, removed bool) {
+	h.t.Helper()
+	resp := resource.ReadResponse{State: state, Identity: identity}
+	h.r.Read(h.ctx, resource.ReadRequest{State: state, Identity: identity}, &resp)
+	h.check("read", resp.Diagnostics)
+	return resp.State, resp.State.Raw.IsNull()
+}
+
+func (h *crudHarness[M]) update(prior tfsdk.State, identity *tfsdk.ResourceIdentity, m *M) tfsdk.State {
+	h.t.Helper()
+	plan, config := h.plan(m)
+	resp := resource.UpdateResponse{State: tfsdk.State(plan), Identity: identity}
+	h.r.Update(h.ctx, resource.UpdateRequest{Plan: plan, Config: config, State: prior, Identity: identity}, &resp)
+	h.check("update", resp.Diagnostics)
+	return resp.State
+}
+
+// delete destroys the resource, returning the diagnostics so that a refused
+// delete can be asserted.
+func (h *crudHarness[M]) delete(state tfsdk.State, identity *tfsdk.ResourceIdentity) diag.Diagnostics {
+	resp := resource.DeleteResponse{State: state}
+	h.r.Delete(h.ctx, resource.DeleteRequest{State: state, Identity: identity}, &resp)
+	return resp.Diagnostics
+}
+
+// importID imports by id the way `terraform import` does: ImportState, then
+// the Read that fills in the rest of the state.
+func (h *crudHarness[M]) importID(id string) tfsdk.State {
+	h.t.Helper()
+	resp := resource.ImportStateResponse{State: h.nullState(), Identity: h.nullIdentity()}
+	h.r.(resource.ResourceWithImportState).ImportState(h.ctx, resource.ImportStateRequest{ID: id}, &resp)
+	h.check("import", resp.Diagnostics)
+	state, removed := h.read(resp.State, resp.Identity)
+	if removed {
+		h.t.Fatalf("import %s: the imported object was not found", id)
+	}
+	return state
+}
+
+func TestVolumeResource_crud(t *testing.T) {
+	c := testFakePanelClient(t)
+	h := newCRUDHarness[VolumeResourceModel](t, c, NewVolumeResource())
+
+	planned := VolumeResourceModel{
+		ID:                 types.Int64Unknown(),
+		Region:             types.StringUnknown(),
+		ProjectTag:         types.StringUnknown(),
+		Name:               types.StringValue("data"),
+		Type:               types.StringValue("HDD"),
+		Size:               types.Int64Value(10),
+		DeletionProtection: types.BoolValue(false),
+	}
+	state, identity := h.create(&planned)
+	created := h.model(state)
+	if created.ID.ValueInt64() == 0 || created.Region.ValueString() != fakepanel.Region ||
+		created.ProjectTag.ValueString() != fakepanel.ProjectTag {
+		t.Errorf("created = %+v", created)
+	}
+	var id scopedIdentityModel
+	h.check("get identity", identity.Get(h.ctx, &id))
+	if id.ID != created.ID || id.Region.ValueString() != fakepanel.Region {
+		t.Errorf("identity = %+v", id)
+	}
+
+	if state, removed := h.read(state, identity); removed || h.model(state) != created {
+		t.Errorf("read changed a fresh volume: removed %t", removed)
+	}
+
+	renamed := created
+	renamed.Name = types.StringValue("data-renamed")
+	state = h.update(state, identity, &renamed)
+	volume, err := c.GetVolume(h.ctx, created.ID.ValueInt64(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume.Name != "data-renamed" || h.model(state).Name.ValueString() != "data-renamed" {
+		t.Errorf("rename: panel name %q, state %+v", volume.Name, h.model(state))
+	}
+
+	if imported := h.model(h.importID(created.ID.String())); imported != h.model(state) {
+		t.Errorf("imported %+v, want %+v", imported, h.model(state))
+	}
+
+	if diags := h.delete(state, identity); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	// The panel keeps serving a deleted volume by id; Read must still drop it.
+	if _, removed := h.read(state, identity); !removed {
+		t.Error("a deleted volume should be removed from state on read")
+	}
+}
+
+func TestVolumeResource_crudDeletionProtection(t *testing.T) {
+	c := testFakePanelClient(t)
+	h := newCRUDHarness[VolumeResourceModel](t, c, NewVolumeResource())
+
+	planned := VolumeResourceModel{
+		ID:                 types.Int64Unknown(),
+		Region:             types.StringUnknown(),
+		ProjectTag:         types.StringUnknown(),
+		Name:               types.StringValue("keep"),
+		Type:               types.StringValue("SSD"),
+		Size:               types.Int64Value(10),
+		DeletionProtection: types.BoolValue(true),
+	}
+	state, identity := h.create(&planned)
+	if diags := h.delete(state, identity); !diags.HasError() {
+		t.Fatal("deleting a protected volume should fail")
+	}
+	if _, removed := h.read(state, identity); removed {
+		t.Error("a refused delete must leave the volume in place")
+	}
+
+	unprotected := h.model(state)
+	unprotected.DeletionProtection = types.BoolValue(false)
+	state = h.update(state, identity, &unprotected)
+	if diags := h.delete(state, identity); diags.HasError() {
+		t.Fatalf("delete after turning the protection off: %v", diags)
+	}
+}
+
+func TestLocalNetworkResource_crud(t *testing.T) {
+	c := testFakePanelClient(t)
+	h := newCRUDHarness[LocalNetworkResourceModel](t, c, NewLocalNetworkResource())
+
+	planned := LocalNetworkResourceModel{
+		ID:         types.Int64Unknown(),
+		Region:     types.StringUnknown(),
+		ProjectTag: types.StringUnknown(),
+		Name:       types.StringValue("net"),
+		CIDR:       types.StringValue("10.70.0.0/24"),
+		Gateway:    types.StringValue("10.70.0.1"),
+	}
+	state, identity := h.create(&planned)
+	created := h.model(state)
+	if created.ID.ValueInt64() == 0 || created.Region.ValueString() != fakepanel.Region {
+		t.Errorf("created = %+v", created)
+	}
+	if state, removed := h.read(state, identity); removed || h.model(state) != created {
+		t.Errorf("read changed a fresh network: removed %t", removed)
+	}
+
+	renamed := created
+	renamed.Name = types.StringValue("net-renamed")
+	state = h.update(state, identity, &renamed)
+	if got := h.model(state); got != renamed {
+		t.Errorf("updated = %+v, want %+v", got, renamed)
+	}
+
+	if imported := h.model(h.importID(created.ID.String())); imported != renamed {
+		t.Errorf("imported %+v, want %+v", imported, renamed)
+	}
+
+	if diags := h.delete(state, identity); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if _, removed := h.read(state, identity); !removed {
+		t.Error("a deleted network should be removed from state on read")
+	}
+}
+
+func TestPublicIPResource_crud(t *testing.T) {
+	c := testFakePanelClient(t)
+	h := newCRUDHarness[PublicIPResourceModel](t, c, NewPublicIPResource())
+
+	planned := PublicIPResourceModel{
+		ID:         types.Int64Unknown(),
+		Region:     types.StringUnknown(),
+		ProjectTag: types.StringUnknown(),
+		Name:       types.StringValue("ip"),
+		IP:         types.StringUnknown(),
+		Mask:       types.StringUnknown(),
+		Gateway:    types.StringUnknown(),
+	}
+	state, identity := h.create(&planned)
+	created := h.model(state)
+	if created.ID.ValueInt64() == 0 || created.IP.ValueString() == "" || created.Gateway.IsUnknown() {
+		t.Errorf("created = %+v", created)
+	}
+
+	renamed := created
+	renamed.Name = types.StringValue("ip-renamed")
+	state = h.update(state, identity, &renamed)
+	if got := h.model(state); got != renamed {
+		t.Errorf("updated = %+v, want %+v", got, renamed)
+	}
+
+	if imported := h.model(h.importID(created.ID.String())); imported != renamed {
+		t.Errorf("imported %+v, want %+v", imported, renamed)
+	}
+
+	if diags := h.delete(state, identity); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if _, removed := h.read(state, identity); !removed {
+		t.Error("a deleted public IP should be removed from state on read")
+	}
+}
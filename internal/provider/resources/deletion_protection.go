@@ -0,0 +1,220 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// deletion_protection lives only in state: the panel has no such setting. It is
+// enforced at plan time by checkDeletionProtection, deferred from ModifyPlan, and
+// again by Delete as a backstop.
+
+// deletionProtectionAttribute is the deletion_protection attribute; what names
+// the object protected.
+func deletionProtectionAttribute(what string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "Whether Terraform refuses to destroy the " + what + ". While `true`, any plan that " +
+			"destroys or replaces it fails. To destroy it, first apply `deletion_protection = false`. " +
+			"Defaults to `false`.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// deletionProtectionOrDefault is v, or false when v is null: state written
+// before the attribute existed, or just imported, has no value for it.
+func deletionProtectionOrDefault(v types.Bool) types.Bool {
+	if v.IsNull() {
+		return types.BoolValue(false)
+	}
+	return v
+}
+
+// checkDeletionProtection fails a plan that destroys or replaces a resource of
+// type typeName whose prior state has deletion_protection set. Defer it at the
+// top of ModifyPlan: it then runs after every early return and sees the
+// replacements ModifyPlan itself adds, as well as those of the schema.
+func checkDeletionProtection(ctx context.Context, typeName string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+	var protected types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	if !protected.ValueBool() {
+		return
+	}
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.AddError("Deletion Protection Enabled",
+			fmt.Sprintf("This %s has deletion_protection set, so it cannot be destroyed. "+
+				"Set deletion_protection = false and apply that change before destroying it.", typeName))
+		return
+	}
+
+	var planSchema schema.Schema
+	if s, ok := req.Plan.Schema.(schema.Schema); ok {
+		planSchema = s
+	}
+	replace := append(path.Paths{}, resp.RequiresReplace...)
+	replace = append(replace, schemaReplacements(ctx, planSchema.Attributes, path.Empty(), req, &resp.Diagnostics)...)
+	if len(replace) == 0 {
+		return
+	}
+	var names []string
+	for _, p := range replace {
+		if name := p.String(); !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	resp.Diagnostics.AddError("Deletion Protection Enabled",
+		fmt.Sprintf("This %s has deletion_protection set, so it cannot be replaced. The plan replaces it "+
+			"because of changes to: %s. Revert those changes, or set deletion_protection = false and "+
+			"apply that change first.", typeName, strings.Join(names, ", ")))
+}
+
+// schemaReplacements re-runs the plan modifiers of attrs, nested under parent, and
+// returns the paths of those that require replacement. The framework applies them
+// before ModifyPlan but does not pass their verdict on to it.
+func schemaReplacements(ctx context.Context, attrs map[string]schema.Attribute, parent path.Path, req resource.ModifyPlanRequest, diags *diag.Diagnostics) path.Paths {
+	var paths path.Paths
+	for name, a := range attrs {
+		p := parent.AtName(name)
+		var replace bool
+		switch a := a.(type) {
+		case schema.StringAttribute:
+			replace = stringRequiresReplace(ctx, a.PlanModifiers, p, req, diags)
+		case schema.Int64Attribute:
+			replace = int64RequiresReplace(ctx, a.PlanModifiers, p, req, diags)
+		case schema.BoolAttribute:
+			replace = boolRequiresReplace(ctx, a.PlanModifiers, p, req, diags)
+		case schema.SingleNestedAttribute:
+			paths = append(paths, schemaReplacements(ctx, a.Attributes, p, req, diags)...)
+		}
+		if replace {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+func stringRequiresReplace(ctx context.Context, modifiers []planmodifier.String, p path.Path, req resource.ModifyPlanRequest, diags *diag.Diagnostics) bool {
+	if len(modifiers) == 0 {
+		return false
+	}
+	var config, plan, state types.String
+	if !getPlanModifierValues(ctx, p, req, diags, &config, &plan, &state) {
+		return false
+	}
+	for _, m := range modifiers {
+		mreq := planmodifier.StringRequest{Path: p, Config: req.Config, ConfigValue: config, Plan: req.Plan, PlanValue: plan, State: req.State, StateValue: state, Private: req.Private}
+		mresp := planmodifier.StringResponse{PlanValue: plan}
+		m.PlanModifyString(ctx, mreq, &mresp)
+		if mresp.RequiresReplace {
+			return true
+		}
+	}
+	return false
+}
+
+func int64RequiresReplace(ctx context.Context, modifiers []planmodifier.Int64, p path.Path, req resource.ModifyPlanRequest, diags *diag.Diagnostics) bool {
+	if len(modifiers) == 0 {
+		return false
+	}
+	var config, plan, state types.Int64
+	if !getPlanModifierValues(ctx, p, req, diags, &config, &plan, &state) {
+		return false
+	}
+	for _, m := range modifiers {
+		mreq := planmodifier.Int64Request{Path: p, Config: req.Config, ConfigValue: config, Plan: req.Plan, PlanValue: plan, State: req.State, StateValue: state, Private: req.Private}
+		mresp := planmodifier.Int64Response{PlanValue: plan}
+		m.PlanModifyInt64(ctx, mreq, &mresp)
+		if mresp.RequiresReplace {
+			return true
+		}
+	}
+	return false
+}
+
+func boolRequiresReplace(ctx context.Context, modifiers []planmodifier.Bool, p path.Path, req resource.ModifyPlanRequest, diags *diag.Diagnostics) bool {
+	if len(modifiers) == 0 {
+		return false
+	}
+	var config, plan, state types.Bool
+	if !getPlanModifierValues(ctx, p, req, diags, &config, &plan, &state) {
+		return false
+	}
+	for _, m := range modifiers {
+		mreq := planmodifier.BoolRequest{Path: p, Config: req.Config, ConfigValue: config, Plan: req.Plan, PlanValue: plan, State: req.State, StateValue: state, Private: req.Private}
+		mresp := planmodifier.BoolResponse{PlanValue: plan}
+		m.PlanModifyBool(ctx, mreq, &mresp)
+		if mresp.RequiresReplace {
+			return true
+		}
+	}
+	return false
+}
+
+// getPlanModifierValues reads the config, plan and state values at p for a plan
+// modifier request, reporting whether all three could be read.
+func getPlanModifierValues(ctx context.Context, p path.Path, req resource.ModifyPlanRequest, diags *diag.Diagnostics, config, plan, state any) bool {
+	var d diag.Diagnostics
+	d.Append(req.Config.GetAttribute(ctx, p, config)...)
+	d.Append(req.Plan.GetAttribute(ctx, p, plan)...)
+	d.Append(req.State.GetAttribute(ctx, p, state)...)
+	diags.Append(d...)
+	return !d.HasError()
+}
+
+// refuseProtectedDelete fails a Delete of a resource whose state has
+// deletion_protection set, reporting whether it did. ModifyPlan fails such a
+// plan first; this is the backstop should a destroy reach the provider anyway.
+func refuseProtectedDelete(ctx context.Context, typeName string, state tfsdk.State, diags *diag.Diagnostics) bool {
+	var protected types.Bool
+	diags.Append(state.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	if !protected.ValueBool() {
+		return false
+	}
+	diags.AddError("Deletion Protection Enabled",
+		fmt.Sprintf("This %s has deletion_protection set, so it cannot be destroyed. "+
+			"Set deletion_protection = false and apply that change before destroying it.", typeName))
+	return true
+}
+
+// updateDeletionProtectionOnly completes an Update that changes nothing but
+// deletion_protection by saving the plan as the new state, with no API call. It
+// reports whether it did so; otherwise the Update proceeds as usual.
+func updateDeletionProtectionOnly(req resource.UpdateRequest, resp *resource.UpdateResponse) bool {
+	attr := tftypes.NewAttributePath().WithAttributeName("deletion_protection")
+	prior, _, err := tftypes.WalkAttributePath(req.State.Raw, attr)
+	if err != nil {
+		return false
+	}
+	priorValue, ok := prior.(tftypes.Value)
+	if !ok {
+		return false
+	}
+	unchanged, err := tftypes.Transform(req.Plan.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if p.Equal(attr) {
+			return priorValue, nil
+		}
+		return v, nil
+	})
+	if err != nil || !unchanged.Equal(req.State.Raw) {
+		return false
+	}
+	resp.State.Raw = req.Plan.Raw
+	return true
+}
@@ -0,0 +1,135 @@
+package resources
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testVolume(protected bool, typ string) *VolumeResourceModel {
+	return &VolumeResourceModel{
+		ID:                 types.Int64Value(42),
+		Region:             types.StringValue("UZ-5"),
+		ProjectTag:         types.StringValue("default"),
+		Name:               types.StringValue("data"),
+		Type:               types.StringValue(typ),
+		Size:               types.Int64Value(20),
+		DeletionProtection: types.BoolValue(protected),
+	}
+}
+
+// planVolume runs the volume's ModifyPlan from prior to planned; a nil planned
+// is a destroy.
+func planVolume(t *testing.T, prior, planned *VolumeResourceModel) resource.ModifyPlanResponse {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewVolumeResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	null := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	req := resource.ModifyPlanRequest{
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: null},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: null},
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: null},
+	}
+	if diags := req.State.Set(ctx, prior); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if planned != nil {
+		if diags := req.Plan.Set(ctx, planned); diags.HasError() {
+			t.Fatal(diags)
+		}
+		req.Config.Raw = req.Plan.Raw
+	}
+	resp := resource.ModifyPlanResponse{Plan: req.Plan}
+	NewVolumeResource().(resource.ResourceWithModifyPlan).ModifyPlan(ctx, req, &resp)
+	return resp
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	resp := planVolume(t, testVolume(true, "ssd"), nil)
+	if !resp.Diagnostics.HasError() {
+		t.Error("destroying a protected volume should fail")
+	}
+
+	resp = planVolume(t, testVolume(true, "ssd"), testVolume(true, "hdd"))
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("replacing a protected volume should fail")
+	}
+	if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, "type") {
+		t.Errorf("the error should name the attribute forcing replacement: %s", detail)
+	}
+
+	// Renaming is an in-place update, and so is turning the protection off.
+	renamed := testVolume(true, "ssd")
+	renamed.Name = types.StringValue("data-2")
+	if resp := planVolume(t, testVolume(true, "ssd"), renamed); resp.Diagnostics.HasError() {
+		t.Errorf("an in-place update should be allowed: %v", resp.Diagnostics)
+	}
+	if resp := planVolume(t, testVolume(true, "ssd"), testVolume(false, "ssd")); resp.Diagnostics.HasError() {
+		t.Errorf("removing the protection should be allowed: %v", resp.Diagnostics)
+	}
+
+	// The protection that counts is the applied one, not the one being planned.
+	if resp := planVolume(t, testVolume(false, "ssd"), nil); resp.Diagnostics.HasError() {
+		t.Errorf("destroying an unprotected volume should be allowed: %v", resp.Diagnostics)
+	}
+	if resp := planVolume(t, testVolume(false, "ssd"), testVolume(true, "hdd")); resp.Diagnostics.HasError() {
+		t.Errorf("replacing an unprotected volume should be allowed: %v", resp.Diagnostics)
+	}
+}
+
+func TestRefuseProtectedDelete(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewVolumeResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	for _, protected := range []bool{true, false} {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		if diags := state.Set(ctx, testVolume(protected, "ssd")); diags.HasError() {
+			t.Fatal(diags)
+		}
+		var resp resource.DeleteResponse
+		refused := refuseProtectedDelete(ctx, "prodata_volume", state, &resp.Diagnostics)
+		if refused != protected || resp.Diagnostics.HasError() != protected {
+			t.Errorf("protected %t: refused %t, diagnostics %v", protected, refused, resp.Diagnostics)
+		}
+	}
+}
+
+func TestUpdateDeletionProtectionOnly(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewVolumeResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	renamed := testVolume(false, "ssd")
+	renamed.Name = types.StringValue("data-2")
+	for _, tc := range []struct {
+		name    string
+		planned *VolumeResourceModel
+		want    bool
+	}{
+		{"protection only", testVolume(false, "ssd"), true},
+		{"protection and name", renamed, false},
+	} {
+		req := resource.UpdateRequest{
+			State: tfsdk.State{Schema: schemaResp.Schema},
+			Plan:  tfsdk.Plan{Schema: schemaResp.Schema},
+		}
+		req.State.Set(ctx, testVolume(true, "ssd"))
+		req.Plan.Set(ctx, tc.planned)
+		resp := resource.UpdateResponse{State: req.State}
+
+		if got := updateDeletionProtectionOnly(req, &resp); got != tc.want {
+			t.Errorf("%s: handled = %t, want %t", tc.name, got, tc.want)
+		}
+		if tc.want && !resp.State.Raw.Equal(req.Plan.Raw) {
+			t.Errorf("%s: state not updated to the plan", tc.name)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// scopedIdentityModel is the resource identity of everything the panel addresses
+// by a numeric id within a region and project. Region and project_tag are always
+// the resolved values from state, never null, once the resource exists.
+type scopedIdentityModel struct {
+	ID         types.Int64  `tfsdk:"id"`
+	Region     types.String `tfsdk:"region"`
+	ProjectTag types.String `tfsdk:"project_tag"`
+}
+
+// bucketIdentityModel is scopedIdentityModel for prodata_s3_bucket, whose id is
+// the bucket name.
+type bucketIdentityModel struct {
+	ID         types.String `tfsdk:"id"`
+	Region     types.String `tfsdk:"region"`
+	ProjectTag types.String `tfsdk:"project_tag"`
+}
+
+// nodePoolIdentityModel is the identity of prodata_kubernetes_node_pool: pool ids
+// are only meaningful within their cluster.
+type nodePoolIdentityModel struct {
+	ID         types.Int64  `tfsdk:"id"`
+	ClusterID  types.Int64  `tfsdk:"cluster_id"`
+	Region     types.String `tfsdk:"region"`
+	ProjectTag types.String `tfsdk:"project_tag"`
+}
+
+// publicIPAttachmentIdentityModel is the identity of prodata_public_ip_attachment,
+// which has no id of its own: the VM and the public IP attached to it.
+type publicIPAttachmentIdentityModel struct {
+	VmID       types.Int64  `tfsdk:"vm_id"`
+	PublicIPID types.Int64  `tfsdk:"public_ip_id"`
+	Region     types.String `tfsdk:"region"`
+	ProjectTag types.String `tfsdk:"project_tag"`
+}
+
+// volumeAttachmentIdentityModel is the identity of prodata_volume_attachment: the
+// VM and the volume attached to it.
+type volumeAttachmentIdentityModel struct {
+	VmID       types.Int64  `tfsdk:"vm_id"`
+	VolumeID   types.Int64  `tfsdk:"volume_id"`
+	Region     types.String `tfsdk:"region"`
+	ProjectTag types.String `tfsdk:"project_tag"`
+}
+
+// scopeIdentityAttributes are the region and project_tag identity attributes
+// shared by every identity schema. Both are optional on import and default to
+// the provider's region and project_tag.
+func scopeIdentityAttributes() map[string]identityschema.Attribute {
+	return map[string]identityschema.Attribute{
+		"region": identityschema.StringAttribute{
+			Description:       "Region ID. Defaults to the provider's region on import.",
+			OptionalForImport: true,
+		},
+		"project_tag": identityschema.StringAttribute{
+			Description:       "Project tag. Defaults to the provider's project_tag on import.",
+			OptionalForImport: true,
+		},
+	}
+}
+
+// scopedIdentitySchema is the identity schema of a resource addressed by a
+// numeric id; what names the kind of object in the id's description.
+func scopedIdentitySchema(what string) identityschema.Schema {
+	return compositeIdentitySchema(map[string]string{"id": "ID of the " + what + "."})
+}
+
+// compositeIdentitySchema is the identity schema of a resource addressed by
+// several numeric ids, mapped from attribute name to description. Each is also
+// the name of the resource attribute it identifies.
+func compositeIdentitySchema(ids map[string]string) identityschema.Schema {
+	attrs := scopeIdentityAttributes()
+	for name, description := range ids {
+		attrs[name] = identityschema.Int64Attribute{
+			Description:       description,
+			RequiredForImport: true,
+		}
+	}
+	return identityschema.Schema{Attributes: attrs}
+}
+
+// bucketIdentitySchema is scopedIdentitySchema for prodata_s3_bucket.
+func bucketIdentitySchema() identityschema.Schema {
+	attrs := scopeIdentityAttributes()
+	attrs["id"] = identityschema.StringAttribute{
+		Description:       "Name of the bucket.",
+		RequiredForImport: true,
+	}
+	return identityschema.Schema{Attributes: attrs}
+}
+
+// setScopedIdentity records id, region and project_tag as the resource identity.
+// identity is nil when the caller is not the framework (unit tests driving CRUD
+// methods directly), in which case there is nothing to record.
+func setScopedIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.Int64, region, projectTag types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, scopedIdentityModel{ID: id, Region: region, ProjectTag: projectTag})
+}
+
+// setIdentity records v, one of the identity models above, as the resource
+// identity. Like setScopedIdentity it is a no-op when identity is nil.
+func setIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, v any) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, v)
+}
+
+// setBucketIdentity is setScopedIdentity for prodata_s3_bucket.
+func setBucketIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id, region, projectTag types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, bucketIdentityModel{ID: id, Region: region, ProjectTag: projectTag})
+}
+
+// importScopedIdentity handles an import block that sets `identity` instead of
+// `id` (Terraform 1.12+) for a resource whose identity is scopedIdentityModel.
+func importScopedIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, defaultRegion, defaultProjectTag string) {
+	importIdentity(ctx, req, resp, defaultRegion, defaultProjectTag, "id")
+}
+
+// importIdentity handles an import block that sets `identity` instead of `id`:
+// the numeric ids named by idAttrs are copied from the identity onto the
+// same-named state attributes, with region and project_tag defaulting to the
+// provider's. The Read that follows an import refreshes everything else, and the
+// identity with it.
+func importIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, defaultRegion, defaultProjectTag string, idAttrs ...string) {
+	for _, name := range idAttrs {
+		var id types.Int64
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root(name), &id)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), id)...)
+		resp.Diagnostics.Append(resp.Identity.SetAttribute(ctx, path.Root(name), id)...)
+	}
+	var region, projectTag types.String
+	resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("region"), &region)...)
+	resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("project_tag"), &projectTag)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	region = types.StringValue(valueOrDefault(region, defaultRegion))
+	projectTag = types.StringValue(valueOrDefault(projectTag, defaultProjectTag))
+	for name, v := range map[string]types.String{"region": region, "project_tag": projectTag} {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), v)...)
+		resp.Diagnostics.Append(resp.Identity.SetAttribute(ctx, path.Root(name), v)...)
+	}
+}
+
+// importByIdentity reports whether an import was started from an import block's
+// `identity` rather than an import ID.
+func importByIdentity(req resource.ImportStateRequest) bool {
+	return req.ID == "" && req.Identity != nil && !req.Identity.Raw.IsNull()
+}
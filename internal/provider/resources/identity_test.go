@@ -0,0 +1,145 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-prodata/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// importWithIdentity runs r's ImportState the way Terraform 1.12+ does for an
+// import block with `identity = {...}`, returning the imported state.
+func importWithIdentity(t *testing.T, r resource.Resource, identity map[string]tftypes.Value) (tfsdk.State, tfsdk.ResourceIdentity) {
+	t.Helper()
+	ctx := context.Background()
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{
+		ProviderData: &client.Client{Region: "UZ-5", ProjectTag: "default"},
+	}, &resource.ConfigureResponse{})
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	var identityResp resource.IdentitySchemaResponse
+	r.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identityResp)
+	identityType := identityResp.IdentitySchema.Type().TerraformType(ctx)
+
+	req := resource.ImportStateRequest{
+		Identity: &tfsdk.ResourceIdentity{
+			Schema: identityResp.IdentitySchema,
+			Raw:    tftypes.NewValue(identityType, identity),
+		},
+	}
+	resp := resource.ImportStateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+		Identity: &tfsdk.ResourceIdentity{
+			Schema: identityResp.IdentitySchema,
+			Raw:    tftypes.NewValue(identityType, nil),
+		},
+	}
+	r.(resource.ResourceWithImportState).ImportState(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("import: %v", resp.Diagnostics)
+	}
+	return resp.State, *resp.Identity
+}
+
+func TestImportState_identity(t *testing.T) {
+	ctx := context.Background()
+
+	// region and project_tag are optional and default to the provider's.
+	state, identity := importWithIdentity(t, NewVmResource(), map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.Number, 42),
+		"region":      tftypes.NewValue(tftypes.String, nil),
+		"project_tag": tftypes.NewValue(tftypes.String, nil),
+	})
+	var vm VmResourceModel
+	if diags := state.Get(ctx, &vm); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if vm.ID.ValueInt64() != 42 || vm.Region.ValueString() != "UZ-5" || vm.ProjectTag.ValueString() != "default" {
+		t.Errorf("vm state = id %s, region %s, project_tag %s", vm.ID, vm.Region, vm.ProjectTag)
+	}
+	var got scopedIdentityModel
+	if diags := identity.Get(ctx, &got); diags.HasError() || got.Region.ValueString() != "UZ-5" {
+		t.Errorf("identity = %+v, %v", got, diags)
+	}
+
+	state, _ = importWithIdentity(t, NewK8sClusterResource(), map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.Number, 7),
+		"region":      tftypes.NewValue(tftypes.String, "UZ-3"),
+		"project_tag": tftypes.NewValue(tftypes.String, "team-a"),
+	})
+	var cl K8sClusterModel
+	if diags := state.Get(ctx, &cl); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if cl.ID.ValueInt64() != 7 || cl.Region.ValueString() != "UZ-3" || cl.ProjectTag.ValueString() != "team-a" {
+		t.Errorf("cluster state = id %s, region %s, project_tag %s", cl.ID, cl.Region, cl.ProjectTag)
+	}
+
+	state, _ = importWithIdentity(t, NewS3BucketResource(), map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.String, "logs"),
+		"region":      tftypes.NewValue(tftypes.String, nil),
+		"project_tag": tftypes.NewValue(tftypes.String, "team-a"),
+	})
+	var b S3BucketResourceModel
+	if diags := state.Get(ctx, &b); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if b.Name.ValueString() != "logs" || b.ID.ValueString() != "logs" || b.Region.ValueString() != "UZ-5" {
+		t.Errorf("bucket state = id %s, name %s, region %s", b.ID, b.Name, b.Region)
+	}
+
+	// Composite identities copy each id onto the same-named attribute.
+	state, _ = importWithIdentity(t, NewK8sNodePoolResource(), map[string]tftypes.Value{
+		"id":          tftypes.NewValue(tftypes.Number, 3),
+		"cluster_id":  tftypes.NewValue(tftypes.Number, 7),
+		"region":      tftypes.NewValue(tftypes.String, nil),
+		"project_tag": tftypes.NewValue(tftypes.String, nil),
+	})
+	var pool K8sNodePoolModel
+	if diags := state.Get(ctx, &pool); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if pool.ID.ValueInt64() != 3 || pool.ClusterID.ValueInt64() != 7 || pool.ProjectTag.ValueString() != "default" {
+		t.Errorf("node pool state = id %s, cluster_id %s, project_tag %s", pool.ID, pool.ClusterID, pool.ProjectTag)
+	}
+
+	state, identity = importWithIdentity(t, NewVolumeAttachmentResource(), map[string]tftypes.Value{
+		"vm_id":       tftypes.NewValue(tftypes.Number, 42),
+		"volume_id":   tftypes.NewValue(tftypes.Number, 9),
+		"region":      tftypes.NewValue(tftypes.String, "UZ-3"),
+		"project_tag": tftypes.NewValue(tftypes.String, nil),
+	})
+	var va VolumeAttachmentResourceModel
+	if diags := state.Get(ctx, &va); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if va.VmID.ValueInt64() != 42 || va.VolumeID.ValueInt64() != 9 || va.Region.ValueString() != "UZ-3" || !va.AttachedVolumeID.IsNull() {
+		t.Errorf("volume attachment state = %+v", va)
+	}
+	var vai volumeAttachmentIdentityModel
+	if diags := identity.Get(ctx, &vai); diags.HasError() || vai.ProjectTag.ValueString() != "default" || vai.VolumeID.ValueInt64() != 9 {
+		t.Errorf("volume attachment identity = %+v, %v", vai, diags)
+	}
+
+	state, _ = importWithIdentity(t, NewPublicIPAttachmentResource(), map[string]tftypes.Value{
+		"vm_id":        tftypes.NewValue(tftypes.Number, 42),
+		"public_ip_id": tftypes.NewValue(tftypes.Number, 5),
+		"region":       tftypes.NewValue(tftypes.String, nil),
+		"project_tag":  tftypes.NewValue(tftypes.String, nil),
+	})
+	var pa PublicIPAttachmentResourceModel
+	if diags := state.Get(ctx, &pa); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if pa.VmID.ValueInt64() != 42 || pa.PublicIPID.ValueInt64() != 5 || pa.Region.ValueString() != "UZ-5" {
+		t.Errorf("public IP attachment state = %+v", pa)
+	}
+}
@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"context"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ list.ListResource              = &K8sClusterListResource{}
+	_ list.ListResourceWithConfigure = &K8sClusterListResource{}
+)
+
+// K8sClusterListResource lists the Kubernetes clusters in a project, skipping
+// any the panel still reports as DELETED. A listed cluster's default_node_pool
+// is reconstructed from its lowest-id pool, exactly as on import (ADR-K6).
+type K8sClusterListResource struct {
+	listBase
+}
+
+func NewK8sClusterListResource() list.ListResource {
+	return &K8sClusterListResource{}
+}
+
+func (l *K8sClusterListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_kubernetes_cluster"
+}
+
+func (l *K8sClusterListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listScopeSchema("Kubernetes clusters")
+}
+
+func (l *K8sClusterListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_kubernetes_cluster", "list")
+	var diags diag.Diagnostics
+	defer op.End(nil, &diags)
+
+	region, projectTag := l.scope(ctx, req.Config, &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	clusters, err := l.c.ListClusters(ctx, &client.RequestOpts{Region: region, ProjectTag: projectTag})
+	if err != nil {
+		diags.AddError("Unable to list Kubernetes clusters", client.ErrorDetail(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	tflog.Debug(ctx, "Listed Kubernetes clusters", map[string]any{"count": len(clusters), "region": region, "project_tag": projectTag})
+
+	r := &K8sClusterResource{c: l.c}
+	items := make([]listItem, 0, len(clusters))
+	for i := range clusters {
+		cl := &clusters[i]
+		if cl.Status == client.ClusterStatusDeleted {
+			continue
+		}
+		items = append(items, listItem{
+			displayName: cl.Name,
+			identity: scopedIdentityModel{
+				ID:         types.Int64Value(cl.ID),
+				Region:     types.StringValue(region),
+				ProjectTag: types.StringValue(projectTag),
+			},
+			resource: func(ctx context.Context, diags *diag.Diagnostics) any {
+				m := K8sClusterModel{DeletionProtection: types.BoolValue(false)}
+				r.applyServerState(ctx, &m, cl, 0, region, projectTag, true, diags)
+				return &m
+			},
+		})
+	}
+	stream.Results = streamListItems(ctx, req, items)
+}
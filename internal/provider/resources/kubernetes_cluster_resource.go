@@ -2,6 +2,7 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
 	"terraform-provider-prodata/internal/tfutil"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
@@ -36,6 +38,8 @@ var (
 	_ resource.ResourceWithModifyPlan     = &K8sClusterResource{}
 	_ resource.ResourceWithImportState    = &K8sClusterResource{}
 	_ resource.ResourceWithValidateConfig = &K8sClusterResource{}
+	_ resource.ResourceWithIdentity       = &K8sClusterResource{}
+	_ resource.ResourceWithUpgradeState   = &K8sClusterResource{}
 )
 
 // K8sClusterResource implements the prodata_kubernetes_cluster resource. The
@@ -64,6 +68,7 @@ type K8sClusterModel struct {
 	MasterFlavorID        types.Int64          `tfsdk:"master_flavor_id"`
 	ControlPlaneSize      types.String         `tfsdk:"control_plane_size"`
 	DefaultNodePool       *K8sDefaultPoolModel `tfsdk:"default_node_pool"`
+	DeletionProtection    types.Bool           `tfsdk:"deletion_protection"`
 
 	// Computed, server-owned.
 	APIEndpoint       types.String   `tfsdk:"api_endpoint"`
@@ -154,6 +159,7 @@ type K8sAutoscalingModel struct {
 }
 
 const (
+	k8sMinPollInterval    = 5 * time.Second
 	k8sPollInterval       = 30 * time.Second
 	k8sDefaultCreateTime  = 90 * time.Minute
 	k8sDefaultUpdateTime  = 60 * time.Minute
@@ -168,6 +174,15 @@ const (
 	k8sMaxNameLen = 24
 )
 
+// States reported to client.Waiter by the lookups that poll for an object to
+// appear (a discovered pool id, a read-back) rather than for a panel status.
+const (
+	k8sLookupFound     = "FOUND"
+	k8sLookupPending   = "PENDING"
+	k8sLookupMissing   = "MISSING"
+	k8sLookupAmbiguous = "AMBIGUOUS"
+)
+
 // k8sNameRegex: lowercase letters, digits and hyphens; no leading/trailing
 // hyphen. The backend silently lowercases names, so we require lowercase up front
 // to keep state stable.
@@ -196,24 +211,55 @@ func lockCluster(id int64) func() {
 // cluster is unblocked or the context deadline hits. Returns an error suitable
 // for a diagnostic.
 func (r *K8sClusterResource) ensureMutable(ctx context.Context, id int64, opts *client.RequestOpts) error {
-	for {
-		cl, err := r.c.GetCluster(ctx, id, opts)
-		if err != nil {
-			return err
-		}
-		if cl.Status == client.ClusterStatusFail {
-			return fmt.Errorf("cluster %d is in FAIL state and cannot be modified; inspect it in the panel and recreate", id)
-		}
-		if !cl.Blocked {
-			return nil
-		}
-		tflog.Debug(ctx, "Cluster is blocked by an in-flight operation, waiting", map[string]any{"id": id})
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("timed out waiting for cluster %d to become unblocked: %w", id, ctx.Err())
-		case <-time.After(k8sPollInterval):
-		}
-	}
+	return waitClusterUnblocked(ctx, r.c, id, opts, false)
+}
+
+// Synthetic states for the cluster mutability waits. The backend reports an
+// in-flight operation through the blocked flag, not through the status.
+const (
+	clusterStateBlocked = "BLOCKED"
+	clusterStateIdle    = "IDLE"
+)
+
+// waitClusterUnblocked waits until a cluster has no in-flight (blocked) operation.
+// A FAILed cluster is refused with a friendly error, unless failOK (a FAILed
+// cluster stays blocked, so waiting it out would hang). With failOK a vanished
+// cluster also ends the wait successfully.
+func waitClusterUnblocked(ctx context.Context, c *client.Client, id int64, opts *client.RequestOpts, failOK bool) error {
+	w := &client.Waiter[*client.Cluster]{
+		Name: fmt.Sprintf("cluster %d", id),
+		Op:   "cluster_unblocked",
+		ID:   id,
+		Refresh: func(ctx context.Context) (*client.Cluster, string, error) {
+			cl, err := c.GetCluster(ctx, id, opts)
+			switch {
+			case err != nil:
+				return nil, "", err
+			case cl.Status == client.ClusterStatusFail:
+				return cl, client.ClusterStatusFail, nil
+			case cl.Blocked:
+				return cl, clusterStateBlocked, nil
+			}
+			return cl, clusterStateIdle, nil
+		},
+		Target:               []string{clusterStateIdle},
+		Failure:              []string{client.ClusterStatusFail},
+		MaxConsecutiveErrors: c.Retry.PollErrorToleranceOr(k8sMaxConsecutiveErrs),
+		MinTimeout:           k8sMinPollInterval,
+		MaxPollInterval:      k8sPollInterval,
+	}
+	if failOK {
+		w.Target = []string{clusterStateIdle, client.ClusterStatusFail}
+		w.Failure = nil
+		w.NotFound = client.IsKuberNotFound
+		w.TargetNotFound = true
+	}
+	_, err := w.Wait(ctx)
+	var stateErr *client.UnexpectedStateError
+	if errors.As(err, &stateErr) {
+		return fmt.Errorf("cluster %d is in FAIL state and cannot be modified; inspect it in the panel and recreate", id)
+	}
+	return err
 }
 
 func NewK8sClusterResource() resource.Resource {
@@ -226,6 +272,9 @@ func (r *K8sClusterResource) Metadata(_ context.Context, req resource.MetadataRe
 
 func (r *K8sClusterResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1: node_subnet removed, master_flavor_id made Optional+Computed
+		// alongside control_plane_size (0.23.0). See UpgradeState.
+		Version: 1,
 		MarkdownDescription: "Manages a ProData Managed Kubernetes cluster and its inline default worker " +
 			"node pool. Cluster creation is asynchronous; `terraform apply` blocks until the cluster " +
 			"reaches a usable state (SUCCESS with a kubeconfig) or the create timeout elapses. " +
@@ -514,11 +563,31 @@ func (r *K8sClusterResource) Schema(ctx context.Context, _ resource.SchemaReques
 				Computed:            true,
 				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
+			"deletion_protection": deletionProtectionAttribute("cluster"),
+			"timeouts":            timeouts.Attributes(ctx, timeouts.Opts{Create: true, Update: true, Delete: true}),
 		},
 	}
 }
 
+// UpgradeState migrates version 0 state, written before and after 0.23.0. The
+// removed node_subnet is dropped. A pre-0.23.0 state has no control_plane_size,
+// which upgrades to null beside its master_flavor_id: exactly the state of a
+// cluster configured with master_flavor_id alone, which is what such a
+// configuration must now be.
+func (r *K8sClusterResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeRawState(ctx, req, resp, nil)
+			},
+		},
+	}
+}
+
+func (r *K8sClusterResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = scopedIdentitySchema("cluster")
+}
+
 func (r *K8sClusterResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -553,7 +622,9 @@ func (r *K8sClusterResource) ValidateConfig(ctx context.Context, req resource.Va
 			"Set exactly one of master_flavor_id (an explicit flavor ID) or control_plane_size "+
 				"(small/medium/large, resolved for you).",
 		)
-	case !flavorSet && !sizeSet:
+	case cfg.MasterFlavorID.IsNull() && cfg.ControlPlaneSize.IsNull():
+		// Only when both are null: an unknown value (e.g. a flavor ID from a data
+		// source during validate) will be set once it is known.
 		resp.Diagnostics.AddAttributeError(
 			path.Root("control_plane_size"),
 			"a control-plane size is required",
@@ -597,14 +668,26 @@ func (r *K8sClusterResource) ValidateConfig(ctx context.Context, req resource.Va
 // the kubeconfig / api_endpoint and the cluster transits PROCESSING, so those
 // computed values must be unknown in the plan (ADR-K3) — otherwise Terraform's
 // "computed output must be consistent" check fails after apply. Also handles
-// default-pool out-of-band deletion drift (ADR-K8).
+// default-pool out-of-band deletion drift (ADR-K8). On create it refuses an
+// omitted node_ip_range when the panel cannot allocate one.
 func (r *K8sClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer checkDeletionProtection(ctx, "prodata_kubernetes_cluster", req, resp)
+
 	// Destroy plan — nothing to do.
 	if req.Plan.Raw.IsNull() {
 		return
 	}
-	// Create plan — no prior state to diff.
+	// Create plan — no prior state to diff, but an omitted node_ip_range relies on
+	// the panel allocating one, which older panel-main deploys cannot do.
 	if req.State.Raw.IsNull() {
+		var nodeIPRange types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("node_ip_range"), &nodeIPRange)...)
+		if nodeIPRange.IsNull() {
+			requireFeature(r.c, client.FeatureNodeIPRangeAllocation, path.Root("node_ip_range"),
+				"Omitting node_ip_range",
+				"set node_ip_range to a free `start-end` range in the local network",
+				&resp.Diagnostics)
+		}
 		return
 	}
 
@@ -688,6 +771,9 @@ func defaultPoolChanged(state, plan *K8sDefaultPoolModel) bool {
 // ---- Create ----
 
 func (r *K8sClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_kubernetes_cluster", "create")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
 	var plan K8sClusterModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -697,7 +783,7 @@ func (r *K8sClusterResource) Create(ctx context.Context, req resource.CreateRequ
 	region, projectTag := r.resolveScope(plan.Region, plan.ProjectTag)
 	opts := &client.RequestOpts{Region: region, ProjectTag: projectTag}
 
-	createTimeout, diags := plan.Timeouts.Create(ctx, k8sDefaultCreateTime)
+	createTimeout, diags := plan.Timeouts.Create(ctx, timeoutOr(r.c.DefaultTimeouts.Create, k8sDefaultCreateTime))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -755,7 +841,7 @@ func (r *K8sClusterResource) Create(ctx context.Context, req resource.CreateRequ
 			resp.Diagnostics.AddError(
 				"Unable to resolve control_plane_size",
 				fmt.Sprintf("Could not list %s master flavors for region %q to map control_plane_size: %s",
-					haDesc, region, client.KuberErrorDetail(ferr)),
+					haDesc, region, client.ErrorDetail(ferr)),
 			)
 			return
 		}
@@ -801,7 +887,7 @@ func (r *K8sClusterResource) Create(ctx context.Context, req resource.CreateRequ
 	// scope, a lost create response (e.g. a 429 on read-back) must not orphan it.
 	existingID, adoptErr := r.findClusterIDByName(ctx, plan.Name.ValueString(), opts)
 	if adoptErr != nil {
-		resp.Diagnostics.AddError("Unable to verify cluster name availability", client.KuberErrorDetail(adoptErr))
+		resp.Diagnostics.AddError("Unable to verify cluster name availability", client.ErrorDetail(adoptErr))
 		return
 	}
 	if existingID != 0 {
@@ -820,11 +906,11 @@ func (r *K8sClusterResource) Create(ctx context.Context, req resource.CreateRequ
 	// RetryOnBusy covers transient 503 (capacity); it deliberately does not retry
 	// 627. The create endpoint is not idempotent, but it errors before persisting
 	// on a duplicate name, and we adopt-checked above.
-	created, err := client.RetryOnBusy(ctx, client.RetryTimeoutLong, func() (*client.Cluster, error) {
+	created, err := client.RetryOnBusy(ctx, r.c.Retry.BusyTimeoutOr(client.RetryTimeoutLong), func() (*client.Cluster, error) {
 		return r.c.CreateCluster(ctx, wire, opts)
 	})
 	if err != nil {
-		detail := client.KuberErrorDetail(err)
+		detail := client.ErrorDetail(err)
 		// The backend returns a generic HTTP 500 ("Could not create kubernetes cluster")
 		// when it cannot provision the control plane. With the master flavor preflighted
 		// and the version/network/addresses validated above, this is a server-side
@@ -844,6 +930,7 @@ func (r *K8sClusterResource) Create(ctx context.Context, req resource.CreateRequ
 	// failure leaves an importable/destroyable resource rather than an orphan.
 	plan.ID = types.Int64Value(created.ID)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, plan.ID, types.StringValue(region), types.StringValue(projectTag))...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -859,6 +946,7 @@ func (r *K8sClusterResource) Create(ctx context.Context, req resource.CreateRequ
 
 	r.applyServerState(ctx, &plan, result, poolID, region, projectTag, false, nil)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, plan.ID, plan.Region, plan.ProjectTag)...)
 
 	if waitErr != nil {
 		resp.Diagnostics.AddError(
@@ -879,6 +967,11 @@ func (r *K8sClusterResource) Create(ctx context.Context, req resource.CreateRequ
 // ---- Read ----
 
 func (r *K8sClusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_kubernetes_cluster", "read")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.c.DefaultTimeouts.Read)
+	defer cancel()
+
 	var data K8sClusterModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -895,7 +988,7 @@ func (r *K8sClusterResource) Read(ctx context.Context, req resource.ReadRequest,
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Unable to read Kubernetes cluster", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to read Kubernetes cluster", client.ErrorDetail(err))
 		return
 	}
 	if cl.Status == client.ClusterStatusDeleted {
@@ -912,12 +1005,20 @@ func (r *K8sClusterResource) Read(ctx context.Context, req resource.ReadRequest,
 		poolID = data.DefaultNodePool.ID.ValueInt64()
 	}
 	r.applyServerState(ctx, &data, cl, poolID, region, projectTag, true, &resp.Diagnostics)
+	data.DeletionProtection = deletionProtectionOrDefault(data.DeletionProtection)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, data.ID, data.Region, data.ProjectTag)...)
 }
 
 // ---- Update ----
 
 func (r *K8sClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_kubernetes_cluster", "update")
+	defer op.End(&req.State, &resp.Diagnostics)
+	if updateDeletionProtectionOnly(req, resp) {
+		return
+	}
+
 	var state, plan K8sClusterModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -928,7 +1029,7 @@ func (r *K8sClusterResource) Update(ctx context.Context, req resource.UpdateRequ
 	opts := r.optsFromState(state.Region, state.ProjectTag)
 	id := state.ID.ValueInt64()
 
-	updateTimeout, diags := plan.Timeouts.Update(ctx, k8sDefaultUpdateTime)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, timeoutOr(r.c.DefaultTimeouts.Update, k8sDefaultUpdateTime))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -941,14 +1042,14 @@ func (r *K8sClusterResource) Update(ctx context.Context, req resource.UpdateRequ
 	unlock := lockCluster(id)
 	defer unlock()
 	if err := r.ensureMutable(ctx, id, opts); err != nil {
-		resp.Diagnostics.AddError("Cluster is not in a modifiable state", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Cluster is not in a modifiable state", client.ErrorDetail(err))
 		return
 	}
 
 	// 1) Kubernetes version upgrade (in-place, async).
 	if !plan.KubernetesVersion.Equal(state.KubernetesVersion) {
 		if _, err := r.c.UpdateClusterVersion(ctx, id, plan.KubernetesVersion.ValueString(), opts); err != nil {
-			resp.Diagnostics.AddError("Unable to upgrade Kubernetes version", client.KuberErrorDetail(err))
+			resp.Diagnostics.AddError("Unable to upgrade Kubernetes version", client.ErrorDetail(err))
 			return
 		}
 		if _, waitErr := r.waitForClusterReady(ctx, id, plan.KubernetesVersion.ValueString(), opts); waitErr != nil {
@@ -964,7 +1065,7 @@ func (r *K8sClusterResource) Update(ctx context.Context, req resource.UpdateRequ
 		poolID := state.DefaultNodePool.ID.ValueInt64()
 		changed, err := r.reconcileDefaultPool(ctx, id, poolID, state.DefaultNodePool, plan.DefaultNodePool, opts)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to update default node pool", client.KuberErrorDetail(err))
+			resp.Diagnostics.AddError("Unable to update default node pool", client.ErrorDetail(err))
 			return
 		}
 		if changed {
@@ -986,7 +1087,7 @@ func (r *K8sClusterResource) Update(ctx context.Context, req resource.UpdateRequ
 		resp.Diagnostics.AddError(
 			"Cluster updated but its new state could not be read back",
 			fmt.Sprintf("cluster %d was modified successfully but reading it back failed: %s. "+
-				"Run `terraform refresh` to reconcile Terraform state.", id, client.KuberErrorDetail(readErr)),
+				"Run `terraform refresh` to reconcile Terraform state.", id, client.ErrorDetail(readErr)),
 		)
 		return
 	}
@@ -998,6 +1099,7 @@ func (r *K8sClusterResource) Update(ctx context.Context, req resource.UpdateRequ
 	}
 	r.applyServerState(ctx, &plan, final, poolID, region, projectTag, false, nil)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, plan.ID, plan.Region, plan.ProjectTag)...)
 }
 
 // reconcileDefaultPool applies node_count / autoscaling changes to the default
@@ -1054,6 +1156,12 @@ func (r *K8sClusterResource) reconcileDefaultPool(ctx context.Context, clusterID
 // ---- Delete ----
 
 func (r *K8sClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_kubernetes_cluster", "delete")
+	defer op.End(&req.State, &resp.Diagnostics)
+	if refuseProtectedDelete(ctx, "prodata_kubernetes_cluster", req.State, &resp.Diagnostics) {
+		return
+	}
+
 	var data K8sClusterModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -1063,7 +1171,7 @@ func (r *K8sClusterResource) Delete(ctx context.Context, req resource.DeleteRequ
 	opts := r.optsFromState(data.Region, data.ProjectTag)
 	id := data.ID.ValueInt64()
 
-	deleteTimeout, diags := data.Timeouts.Delete(ctx, k8sDefaultDeleteTime)
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, timeoutOr(r.c.DefaultTimeouts.Delete, k8sDefaultDeleteTime))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -1078,51 +1186,46 @@ func (r *K8sClusterResource) Delete(ctx context.Context, req resource.DeleteRequ
 		if client.IsKuberNotFound(err) {
 			return
 		}
-		resp.Diagnostics.AddError("Unable to delete Kubernetes cluster", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to delete Kubernetes cluster", client.ErrorDetail(err))
 		return
 	}
 
 	// Delete is a synchronous soft-delete; confirm the cluster reads back DELETED
 	// (or gone). Tolerate a few transient errors, then surface the real one
 	// instead of spinning silently to the timeout (ADR-K5).
-	var consecutiveErrs int
-	var lastErr error
-	for {
-		cl, err := r.c.GetCluster(ctx, id, opts)
-		switch {
-		case err == nil:
-			consecutiveErrs = 0
-			if cl.Status == client.ClusterStatusDeleted {
-				return
-			}
-		case client.IsKuberNotFound(err):
+	w := &client.Waiter[*client.Cluster]{
+		Name:                 fmt.Sprintf("cluster %d", id),
+		Op:                   "cluster_deleted",
+		ID:                   id,
+		Refresh:              r.clusterStatusRefresh(id, opts),
+		Target:               []string{client.ClusterStatusDeleted},
+		NotFound:             client.IsKuberNotFound,
+		TargetNotFound:       true,
+		MaxConsecutiveErrors: r.c.Retry.PollErrorToleranceOr(k8sMaxConsecutiveErrs),
+		MinTimeout:           k8sMinPollInterval,
+		MaxPollInterval:      k8sPollInterval,
+	}
+	if _, err := w.Wait(ctx); err != nil {
+		var timeoutErr *client.WaitTimeoutError
+		if errors.As(err, &timeoutErr) {
+			resp.Diagnostics.AddError("Kubernetes cluster did not finish deleting", err.Error())
 			return
-		default:
-			consecutiveErrs++
-			lastErr = err
-			if consecutiveErrs > k8sMaxConsecutiveErrs {
-				resp.Diagnostics.AddError("Unable to confirm cluster deletion",
-					fmt.Sprintf("cluster %d: %s", id, client.KuberErrorDetail(err)))
-				return
-			}
-		}
-		select {
-		case <-ctx.Done():
-			msg := ctx.Err().Error()
-			if lastErr != nil {
-				msg = fmt.Sprintf("%s (last error: %s)", msg, lastErr.Error())
-			}
-			resp.Diagnostics.AddError("Kubernetes cluster did not finish deleting",
-				fmt.Sprintf("cluster %d: %s", id, msg))
-			return
-		case <-time.After(k8sPollInterval):
 		}
+		resp.Diagnostics.AddError("Unable to confirm cluster deletion", client.ErrorDetail(err))
 	}
 }
 
 // ---- ImportState ----
 
 func (r *K8sClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_kubernetes_cluster", "import")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
+	if importByIdentity(req) {
+		importScopedIdentity(ctx, req, resp, r.c.Region, r.c.ProjectTag)
+		return
+	}
+
 	id, region, projectTag, err := parseK8sImportID(req.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -1188,32 +1291,48 @@ func (r *K8sClusterResource) findClusterIDByName(ctx context.Context, name strin
 
 // discoverDefaultPoolID resolves the default pool's id after create. The create
 // response carries only the cluster id, so we list the cluster's pools and match
-// the one whose name equals the configured default pool name (lowercased). On any
-// ambiguity or failure it returns 0 — the caller still has valid cluster state.
+// the one whose name equals the configured default pool name (lowercased). It
+// lists up to k8sMaxConsecutiveErrs (or the provider's poll_error_tolerance) more
+// times; on any ambiguity or failure it returns 0 — the caller still has valid
+// cluster state.
 func (r *K8sClusterResource) discoverDefaultPoolID(ctx context.Context, clusterID int64, poolName string, opts *client.RequestOpts) int64 {
 	want := strings.ToLower(poolName)
-	for attempt := 0; ; attempt++ {
-		pools, err := r.c.ListNodePools(ctx, clusterID, opts)
-		if err == nil {
+	tolerance := r.c.Retry.PollErrorToleranceOr(k8sMaxConsecutiveErrs)
+	attempts := 0
+	w := &client.Waiter[int64]{
+		Name: fmt.Sprintf("default node pool of cluster %d", clusterID),
+		Op:   "default_pool_discovered",
+		ID:   clusterID,
+		Refresh: func(ctx context.Context) (int64, string, error) {
+			attempts++
+			pools, err := r.c.ListNodePools(ctx, clusterID, opts)
+			if err != nil {
+				return 0, "", err
+			}
 			for i := range pools {
 				if strings.ToLower(pools[i].Name) == want {
-					return pools[i].ID
+					return pools[i].ID, k8sLookupFound, nil
 				}
 			}
-		} else {
-			tflog.Warn(ctx, "Could not list node pools to resolve default pool id", map[string]any{
-				"cluster_id": clusterID, "error": err.Error(), "attempt": attempt,
-			})
-		}
-		if attempt >= k8sMaxConsecutiveErrs {
-			return 0
-		}
-		select {
-		case <-ctx.Done():
-			return 0
-		case <-time.After(k8sPollInterval):
-		}
+			if attempts > tolerance {
+				return 0, k8sLookupMissing, nil
+			}
+			return 0, k8sLookupPending, nil
+		},
+		Target:               []string{k8sLookupFound},
+		Failure:              []string{k8sLookupMissing},
+		MaxConsecutiveErrors: tolerance,
+		MinTimeout:           k8sMinPollInterval,
+		MaxPollInterval:      k8sPollInterval,
+	}
+	id, err := w.Wait(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "Could not resolve the default node pool id", map[string]any{
+			"cluster_id": clusterID, "error": err.Error(),
+		})
+		return 0
 	}
+	return id
 }
 
 // clusterUpgradeConverged reports whether an in-place version upgrade has settled:
@@ -1225,25 +1344,28 @@ func clusterUpgradeConverged(cl *client.Cluster, wantVersion string) bool {
 }
 
 // getClusterWithRetry reads a cluster, tolerating up to k8sMaxConsecutiveErrs
-// transient errors so a post-mutation read-back is not derailed by a transient
-// blip. Returns the last error if every attempt fails.
+// (or the provider's poll_error_tolerance) transient errors so a post-mutation
+// read-back is not derailed by a transient blip. Returns the last error, wrapped,
+// if every attempt fails.
 func (r *K8sClusterResource) getClusterWithRetry(ctx context.Context, id int64, opts *client.RequestOpts) (*client.Cluster, error) {
-	var lastErr error
-	for attempt := 0; ; attempt++ {
-		cl, err := r.c.GetCluster(ctx, id, opts)
-		if err == nil {
-			return cl, nil
-		}
-		lastErr = err
-		if attempt >= k8sMaxConsecutiveErrs {
-			return nil, lastErr
-		}
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-time.After(k8sPollInterval):
-		}
+	w := &client.Waiter[*client.Cluster]{
+		Name: fmt.Sprintf("cluster %d", id),
+		Op:   "cluster_read",
+		ID:   id,
+		// Any successful read ends the wait, whatever the cluster's status.
+		Refresh: func(ctx context.Context) (*client.Cluster, string, error) {
+			cl, err := r.c.GetCluster(ctx, id, opts)
+			if err != nil {
+				return nil, "", err
+			}
+			return cl, k8sLookupFound, nil
+		},
+		Target:               []string{k8sLookupFound},
+		MaxConsecutiveErrors: r.c.Retry.PollErrorToleranceOr(k8sMaxConsecutiveErrs),
+		MinTimeout:           k8sMinPollInterval,
+		MaxPollInterval:      k8sPollInterval,
 	}
+	return w.Wait(ctx)
 }
 
 // waitForClusterReady polls until the cluster reaches the desired state. In create
@@ -1253,56 +1375,50 @@ func (r *K8sClusterResource) getClusterWithRetry(ctx context.Context, id int64,
 // (clusterUpgradeConverged). FAIL or DELETED is a terminal error. Tolerates up to
 // k8sMaxConsecutiveErrs transient errors (ADR-K5).
 func (r *K8sClusterResource) waitForClusterReady(ctx context.Context, id int64, wantVersion string, opts *client.RequestOpts) (*client.Cluster, error) {
-	var consecutiveErrs int
-	var last *client.Cluster
 	var kubeconfigDeadline time.Time
-
-	for {
-		cl, err := r.c.GetCluster(ctx, id, opts)
-		switch {
-		case err == nil:
-			consecutiveErrs = 0
-			last = cl
-			tflog.Debug(ctx, "Polling cluster", map[string]any{"id": id, "status": cl.Status})
-			switch cl.Status {
-			case client.ClusterStatusSuccess:
-				// Converged means create mode -> SUCCESS, or upgrade mode -> SUCCESS on
-				// the requested version with no operation still in flight. Until then,
-				// keep polling rather than return a stale pre-upgrade snapshot.
-				if wantVersion == "" || clusterUpgradeConverged(cl, wantVersion) {
-					if cl.Kubeconfig != "" {
-						return cl, nil
-					}
-					// SUCCESS but the kubeconfig (and private key) are fetched lazily
-					// server-side (G5) and can lag — also right after an upgrade rewrites
-					// them. Give it a bounded grace, then accept SUCCESS rather than burn
-					// the timeout (ADR-K3). The caller warns when it is still empty.
-					if kubeconfigDeadline.IsZero() {
-						kubeconfigDeadline = time.Now().Add(k8sKubeconfigGrace)
-					} else if !time.Now().Before(kubeconfigDeadline) {
-						return cl, nil
-					}
-				}
-			case client.ClusterStatusFail, client.ClusterStatusDeleted:
-				return cl, fmt.Errorf("terminal status %s", cl.Status)
+	w := &client.Waiter[*client.Cluster]{
+		Name:    fmt.Sprintf("cluster %d", id),
+		Op:      "cluster_ready",
+		ID:      id,
+		Refresh: r.clusterStatusRefresh(id, opts),
+		Target:  []string{client.ClusterStatusSuccess},
+		Failure: []string{client.ClusterStatusFail, client.ClusterStatusDeleted},
+		// Converged means create mode -> SUCCESS, or upgrade mode -> SUCCESS on
+		// the requested version with no operation still in flight. Until then,
+		// keep polling rather than return a stale pre-upgrade snapshot.
+		Ready: func(cl *client.Cluster, _ string) bool {
+			if wantVersion != "" && !clusterUpgradeConverged(cl, wantVersion) {
+				return false
 			}
-		case client.IsKuberNotFound(err):
-			return last, fmt.Errorf("cluster %d disappeared while waiting", id)
-		default:
-			consecutiveErrs++
-			tflog.Warn(ctx, "Transient error polling cluster", map[string]any{
-				"id": id, "error": err.Error(), "consecutive_errors": consecutiveErrs,
-			})
-			if consecutiveErrs > k8sMaxConsecutiveErrs {
-				return last, fmt.Errorf("polling failed after %d consecutive errors: %w", consecutiveErrs, err)
+			if cl.Kubeconfig != "" {
+				return true
 			}
-		}
+			// SUCCESS but the kubeconfig (and private key) are fetched lazily
+			// server-side (G5) and can lag — also right after an upgrade rewrites
+			// them. Give it a bounded grace, then accept SUCCESS rather than burn
+			// the timeout (ADR-K3). The caller warns when it is still empty.
+			if kubeconfigDeadline.IsZero() {
+				kubeconfigDeadline = time.Now().Add(k8sKubeconfigGrace)
+				return false
+			}
+			return !time.Now().Before(kubeconfigDeadline)
+		},
+		NotFound:             client.IsKuberNotFound,
+		MaxConsecutiveErrors: r.c.Retry.PollErrorToleranceOr(k8sMaxConsecutiveErrs),
+		MinTimeout:           k8sMinPollInterval,
+		MaxPollInterval:      k8sPollInterval,
+	}
+	return w.Wait(ctx)
+}
 
-		select {
-		case <-ctx.Done():
-			return last, ctx.Err()
-		case <-time.After(k8sPollInterval):
+// clusterStatusRefresh reads a cluster and reports its status, for the waits.
+func (r *K8sClusterResource) clusterStatusRefresh(id int64, opts *client.RequestOpts) func(context.Context) (*client.Cluster, string, error) {
+	return func(ctx context.Context) (*client.Cluster, string, error) {
+		cl, err := r.c.GetCluster(ctx, id, opts)
+		if err != nil {
+			return nil, "", err
 		}
+		return cl, cl.Status, nil
 	}
 }
 
@@ -1313,30 +1429,32 @@ func (r *K8sClusterResource) waitForClusterReady(ctx context.Context, id int64,
 // the wait edge-correct regardless of whether the backend has flipped to
 // PROCESSING yet. Tolerates up to k8sMaxConsecutiveErrs transient errors (ADR-K5).
 func (r *K8sClusterResource) waitForPoolReady(ctx context.Context, poolID int64, want *K8sDefaultPoolModel, opts *client.RequestOpts) error {
-	var consecutiveErrs int
-	for {
-		pool, err := r.c.GetNodePool(ctx, poolID, opts)
-		switch {
-		case err == nil:
-			consecutiveErrs = 0
-			tflog.Debug(ctx, "Polling node pool", map[string]any{"id": poolID, "status": pool.Status})
-			if pool.Status == client.ClusterStatusSuccess && poolMatchesDesired(pool, want) {
-				return nil
-			}
-		case client.IsKuberNotFound(err):
-			return fmt.Errorf("node pool %d disappeared while waiting", poolID)
-		default:
-			consecutiveErrs++
-			if consecutiveErrs > k8sMaxConsecutiveErrs {
-				return fmt.Errorf("polling failed after %d consecutive errors: %w", consecutiveErrs, err)
-			}
-		}
+	w := &client.Waiter[*client.NodePool]{
+		Name:    fmt.Sprintf("node pool %d", poolID),
+		Op:      "node_pool_ready",
+		ID:      poolID,
+		Refresh: nodePoolStatusRefresh(r.c, poolID, opts),
+		Target:  []string{client.ClusterStatusSuccess},
+		Ready: func(pool *client.NodePool, _ string) bool {
+			return poolMatchesDesired(pool, want)
+		},
+		NotFound:             client.IsKuberNotFound,
+		MaxConsecutiveErrors: r.c.Retry.PollErrorToleranceOr(k8sMaxConsecutiveErrs),
+		MinTimeout:           k8sMinPollInterval,
+		MaxPollInterval:      k8sPollInterval,
+	}
+	_, err := w.Wait(ctx)
+	return err
+}
 
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(k8sPollInterval):
+// nodePoolStatusRefresh reads a node pool and reports its status, for the waits.
+func nodePoolStatusRefresh(c *client.Client, poolID int64, opts *client.RequestOpts) func(context.Context) (*client.NodePool, string, error) {
+	return func(ctx context.Context) (*client.NodePool, string, error) {
+		pool, err := c.GetNodePool(ctx, poolID, opts)
+		if err != nil {
+			return nil, "", err
 		}
+		return pool, pool.Status, nil
 	}
 }
 
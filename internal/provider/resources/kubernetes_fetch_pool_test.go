@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"terraform-provider-prodata/internal/client"
 )
@@ -83,3 +84,53 @@ func TestFetchPool_GoneVsInconclusive(t *testing.T) {
 		})
 	}
 }
+
+// TestResolveNewPoolID covers the id-set diff Create uses to recover the id of a
+// just-created pool (ADR-K6): a pool that existed before the create is never
+// adopted, and two new pools with the wanted name are an error, not a guess.
+func TestResolveNewPoolID(t *testing.T) {
+	const list = `{"error":0,"errMessage":"","data":[` +
+		`{"id":1,"poolName":"workers","status":{"name":"SUCCESS"}},` +
+		`{"id":2,"poolName":"other","status":{"name":"SUCCESS"}},` +
+		`{"id":3,"poolName":"workers","status":{"name":"PROCESSING"}}]}`
+
+	tests := []struct {
+		name      string
+		beforeIDs map[int64]bool
+		wantID    int64
+		wantErr   string
+	}{
+		{"new pool found by name", map[int64]bool{1: true}, 3, ""},
+		{"unrelated new pool is ignored", map[int64]bool{1: true, 3: true}, 0, ""},
+		{"two new pools with the name", map[int64]bool{}, 0, "ambiguous node pool discovery"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newKuberTestClient(t, kuberResponder(http.StatusOK, "", http.StatusOK, list))
+			r := &K8sNodePoolResource{c: c}
+			ctx := context.Background()
+			if tc.wantID == 0 && tc.wantErr == "" {
+				// Nothing new ever appears: rather than sit through the discovery
+				// retries, check that the wait is still polling when ctx ends.
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+				defer cancel()
+			}
+			pool, err := r.resolveNewPoolID(ctx, 99, "workers", tc.beforeIDs, nil)
+			switch {
+			case tc.wantErr != "":
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tc.wantErr)
+				}
+			case tc.wantID == 0:
+				if err == nil || pool != nil {
+					t.Fatalf("adopted pool %+v (err %v), want none", pool, err)
+				}
+			case err != nil:
+				t.Fatal(err)
+			case pool.ID != tc.wantID:
+				t.Fatalf("pool id = %d, want %d", pool.ID, tc.wantID)
+			}
+		})
+	}
+}
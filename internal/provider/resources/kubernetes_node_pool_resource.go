@@ -2,16 +2,19 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -19,6 +22,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -29,6 +33,7 @@ var (
 	_ resource.ResourceWithModifyPlan     = &K8sNodePoolResource{}
 	_ resource.ResourceWithImportState    = &K8sNodePoolResource{}
 	_ resource.ResourceWithValidateConfig = &K8sNodePoolResource{}
+	_ resource.ResourceWithIdentity       = &K8sNodePoolResource{}
 )
 
 // K8sNodePoolResource implements prodata_kubernetes_node_pool — an additional
@@ -199,6 +204,13 @@ func (r *K8sNodePoolResource) Schema(ctx context.Context, _ resource.SchemaReque
 	}
 }
 
+func (r *K8sNodePoolResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = compositeIdentitySchema(map[string]string{
+		"id":         "ID of the node pool.",
+		"cluster_id": "ID of the cluster the node pool belongs to.",
+	})
+}
+
 func (r *K8sNodePoolResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -314,6 +326,9 @@ func nodePoolChanged(state, plan *K8sNodePoolModel) bool {
 // ---- Create ----
 
 func (r *K8sNodePoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_kubernetes_node_pool", "create")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
 	var plan K8sNodePoolModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -324,7 +339,7 @@ func (r *K8sNodePoolResource) Create(ctx context.Context, req resource.CreateReq
 	opts := &client.RequestOpts{Region: region, ProjectTag: projectTag}
 	clusterID := plan.ClusterID.ValueInt64()
 
-	createTimeout, diags := plan.Timeouts.Create(ctx, k8sPoolDefaultCreateTime)
+	createTimeout, diags := plan.Timeouts.Create(ctx, timeoutOr(r.c.DefaultTimeouts.Create, k8sPoolDefaultCreateTime))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -338,7 +353,7 @@ func (r *K8sNodePoolResource) Create(ctx context.Context, req resource.CreateReq
 	unlock := lockCluster(clusterID)
 	defer unlock()
 	if err := r.ensureClusterMutable(ctx, clusterID, opts); err != nil {
-		resp.Diagnostics.AddError("Cluster is not in a modifiable state", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Cluster is not in a modifiable state", client.ErrorDetail(err))
 		return
 	}
 
@@ -348,7 +363,7 @@ func (r *K8sNodePoolResource) Create(ctx context.Context, req resource.CreateReq
 	// "import it" message instead of a generic conflict.
 	before, err := r.c.ListNodePools(ctx, clusterID, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to verify node pool name availability", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to verify node pool name availability", client.ErrorDetail(err))
 		return
 	}
 	want := strings.ToLower(plan.Name.ValueString())
@@ -389,10 +404,10 @@ func (r *K8sNodePoolResource) Create(ctx context.Context, req resource.CreateReq
 	// RetryOnBusy covers transient 503 (capacity); it deliberately does not retry
 	// 627. createNewNodePool returns no id, so success is signalled only by a nil
 	// error; the id is recovered below via an id-set diff.
-	if _, err := client.RetryOnBusy(ctx, client.RetryTimeoutLong, func() (struct{}, error) {
+	if _, err := client.RetryOnBusy(ctx, r.c.Retry.BusyTimeoutOr(client.RetryTimeoutLong), func() (struct{}, error) {
 		return struct{}{}, r.c.CreateNodePool(ctx, wire, opts)
 	}); err != nil {
-		resp.Diagnostics.AddError("Unable to create node pool", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to create node pool", client.ErrorDetail(err))
 		return
 	}
 
@@ -402,7 +417,7 @@ func (r *K8sNodePoolResource) Create(ctx context.Context, req resource.CreateReq
 	// it a read-back miss would persist unknown Computed values.
 	discovered, err := r.resolveNewPoolID(ctx, clusterID, want, beforeIDs, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to resolve the created node pool", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to resolve the created node pool", client.ErrorDetail(err))
 		return
 	}
 	poolID := discovered.ID
@@ -413,6 +428,7 @@ func (r *K8sNodePoolResource) Create(ctx context.Context, req resource.CreateReq
 	plan.Region = types.StringValue(region)
 	plan.ProjectTag = types.StringValue(projectTag)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setNodePoolIdentity(ctx, resp.Identity, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -445,6 +461,11 @@ func (r *K8sNodePoolResource) Create(ctx context.Context, req resource.CreateReq
 // ---- Read ----
 
 func (r *K8sNodePoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_kubernetes_node_pool", "read")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.c.DefaultTimeouts.Read)
+	defer cancel()
+
 	var data K8sNodePoolModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -461,7 +482,7 @@ func (r *K8sNodePoolResource) Read(ctx context.Context, req resource.ReadRequest
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Unable to read node pool", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to read node pool", client.ErrorDetail(err))
 		return
 	}
 	// Pools hard-delete (no sticky DELETED status, unlike clusters), but guard
@@ -476,11 +497,15 @@ func (r *K8sNodePoolResource) Read(ctx context.Context, req resource.ReadRequest
 	projectTag := valueOrDefault(data.ProjectTag, r.c.ProjectTag)
 	r.applyServerState(&data, pool, region, projectTag, true)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setNodePoolIdentity(ctx, resp.Identity, &data)...)
 }
 
 // ---- Update ----
 
 func (r *K8sNodePoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_kubernetes_node_pool", "update")
+	defer op.End(&req.State, &resp.Diagnostics)
+
 	var state, plan K8sNodePoolModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -492,7 +517,7 @@ func (r *K8sNodePoolResource) Update(ctx context.Context, req resource.UpdateReq
 	clusterID := state.ClusterID.ValueInt64()
 	poolID := state.ID.ValueInt64()
 
-	updateTimeout, diags := plan.Timeouts.Update(ctx, k8sPoolDefaultUpdateTime)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, timeoutOr(r.c.DefaultTimeouts.Update, k8sPoolDefaultUpdateTime))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -505,13 +530,13 @@ func (r *K8sNodePoolResource) Update(ctx context.Context, req resource.UpdateReq
 	unlock := lockCluster(clusterID)
 	defer unlock()
 	if err := r.ensureClusterMutable(ctx, clusterID, opts); err != nil {
-		resp.Diagnostics.AddError("Cluster is not in a modifiable state", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Cluster is not in a modifiable state", client.ErrorDetail(err))
 		return
 	}
 
 	changed, err := r.reconcilePool(ctx, clusterID, poolID, &state, &plan, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to update node pool", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to update node pool", client.ErrorDetail(err))
 		return
 	}
 	var converged *client.NodePool
@@ -545,6 +570,7 @@ func (r *K8sNodePoolResource) Update(ctx context.Context, req resource.UpdateReq
 		plan.NodeCount = state.NodeCount
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setNodePoolIdentity(ctx, resp.Identity, &plan)...)
 }
 
 // reconcilePool applies node_count / autoscaling changes, choosing the right
@@ -601,6 +627,9 @@ func (r *K8sNodePoolResource) reconcilePool(ctx context.Context, clusterID, pool
 // ---- Delete ----
 
 func (r *K8sNodePoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_kubernetes_node_pool", "delete")
+	defer op.End(&req.State, &resp.Diagnostics)
+
 	var data K8sNodePoolModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -611,7 +640,7 @@ func (r *K8sNodePoolResource) Delete(ctx context.Context, req resource.DeleteReq
 	clusterID := data.ClusterID.ValueInt64()
 	id := data.ID.ValueInt64()
 
-	deleteTimeout, diags := data.Timeouts.Delete(ctx, k8sPoolDefaultDeleteTime)
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, timeoutOr(r.c.DefaultTimeouts.Delete, k8sPoolDefaultDeleteTime))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -626,7 +655,7 @@ func (r *K8sNodePoolResource) Delete(ctx context.Context, req resource.DeleteReq
 	// race a cluster mid-roll. Unlike Create/Update we do NOT refuse a FAILed cluster
 	// — a pool must stay deletable while tearing down a broken one.
 	if err := r.waitClusterDeletable(ctx, clusterID, opts); err != nil {
-		resp.Diagnostics.AddError("Cluster is not in a modifiable state", client.KuberErrorDetail(err))
+		resp.Diagnostics.AddError("Cluster is not in a modifiable state", client.ErrorDetail(err))
 		return
 	}
 
@@ -635,53 +664,49 @@ func (r *K8sNodePoolResource) Delete(ctx context.Context, req resource.DeleteReq
 			return
 		}
 		// 756 (last worker pool) and the "Cannot delete master node pool" guard both
-		// surface here; KuberErrorDetail maps 756 to a clear message and others fall
-		// through to the backend text. The pool still exists, so do not remove state.
-		resp.Diagnostics.AddError("Unable to delete node pool", client.KuberErrorDetail(err))
+		// surface here; client.ErrorDetail renders both from the error catalogue
+		// (errorCatalog / errorMessageCatalog) and falls through to the backend text
+		// for anything else. The pool still exists, so do not remove state.
+		resp.Diagnostics.AddError("Unable to delete node pool", client.ErrorDetail(err))
 		return
 	}
 
 	// Confirm the pool is gone. deleteNodePool hard-deletes the row, so the pool
 	// disappears from the API (not-found); tolerate a few transient errors, then
 	// surface the real one instead of spinning to the timeout (ADR-K5).
-	var consecutiveErrs int
-	var lastErr error
-	for {
-		pool, err := r.c.GetNodePool(ctx, id, opts)
-		switch {
-		case err == nil:
-			consecutiveErrs = 0
-			if pool.Status == client.ClusterStatusDeleted {
-				return
-			}
-		case client.IsKuberNotFound(err):
+	w := &client.Waiter[*client.NodePool]{
+		Name:                 fmt.Sprintf("node pool %d", id),
+		Op:                   "node_pool_deleted",
+		ID:                   id,
+		Refresh:              nodePoolStatusRefresh(r.c, id, opts),
+		Target:               []string{client.ClusterStatusDeleted},
+		NotFound:             client.IsKuberNotFound,
+		TargetNotFound:       true,
+		MaxConsecutiveErrors: r.c.Retry.PollErrorToleranceOr(k8sMaxConsecutiveErrs),
+		MinTimeout:           k8sMinPollInterval,
+		MaxPollInterval:      k8sPollInterval,
+	}
+	if _, err := w.Wait(ctx); err != nil {
+		var timeoutErr *client.WaitTimeoutError
+		if errors.As(err, &timeoutErr) {
+			resp.Diagnostics.AddError("Node pool did not finish deleting", err.Error())
 			return
-		default:
-			consecutiveErrs++
-			lastErr = err
-			if consecutiveErrs > k8sMaxConsecutiveErrs {
-				resp.Diagnostics.AddError("Unable to confirm node pool deletion",
-					fmt.Sprintf("node pool %d: %s", id, client.KuberErrorDetail(err)))
-				return
-			}
-		}
-		select {
-		case <-ctx.Done():
-			msg := ctx.Err().Error()
-			if lastErr != nil {
-				msg = fmt.Sprintf("%s (last error: %s)", msg, lastErr.Error())
-			}
-			resp.Diagnostics.AddError("Node pool did not finish deleting",
-				fmt.Sprintf("node pool %d: %s", id, msg))
-			return
-		case <-time.After(k8sPollInterval):
 		}
+		resp.Diagnostics.AddError("Unable to confirm node pool deletion", client.ErrorDetail(err))
 	}
 }
 
 // ---- ImportState ----
 
 func (r *K8sNodePoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_kubernetes_node_pool", "import")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
+	if importByIdentity(req) {
+		importIdentity(ctx, req, resp, r.c.Region, r.c.ProjectTag, "id", "cluster_id")
+		return
+	}
+
 	clusterID, poolID, region, projectTag, err := parseK8sPoolImportID(req.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -709,6 +734,17 @@ func (r *K8sNodePoolResource) ImportState(ctx context.Context, req resource.Impo
 
 // ---- helpers ----
 
+// setNodePoolIdentity records m's pool and cluster ids as the resource identity.
+// m's region and project_tag are always resolved by the time it is saved.
+func setNodePoolIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, m *K8sNodePoolModel) diag.Diagnostics {
+	return setIdentity(ctx, identity, nodePoolIdentityModel{
+		ID:         m.ID,
+		ClusterID:  m.ClusterID,
+		Region:     m.Region,
+		ProjectTag: m.ProjectTag,
+	})
+}
+
 func (r *K8sNodePoolResource) resolveScope(region, projectTag types.String) (string, string) {
 	rg := region.ValueString()
 	if rg == "" {
@@ -737,24 +773,7 @@ func (r *K8sNodePoolResource) optsFromState(region, projectTag types.String) *cl
 // until the cluster is unblocked or the context deadline hits. Mirrors the cluster
 // resource's ensureMutable; a pool op must wait on its parent cluster's state.
 func (r *K8sNodePoolResource) ensureClusterMutable(ctx context.Context, clusterID int64, opts *client.RequestOpts) error {
-	for {
-		cl, err := r.c.GetCluster(ctx, clusterID, opts)
-		if err != nil {
-			return err
-		}
-		if cl.Status == client.ClusterStatusFail {
-			return fmt.Errorf("cluster %d is in FAIL state and cannot be modified; inspect it in the panel and recreate", clusterID)
-		}
-		if !cl.Blocked {
-			return nil
-		}
-		tflog.Debug(ctx, "Cluster is blocked by an in-flight operation, waiting", map[string]any{"id": clusterID})
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("timed out waiting for cluster %d to become unblocked: %w", clusterID, ctx.Err())
-		case <-time.After(k8sPollInterval):
-		}
-	}
+	return waitClusterUnblocked(ctx, r.c, clusterID, opts, false)
 }
 
 // waitClusterDeletable waits out an in-flight (blocked) parent cluster before a pool
@@ -763,24 +782,7 @@ func (r *K8sNodePoolResource) ensureClusterMutable(ctx context.Context, clusterI
 // cluster stays blocked until MR-D, so waiting it out would otherwise hang). A
 // vanished parent means the pool is gone too, so it lets the delete proceed.
 func (r *K8sNodePoolResource) waitClusterDeletable(ctx context.Context, clusterID int64, opts *client.RequestOpts) error {
-	for {
-		cl, err := r.c.GetCluster(ctx, clusterID, opts)
-		if err != nil {
-			if client.IsKuberNotFound(err) {
-				return nil
-			}
-			return err
-		}
-		if cl.Status == client.ClusterStatusFail || !cl.Blocked {
-			return nil
-		}
-		tflog.Debug(ctx, "Cluster is blocked by an in-flight operation, waiting before pool delete", map[string]any{"id": clusterID})
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("timed out waiting for cluster %d to become unblocked: %w", clusterID, ctx.Err())
-		case <-time.After(k8sPollInterval):
-		}
-	}
+	return waitClusterUnblocked(ctx, r.c, clusterID, opts, true)
 }
 
 // resolveNewPoolID recovers the id of the pool just created in clusterID, given the
@@ -790,18 +792,16 @@ func (r *K8sNodePoolResource) waitClusterDeletable(ctx context.Context, clusterI
 // synchronously, so the pool is normally present on the first attempt; a few bounded
 // retries cover replica lag.
 func (r *K8sNodePoolResource) resolveNewPoolID(ctx context.Context, clusterID int64, wantName string, beforeIDs map[int64]bool, opts *client.RequestOpts) (*client.NodePool, error) {
-	var consecutiveErrs, emptyAttempts int
-	for {
-		pools, err := r.c.ListNodePools(ctx, clusterID, opts)
-		if err != nil {
-			// The create already succeeded; tolerate a few transient list errors
-			// rather than aborting and orphaning the pool (ADR-K5).
-			consecutiveErrs++
-			if consecutiveErrs > k8sMaxConsecutiveErrs {
-				return nil, fmt.Errorf("could not list node pools to resolve the created pool: %w", err)
+	emptyAttempts := 0
+	w := &client.Waiter[[]*client.NodePool]{
+		Name: fmt.Sprintf("node pool %q in cluster %d", wantName, clusterID),
+		Op:   "node_pool_discovered",
+		ID:   clusterID,
+		Refresh: func(ctx context.Context) ([]*client.NodePool, string, error) {
+			pools, err := r.c.ListNodePools(ctx, clusterID, opts)
+			if err != nil {
+				return nil, "", err
 			}
-		} else {
-			consecutiveErrs = 0
 			// Match strictly by name (the backend lowercases names and G9 enforces
 			// per-cluster uniqueness); never adopt an unrelated new pool on a name
 			// mismatch, which could bind state to a pool created out-of-band.
@@ -816,23 +816,37 @@ func (r *K8sNodePoolResource) resolveNewPoolID(ctx context.Context, clusterID in
 			}
 			switch {
 			case len(nameMatches) == 1:
-				return nameMatches[0], nil
+				return nameMatches, k8sLookupFound, nil
 			case len(nameMatches) > 1:
-				return nil, fmt.Errorf("ambiguous node pool discovery: %d new pools named %q in cluster %d",
-					len(nameMatches), wantName, clusterID)
+				return nameMatches, k8sLookupAmbiguous, nil
 			}
 			emptyAttempts++
 			if emptyAttempts >= k8sPoolDiscoveryAttempts {
-				return nil, fmt.Errorf("could not resolve the id of node pool %q in cluster %d after creation; "+
-					"run `terraform import` once it appears in the panel", wantName, clusterID)
+				return nil, k8sLookupMissing, nil
 			}
-		}
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-time.After(k8sPollInterval):
-		}
-	}
+			return nil, k8sLookupPending, nil
+		},
+		Target:  []string{k8sLookupFound},
+		Failure: []string{k8sLookupAmbiguous, k8sLookupMissing},
+		// The create already succeeded; tolerate a few transient list errors
+		// rather than aborting and orphaning the pool (ADR-K5).
+		MaxConsecutiveErrors: r.c.Retry.PollErrorToleranceOr(k8sMaxConsecutiveErrs),
+		MinTimeout:           k8sMinPollInterval,
+		MaxPollInterval:      k8sPollInterval,
+	}
+	matches, err := w.Wait(ctx)
+	var stateErr *client.UnexpectedStateError
+	switch {
+	case errors.As(err, &stateErr) && stateErr.State == k8sLookupAmbiguous:
+		return nil, fmt.Errorf("ambiguous node pool discovery: %d new pools named %q in cluster %d",
+			len(matches), wantName, clusterID)
+	case errors.As(err, &stateErr):
+		return nil, fmt.Errorf("could not resolve the id of node pool %q in cluster %d after creation; "+
+			"run `terraform import` once it appears in the panel", wantName, clusterID)
+	case err != nil:
+		return nil, fmt.Errorf("could not list node pools to resolve the created pool: %w", err)
+	}
+	return matches[0], nil
 }
 
 // waitForPoolReady polls a node pool until it has CONVERGED to the requested shape:
@@ -843,33 +857,21 @@ func (r *K8sNodePoolResource) resolveNewPoolID(ctx context.Context, clusterID in
 // branch (a vanished pool is reported as not-found). Mirrors the cluster resource's
 // waitForPoolReady for the standalone model. ADR-K5.
 func (r *K8sNodePoolResource) waitForPoolReady(ctx context.Context, poolID int64, want *K8sNodePoolModel, opts *client.RequestOpts) (*client.NodePool, error) {
-	var consecutiveErrs int
-	var last *client.NodePool
-	for {
-		pool, err := r.c.GetNodePool(ctx, poolID, opts)
-		switch {
-		case err == nil:
-			consecutiveErrs = 0
-			last = pool
-			tflog.Debug(ctx, "Polling node pool", map[string]any{"id": poolID, "status": pool.Status})
-			if pool.Status == client.ClusterStatusSuccess && nodePoolMatchesDesired(pool, want) {
-				return pool, nil
-			}
-		case client.IsKuberNotFound(err):
-			return last, fmt.Errorf("node pool %d disappeared while waiting", poolID)
-		default:
-			consecutiveErrs++
-			if consecutiveErrs > k8sMaxConsecutiveErrs {
-				return last, fmt.Errorf("polling failed after %d consecutive errors: %w", consecutiveErrs, err)
-			}
-		}
-
-		select {
-		case <-ctx.Done():
-			return last, ctx.Err()
-		case <-time.After(k8sPollInterval):
-		}
+	w := &client.Waiter[*client.NodePool]{
+		Name:    fmt.Sprintf("node pool %d", poolID),
+		Op:      "node_pool_ready",
+		ID:      poolID,
+		Refresh: nodePoolStatusRefresh(r.c, poolID, opts),
+		Target:  []string{client.ClusterStatusSuccess},
+		Ready: func(pool *client.NodePool, _ string) bool {
+			return nodePoolMatchesDesired(pool, want)
+		},
+		NotFound:             client.IsKuberNotFound,
+		MaxConsecutiveErrors: r.c.Retry.PollErrorToleranceOr(k8sMaxConsecutiveErrs),
+		MinTimeout:           k8sMinPollInterval,
+		MaxPollInterval:      k8sPollInterval,
 	}
+	return w.Wait(ctx)
 }
 
 // nodePoolMatchesDesired reports whether a live pool reflects the requested shape.
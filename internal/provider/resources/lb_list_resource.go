@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"context"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ list.ListResource              = &LbListResource{}
+	_ list.ListResourceWithConfigure = &LbListResource{}
+)
+
+// LbListResource lists the load balancers in a project, skipping any the panel
+// still reports as DELETED. A CCM load balancer's node_pool_id is not reported
+// and is left null, as after an import.
+type LbListResource struct {
+	listBase
+}
+
+func NewLbListResource() list.ListResource {
+	return &LbListResource{}
+}
+
+func (l *LbListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lb"
+}
+
+func (l *LbListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listScopeSchema("load balancers")
+}
+
+func (l *LbListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_lb", "list")
+	var diags diag.Diagnostics
+	defer op.End(nil, &diags)
+
+	region, projectTag := l.scope(ctx, req.Config, &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	lbs, err := l.c.ListLoadBalancers(ctx, &client.RequestOpts{Region: region, ProjectTag: projectTag})
+	if err != nil {
+		diags.AddError("Unable to list load balancers", client.ErrorDetail(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	tflog.Debug(ctx, "Listed load balancers", map[string]any{"count": len(lbs), "region": region, "project_tag": projectTag})
+
+	r := &LbResource{c: l.c}
+	items := make([]listItem, 0, len(lbs))
+	for i := range lbs {
+		lb := &lbs[i]
+		if lb.Status == client.LbStatusDeleted {
+			continue
+		}
+		items = append(items, listItem{
+			displayName: lb.Name,
+			identity: scopedIdentityModel{
+				ID:         types.Int64Value(lb.ID),
+				Region:     types.StringValue(region),
+				ProjectTag: types.StringValue(projectTag),
+			},
+			resource: func(context.Context, *diag.Diagnostics) any {
+				m := LbResourceModel{DeletionProtection: types.BoolValue(false)}
+				r.applyServerState(&m, lb, region, projectTag)
+				return &m
+			},
+		})
+	}
+	stream.Results = streamListItems(ctx, req, items)
+}
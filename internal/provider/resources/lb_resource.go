@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
 	"terraform-provider-prodata/internal/tfutil"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
@@ -36,6 +37,7 @@ var (
 	_ resource.ResourceWithModifyPlan       = &LbResource{}
 	_ resource.ResourceWithImportState      = &LbResource{}
 	_ resource.ResourceWithConfigValidators = &LbResource{}
+	_ resource.ResourceWithIdentity         = &LbResource{}
 )
 
 // LbResource implements the prodata_lb resource.
@@ -47,22 +49,23 @@ type LbResource struct {
 // framework can distinguish "config block omitted" from "block present with all
 // fields null" — both reach this struct as nil after Get().
 type LbResourceModel struct {
-	ID           types.Int64          `tfsdk:"id"`
-	Region       types.String         `tfsdk:"region"`
-	ProjectTag   types.String         `tfsdk:"project_tag"`
-	Name         types.String         `tfsdk:"name"`
-	Description  types.String         `tfsdk:"description"`
-	Type         types.String         `tfsdk:"type"`
-	Protocol     types.String         `tfsdk:"protocol"`
-	NetworkID    types.Int64          `tfsdk:"network_id"`
-	Port         []LbPortModel        `tfsdk:"port"`
-	BackendGroup *LbBackendGroupModel `tfsdk:"backend_group"`
-	Source       types.String         `tfsdk:"source"`
-	Status       types.String         `tfsdk:"status"`
-	PublicIP     types.String         `tfsdk:"public_ip"`
-	PrivateIP    types.String         `tfsdk:"private_ip"`
-	DateCreated  types.String         `tfsdk:"date_created"`
-	Timeouts     timeouts.Value       `tfsdk:"timeouts"`
+	ID                 types.Int64          `tfsdk:"id"`
+	Region             types.String         `tfsdk:"region"`
+	ProjectTag         types.String         `tfsdk:"project_tag"`
+	Name               types.String         `tfsdk:"name"`
+	Description        types.String         `tfsdk:"description"`
+	Type               types.String         `tfsdk:"type"`
+	Protocol           types.String         `tfsdk:"protocol"`
+	NetworkID          types.Int64          `tfsdk:"network_id"`
+	Port               []LbPortModel        `tfsdk:"port"`
+	BackendGroup       *LbBackendGroupModel `tfsdk:"backend_group"`
+	Source             types.String         `tfsdk:"source"`
+	Status             types.String         `tfsdk:"status"`
+	PublicIP           types.String         `tfsdk:"public_ip"`
+	PrivateIP          types.String         `tfsdk:"private_ip"`
+	DateCreated        types.String         `tfsdk:"date_created"`
+	DeletionProtection types.Bool           `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value       `tfsdk:"timeouts"`
 }
 
 type LbPortModel struct {
@@ -75,9 +78,10 @@ type LbBackendGroupModel struct {
 	NodePoolID types.Int64 `tfsdk:"node_pool_id"`
 }
 
-// Default polling and timeout values. Polling is fixed 30s; create/update/delete
-// timeouts can be overridden via the `timeouts` block.
+// Default polling and timeout values. Polling starts at 5s and backs off to
+// 30s; create/update/delete timeouts can be overridden via the `timeouts` block.
 const (
+	lbMinPollInterval    = 5 * time.Second
 	lbPollInterval       = 30 * time.Second
 	lbDefaultCreateTime  = 30 * time.Minute
 	lbDefaultUpdateTime  = 30 * time.Minute
@@ -267,6 +271,7 @@ func (r *LbResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute("load balancer"),
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
 				Update: true,
@@ -276,6 +281,10 @@ func (r *LbResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp
 	}
 }
 
+func (r *LbResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = scopedIdentitySchema("load balancer")
+}
+
 func (r *LbResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -310,9 +319,13 @@ func (r *LbResource) ConfigValidators(_ context.Context) []resource.ConfigValida
 //     both create and update so the constraint surfaces at plan time rather than
 //     silently. (Description is Computed, so omitting it in HCL is valid; the
 //     panel value reads back into state.)
+//   - CCM support: a node pool backend needs a panel-main deploy with CCM load
+//     balancers; refuse it at plan time when the panel reports it lacks them.
 //   - Update: mode-switches (vm_ids <-> node_pool_id) mark backend_group as
 //     requires-replace. Same-mode content changes pass through to Update.
 func (r *LbResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer checkDeletionProtection(ctx, "prodata_lb", req, resp)
+
 	if req.Plan.Raw.IsNull() {
 		return
 	}
@@ -343,6 +356,12 @@ func (r *LbResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequ
 	}
 
 	if req.State.Raw.IsNull() {
+		if hasPool {
+			requireFeature(r.c, client.FeatureCCMLoadBalancer, path.Root("backend_group").AtName("node_pool_id"),
+				"A node pool backend",
+				"balance the cluster's worker VMs through backend_group.vm_ids",
+				&resp.Diagnostics)
+		}
 		return
 	}
 	var state LbResourceModel
@@ -360,6 +379,9 @@ func (r *LbResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequ
 // ---- Create ----
 
 func (r *LbResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_lb", "create")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
 	var plan LbResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -369,7 +391,7 @@ func (r *LbResource) Create(ctx context.Context, req resource.CreateRequest, res
 	region, projectTag := r.resolveScope(plan.Region, plan.ProjectTag)
 	opts := &client.RequestOpts{Region: region, ProjectTag: projectTag}
 
-	createTimeout, diags := plan.Timeouts.Create(ctx, lbDefaultCreateTime)
+	createTimeout, diags := plan.Timeouts.Create(ctx, timeoutOr(r.c.DefaultTimeouts.Create, lbDefaultCreateTime))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -411,7 +433,7 @@ func (r *LbResource) Create(ctx context.Context, req resource.CreateRequest, res
 			"backends":    len(wire.Backends),
 			"ports":       len(wire.Ports),
 		})
-		lb, err = client.RetryOnBusy(ctx, client.RetryTimeoutLong, func() (*client.LoadBalancer, error) {
+		lb, err = client.RetryOnBusy(ctx, r.c.Retry.BusyTimeoutOr(client.RetryTimeoutLong), func() (*client.LoadBalancer, error) {
 			return r.c.CreateLoadBalancerFrontend(ctx, wire, opts)
 		})
 
@@ -435,7 +457,7 @@ func (r *LbResource) Create(ctx context.Context, req resource.CreateRequest, res
 			"node_pool_id": wire.NodePoolID,
 			"ports":        len(wire.Ports),
 		})
-		lb, err = client.RetryOnBusy(ctx, client.RetryTimeoutLong, func() (*client.LoadBalancer, error) {
+		lb, err = client.RetryOnBusy(ctx, r.c.Retry.BusyTimeoutOr(client.RetryTimeoutLong), func() (*client.LoadBalancer, error) {
 			return r.c.CreateLoadBalancerCCM(ctx, wire, opts)
 		})
 
@@ -445,14 +467,7 @@ func (r *LbResource) Create(ctx context.Context, req resource.CreateRequest, res
 	}
 
 	if err != nil {
-		if client.IsInsufficientFreeIPs(err) {
-			resp.Diagnostics.AddError(
-				"Insufficient free IPs in the local network",
-				fmt.Sprintf("The network needs at least three free IPs (one VIP plus two for the hidden nginx VMs). %s", err.Error()),
-			)
-			return
-		}
-		resp.Diagnostics.AddError("Unable to create load balancer", client.LBErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to create load balancer", client.ErrorDetail(err))
 		return
 	}
 
@@ -464,6 +479,7 @@ func (r *LbResource) Create(ctx context.Context, req resource.CreateRequest, res
 
 	r.applyServerState(&plan, resultLB, region, projectTag)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, plan.ID, plan.Region, plan.ProjectTag)...)
 
 	if waitErr != nil {
 		resp.Diagnostics.AddError(
@@ -477,6 +493,11 @@ func (r *LbResource) Create(ctx context.Context, req resource.CreateRequest, res
 // ---- Read ----
 
 func (r *LbResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_lb", "read")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.c.DefaultTimeouts.Read)
+	defer cancel()
+
 	var data LbResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -493,7 +514,7 @@ func (r *LbResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Unable to read load balancer", client.LBErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to read load balancer", client.ErrorDetail(err))
 		return
 	}
 	if lb.Status == client.LbStatusDeleted {
@@ -511,12 +532,20 @@ func (r *LbResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 		projectTag = r.c.ProjectTag
 	}
 	r.applyServerState(&data, lb, region, projectTag)
+	data.DeletionProtection = deletionProtectionOrDefault(data.DeletionProtection)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, data.ID, data.Region, data.ProjectTag)...)
 }
 
 // ---- Update ----
 
 func (r *LbResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_lb", "update")
+	defer op.End(&req.State, &resp.Diagnostics)
+	if updateDeletionProtectionOnly(req, resp) {
+		return
+	}
+
 	var state, plan LbResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -528,7 +557,7 @@ func (r *LbResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	id := state.ID.ValueInt64()
 	source := state.Source.ValueString()
 
-	updateTimeout, diags := plan.Timeouts.Update(ctx, lbDefaultUpdateTime)
+	updateTimeout, diags := plan.Timeouts.Update(ctx, timeoutOr(r.c.DefaultTimeouts.Update, lbDefaultUpdateTime))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -568,10 +597,10 @@ func (r *LbResource) Update(ctx context.Context, req resource.UpdateRequest, res
 			"backends": len(wire.Backends),
 			"ports":    len(wire.Ports),
 		})
-		if _, err := client.RetryOnBusy(ctx, client.RetryTimeoutLong, func() (*client.LoadBalancer, error) {
+		if _, err := client.RetryOnBusy(ctx, r.c.Retry.BusyTimeoutOr(client.RetryTimeoutLong), func() (*client.LoadBalancer, error) {
 			return r.c.ConfigureLoadBalancerFrontend(ctx, id, wire, opts)
 		}); err != nil {
-			resp.Diagnostics.AddError("Unable to update load balancer", client.LBErrorDetail(err))
+			resp.Diagnostics.AddError("Unable to update load balancer", client.ErrorDetail(err))
 			return
 		}
 	case client.LbSourceCCM:
@@ -583,10 +612,10 @@ func (r *LbResource) Update(ctx context.Context, req resource.UpdateRequest, res
 			"name":  wire.Name,
 			"ports": len(wire.Ports),
 		})
-		if _, err := client.RetryOnBusy(ctx, client.RetryTimeoutLong, func() (*client.LoadBalancer, error) {
+		if _, err := client.RetryOnBusy(ctx, r.c.Retry.BusyTimeoutOr(client.RetryTimeoutLong), func() (*client.LoadBalancer, error) {
 			return r.c.ConfigureLoadBalancerCCM(ctx, id, wire, opts)
 		}); err != nil {
-			resp.Diagnostics.AddError("Unable to update load balancer", client.LBErrorDetail(err))
+			resp.Diagnostics.AddError("Unable to update load balancer", client.ErrorDetail(err))
 			return
 		}
 	default:
@@ -619,6 +648,7 @@ func (r *LbResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		// Terraform's "computed output must be consistent" check during apply.
 		plan.DateCreated = state.DateCreated
 		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, plan.ID, plan.Region, plan.ProjectTag)...)
 	}
 	if waitErr != nil {
 		resp.Diagnostics.AddError(
@@ -632,6 +662,12 @@ func (r *LbResource) Update(ctx context.Context, req resource.UpdateRequest, res
 // ---- Delete ----
 
 func (r *LbResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_lb", "delete")
+	defer op.End(&req.State, &resp.Diagnostics)
+	if refuseProtectedDelete(ctx, "prodata_lb", req.State, &resp.Diagnostics) {
+		return
+	}
+
 	var data LbResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -642,7 +678,7 @@ func (r *LbResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 	id := data.ID.ValueInt64()
 	source := data.Source.ValueString()
 
-	deleteTimeout, diags := data.Timeouts.Delete(ctx, lbDefaultDeleteTime)
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, timeoutOr(r.c.DefaultTimeouts.Delete, lbDefaultDeleteTime))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -668,7 +704,7 @@ func (r *LbResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 		if client.IsNotFound(err) {
 			return
 		}
-		resp.Diagnostics.AddError("Unable to delete load balancer", client.LBErrorDetail(err))
+		resp.Diagnostics.AddError("Unable to delete load balancer", client.ErrorDetail(err))
 		return
 	}
 
@@ -686,6 +722,14 @@ func (r *LbResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 // ---- ImportState ----
 
 func (r *LbResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_lb", "import")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
+	if importByIdentity(req) {
+		importScopedIdentity(ctx, req, resp, r.c.Region, r.c.ProjectTag)
+		return
+	}
+
 	id, region, projectTag, err := parseLBImportID(req.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -806,94 +850,53 @@ func (r *LbResource) applyServerState(m *LbResourceModel, lb *client.LoadBalance
 	}
 }
 
-// waitForTerminalStatus polls GetLoadBalancer at lbPollInterval until the LB
-// reaches one of the terminal statuses, the context is cancelled, or the
-// resource is removed. terminalRemoved=true means IsNotFound is itself a
-// terminal success (Delete path). Returns the final LB (may be nil if removed)
-// and an error if the status is a terminal failure or the wait exhausts.
+// waitForTerminalStatus polls GetLoadBalancer, backing off up to lbPollInterval,
+// until the LB reaches one of the terminal statuses, the context is cancelled,
+// or the resource is removed. t.removedIsSuccess means IsNotFound is itself a
+// terminal success (Delete path). Returns the last LB read (may be nil) and an
+// error if the status is a terminal failure or the wait exhausts.
 func (r *LbResource) waitForTerminalStatus(ctx context.Context, id int64, opts *client.RequestOpts, t lbTerminalSet) (*client.LoadBalancer, error) {
-	var consecutiveErrs int
-	var last *client.LoadBalancer
-
-	for {
-		lb, err := r.c.GetLoadBalancer(ctx, id, opts)
-		switch {
-		case err == nil:
-			consecutiveErrs = 0
-			last = lb
-			tflog.Debug(ctx, "Polling load balancer", map[string]any{
-				"id":     id,
-				"status": lb.Status,
-			})
-			switch {
-			case t.isSuccess(lb.Status):
-				return lb, nil
-			case t.isFailure(lb.Status):
-				return lb, fmt.Errorf("terminal status %s", lb.Status)
+	w := &client.Waiter[*client.LoadBalancer]{
+		Name: fmt.Sprintf("load balancer %d", id),
+		Op:   "lb_status",
+		ID:   id,
+		Refresh: func(ctx context.Context) (*client.LoadBalancer, string, error) {
+			lb, err := r.c.GetLoadBalancer(ctx, id, opts)
+			if err != nil {
+				return nil, "", err
 			}
-		case client.IsNotFound(err):
-			if t.removedIsSuccess {
-				return nil, nil
-			}
-			return nil, err
-		default:
-			consecutiveErrs++
-			tflog.Warn(ctx, "Transient error polling load balancer", map[string]any{
-				"id":                 id,
-				"error":              err.Error(),
-				"consecutive_errors": consecutiveErrs,
-			})
-			if consecutiveErrs > lbMaxConsecutiveErrs {
-				return last, fmt.Errorf("polling failed after %d consecutive errors: %w", consecutiveErrs, err)
-			}
-		}
-
-		select {
-		case <-ctx.Done():
-			return last, ctx.Err()
-		case <-time.After(lbPollInterval):
-		}
+			return lb, lb.Status, nil
+		},
+		Target:               t.success,
+		Failure:              t.failure,
+		NotFound:             client.IsNotFound,
+		TargetNotFound:       t.removedIsSuccess,
+		MaxConsecutiveErrors: r.c.Retry.PollErrorToleranceOr(lbMaxConsecutiveErrs),
+		MinTimeout:           lbMinPollInterval,
+		MaxPollInterval:      lbPollInterval,
 	}
+	return w.Wait(ctx)
 }
 
 // lbTerminalSet describes which statuses end a polling loop and how to interpret them.
 type lbTerminalSet struct {
-	successStatuses  map[string]struct{}
-	failureStatuses  map[string]struct{}
+	success          []string
+	failure          []string
 	removedIsSuccess bool
 }
 
-func (t lbTerminalSet) isSuccess(s string) bool {
-	_, ok := t.successStatuses[s]
-	return ok
-}
-
-func (t lbTerminalSet) isFailure(s string) bool {
-	_, ok := t.failureStatuses[s]
-	return ok
-}
-
 // lbTerminalApply is the Create/Update wait set. SUCCESS = good; FAIL or DELETED
 // are terminal errors (DELETED during create/update is anomalous — scheduler aborted).
 var lbTerminalApply = lbTerminalSet{
-	successStatuses: map[string]struct{}{
-		client.LbStatusSuccess: {},
-	},
-	failureStatuses: map[string]struct{}{
-		client.LbStatusFail:    {},
-		client.LbStatusDeleted: {},
-	},
+	success: []string{client.LbStatusSuccess},
+	failure: []string{client.LbStatusFail, client.LbStatusDeleted},
 }
 
 // lbTerminalDelete is the Delete wait set. Either IsNotFound (already removed) or
 // status == DELETED is a success; FAIL is a terminal error.
 var lbTerminalDelete = lbTerminalSet{
-	successStatuses: map[string]struct{}{
-		client.LbStatusDeleted: {},
-	},
-	failureStatuses: map[string]struct{}{
-		client.LbStatusFail: {},
-	},
+	success:          []string{client.LbStatusDeleted},
+	failure:          []string{client.LbStatusFail},
 	removedIsSuccess: true,
 }
 
@@ -0,0 +1,126 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"terraform-provider-prodata/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listScopeModel is the configuration of every list resource: the region and
+// project to list. Both default to the provider's.
+type listScopeModel struct {
+	Region     types.String `tfsdk:"region"`
+	ProjectTag types.String `tfsdk:"project_tag"`
+}
+
+// listScopeSchema is the list block schema shared by every list resource; what
+// names the objects listed, in the plural.
+func listScopeSchema(what string) listschema.Schema {
+	return listschema.Schema{
+		MarkdownDescription: "Lists the " + what + " in a project, for `terraform query` and bulk import " +
+			"(`terraform query -generate-config-out`). Requires Terraform 1.14 or later.",
+		Attributes: map[string]listschema.Attribute{
+			"region": listschema.StringAttribute{
+				MarkdownDescription: "Region to list. If omitted, uses the provider default.",
+				Optional:            true,
+			},
+			"project_tag": listschema.StringAttribute{
+				MarkdownDescription: "Project tag to list. If omitted, uses the provider default.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// listBase is embedded by every list resource: it holds the client and
+// implements Configure.
+type listBase struct {
+	c *client.Client
+}
+
+func (l *listBase) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	l.c = c
+}
+
+// scope reads the list block and resolves the region and project_tag to list,
+// falling back to the provider defaults.
+func (l *listBase) scope(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) (region, projectTag string) {
+	var data listScopeModel
+	diags.Append(config.Get(ctx, &data)...)
+	return valueOrDefault(data.Region, l.c.Region), valueOrDefault(data.ProjectTag, l.c.ProjectTag)
+}
+
+// listItem is one object found by a list resource. resource builds the full
+// resource model and is only called when Terraform asks for it (config
+// generation, or include_resource in a list block), since for some resources it
+// costs further API calls.
+type listItem struct {
+	displayName string
+	identity    any
+	resource    func(context.Context, *diag.Diagnostics) any
+}
+
+// streamListItems turns items into list results, stopping at req.Limit.
+func streamListItems(ctx context.Context, req list.ListRequest, items []listItem) iter.Seq[list.ListResult] {
+	return func(push func(list.ListResult) bool) {
+		for i, item := range items {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+			result := req.NewListResult(ctx)
+			result.DisplayName = item.displayName
+			result.Diagnostics.Append(result.Identity.Set(ctx, item.identity)...)
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				if m := item.resource(ctx, &result.Diagnostics); m != nil && !result.Diagnostics.HasError() {
+					result.Diagnostics.Append(setListResource(ctx, result.Resource, m)...)
+				}
+			}
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// setListResource sets m on res. A timeouts.Value left at its zero value has no
+// attribute types, so it is first replaced by the typed null the schema expects.
+func setListResource(ctx context.Context, res *tfsdk.Resource, m any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if _, ok := res.Schema.GetAttributes()["timeouts"]; ok {
+		var null timeouts.Value
+		diags.Append(res.GetAttribute(ctx, path.Root("timeouts"), &null)...)
+		if diags.HasError() {
+			return diags
+		}
+		switch m := m.(type) {
+		case *VmResourceModel:
+			m.Timeouts = null
+		case *LbResourceModel:
+			m.Timeouts = null
+		case *K8sClusterModel:
+			m.Timeouts = null
+		}
+	}
+	diags.Append(res.Set(ctx, m)...)
+	return diags
+}
@@ -0,0 +1,275 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/fakepanel"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testFakePanelClient returns a client bound to a fresh fake panel, for the list
+// and CRUD tests that call resources directly.
+func testFakePanelClient(t *testing.T) *client.Client {
+	t.Helper()
+	s := fakepanel.New(t)
+	c, err := client.New(client.Config{
+		APIBaseURL:   s.URL(),
+		APIKeyID:     fakepanel.APIKeyID,
+		APISecretKey: fakepanel.APISecretKey,
+		Region:       fakepanel.Region,
+		ProjectTag:   fakepanel.ProjectTag,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// runList runs the list resource for r against c the way the framework does for
+// `terraform query`, with an empty list block.
+func runList(t *testing.T, c *client.Client, r resource.Resource, newList func() list.ListResource, includeResource bool, limit int64) []list.ListResult {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	var identityResp resource.IdentitySchemaResponse
+	r.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identityResp)
+
+	l := newList()
+	var configureResp resource.ConfigureResponse
+	l.(list.ListResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: c}, &configureResp)
+	if configureResp.Diagnostics.HasError() {
+		t.Fatalf("configure: %v", configureResp.Diagnostics)
+	}
+	var configResp list.ListResourceSchemaResponse
+	l.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, &configResp)
+	configType := configResp.Schema.Type().TerraformType(ctx)
+
+	req := list.ListRequest{
+		Config: tfsdk.Config{
+			Schema: configResp.Schema,
+			Raw: tftypes.NewValue(configType, map[string]tftypes.Value{
+				"region":      tftypes.NewValue(tftypes.String, nil),
+				"project_tag": tftypes.NewValue(tftypes.String, nil),
+			}),
+		},
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         schemaResp.Schema,
+		ResourceIdentitySchema: identityResp.IdentitySchema,
+	}
+	var stream list.ListResultsStream
+	l.List(ctx, req, &stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("list result: %v", result.Diagnostics)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func TestVmListResource(t *testing.T) {
+	c := testFakePanelClient(t)
+	ctx := context.Background()
+	network, err := c.CreateLocalNetwork(ctx, client.CreateLocalNetworkRequest{
+		Name: "list-net", CIDR: "10.60.0.0/24", Gateway: "10.60.0.1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"list-a", "list-b"} {
+		if _, err := c.CreateVm(ctx, client.CreateVmRequest{
+			Name: name, ImageID: 1, CPUCores: 1, RAM: 1, DiskSize: 20, DiskType: "SSD",
+			LocalNetworkID: network.ID, Password: "FakePanel123",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results := runList(t, c, NewVmResource(), NewVmListResource, true, 0)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	for _, result := range results {
+		var identity scopedIdentityModel
+		if diags := result.Identity.Get(ctx, &identity); diags.HasError() {
+			t.Fatal(diags)
+		}
+		if identity.ID.ValueInt64() == 0 || identity.Region.ValueString() != fakepanel.Region ||
+			identity.ProjectTag.ValueString() != fakepanel.ProjectTag {
+			t.Errorf("identity = %+v", identity)
+		}
+		var m VmResourceModel
+		if diags := result.Resource.Get(ctx, &m); diags.HasError() {
+			t.Fatal(diags)
+		}
+		if m.Name.ValueString() != result.DisplayName || m.LocalNetworkID.ValueInt64() != network.ID {
+			t.Errorf("resource name %s, network %d; display name %s", m.Name, m.LocalNetworkID, result.DisplayName)
+		}
+		if !m.Password.IsNull() || !m.UserData.IsNull() || !m.Timeouts.IsNull() {
+			t.Errorf("credentials, user_data and timeouts should be null: %+v", m)
+		}
+	}
+
+	// Without include_resource, only identities are returned.
+	results = runList(t, c, NewVmResource(), NewVmListResource, false, 1)
+	if len(results) != 1 {
+		t.Fatalf("limit 1: got %d results", len(results))
+	}
+	if !results[0].Resource.Raw.IsNull() {
+		t.Error("resource should be null unless requested")
+	}
+}
+
+// TestListResources_scoped lists one of each of the simpler kinds and checks
+// identity and the refreshed attributes line up with what the panel holds.
+func TestListResources_scoped(t *testing.T) {
+	c := testFakePanelClient(t)
+	ctx := context.Background()
+	network, err := c.CreateLocalNetwork(ctx, client.CreateLocalNetworkRequest{
+		Name: "list-net", CIDR: "10.61.0.0/24", Gateway: "10.61.0.1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume, err := c.CreateVolume(ctx, client.CreateVolumeRequest{Name: "list-vol", Type: "SSD", Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ip, err := c.CreatePublicIP(ctx, client.CreatePublicIPRequest{Name: "list-ip"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CreateBucket(ctx, client.CreateBucketRequest{BucketKey: "list-bucket"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	one := func(r resource.Resource, newList func() list.ListResource) list.ListResult {
+		t.Helper()
+		results := runList(t, c, r, newList, true, 0)
+		if len(results) != 1 {
+			t.Fatalf("got %d results, want 1", len(results))
+		}
+		return results[0]
+	}
+	scoped := func(result list.ListResult, wantID int64) {
+		t.Helper()
+		var identity scopedIdentityModel
+		if diags := result.Identity.Get(ctx, &identity); diags.HasError() {
+			t.Fatal(diags)
+		}
+		if identity.ID.ValueInt64() != wantID || identity.Region.ValueString() != fakepanel.Region {
+			t.Errorf("identity = %+v, want id %d", identity, wantID)
+		}
+	}
+
+	result := one(NewLocalNetworkResource(), NewLocalNetworkListResource)
+	scoped(result, network.ID)
+	var nm LocalNetworkResourceModel
+	if diags := result.Resource.Get(ctx, &nm); diags.HasError() || nm.CIDR.ValueString() != "10.61.0.0/24" {
+		t.Errorf("local network = %+v, %v", nm, diags)
+	}
+
+	result = one(NewVolumeResource(), NewVolumeListResource)
+	scoped(result, volume.ID)
+	var vm VolumeResourceModel
+	if diags := result.Resource.Get(ctx, &vm); diags.HasError() || vm.Size.ValueInt64() != 10 || vm.Type.ValueString() != "SSD" {
+		t.Errorf("volume = %+v, %v", vm, diags)
+	}
+
+	result = one(NewPublicIPResource(), NewPublicIPListResource)
+	scoped(result, ip.ID)
+	var im PublicIPResourceModel
+	if diags := result.Resource.Get(ctx, &im); diags.HasError() || im.IP.ValueString() != ip.IP {
+		t.Errorf("public IP = %+v, %v", im, diags)
+	}
+
+	result = one(NewS3BucketResource(), NewS3BucketListResource)
+	var bi bucketIdentityModel
+	if diags := result.Identity.Get(ctx, &bi); diags.HasError() || bi.ID.ValueString() != "list-bucket" {
+		t.Errorf("bucket identity = %+v, %v", bi, diags)
+	}
+	var bm S3BucketResourceModel
+	if diags := result.Resource.Get(ctx, &bm); diags.HasError() || bm.Name.ValueString() != "list-bucket" || bm.Versioning.ValueBool() {
+		t.Errorf("bucket = %+v, %v", bm, diags)
+	}
+	if !bm.Acl.IsNull() {
+		t.Errorf("acl is trust-state and should be null, got %s", bm.Acl)
+	}
+}
+
+// TestListResources_skipDeleted lists load balancers and clusters. A deleted
+// cluster lingers in the panel's list as DELETED and must not be offered for
+// import.
+func TestListResources_skipDeleted(t *testing.T) {
+	c := testFakePanelClient(t)
+	ctx := context.Background()
+	network, err := c.CreateLocalNetwork(ctx, client.CreateLocalNetworkRequest{
+		Name: "list-net", CIDR: "10.62.0.0/24", Gateway: "10.62.0.1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm, err := c.CreateVm(ctx, client.CreateVmRequest{
+		Name: "web", ImageID: 1, CPUCores: 1, RAM: 1, DiskSize: 10, LocalNetworkID: network.ID, Password: "FakePanel123",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lb, err := c.CreateLoadBalancerFrontend(ctx, client.LoadBalancerRequest{
+		Name: "list-lb", IsPublic: true, Protocol: "TCP", UserNetID: network.ID,
+		Backends: []client.LbBackendRef{{UserVmID: vm.Guid}},
+		Ports:    []client.LbPortReq{{BalancerPort: 80, BackendPort: 8080}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterReq := client.CreateClusterRequest{
+		ClusterName: "list-k8s", KuberVersion: "v1.31.4", NodePoolName: "default",
+		WorkerCPU: 2, WorkerRAM: 4, WorkerDiskSize: 50, WorkerReplicas: 2,
+		LocalNetID: network.ID, MasterNodeConfigID: 11, PodSubnet: "10.244.0.0/16",
+	}
+	cluster, err := c.CreateCluster(ctx, clusterReq, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clusterReq.ClusterName = "gone-k8s"
+	gone, err := c.CreateCluster(ctx, clusterReq, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteCluster(ctx, gone.ID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	results := runList(t, c, NewLbResource(), NewLbListResource, true, 0)
+	if len(results) != 1 || results[0].DisplayName != "list-lb" {
+		t.Fatalf("load balancers = %+v", results)
+	}
+	var lm LbResourceModel
+	if diags := results[0].Resource.Get(ctx, &lm); diags.HasError() || lm.ID.ValueInt64() != lb.ID || len(lm.Port) != 1 {
+		t.Errorf("load balancer = %+v, %v", lm, diags)
+	}
+
+	results = runList(t, c, NewK8sClusterResource(), NewK8sClusterListResource, true, 0)
+	if len(results) != 1 || results[0].DisplayName != "list-k8s" {
+		t.Fatalf("clusters = %+v", results)
+	}
+	var km K8sClusterModel
+	if diags := results[0].Resource.Get(ctx, &km); diags.HasError() || km.ID.ValueInt64() != cluster.ID {
+		t.Fatalf("cluster = %+v, %v", km, diags)
+	}
+	if km.DefaultNodePool == nil || km.DefaultNodePool.Name.ValueString() != "default" || km.DefaultNodePool.NodeCount.ValueInt64() != 2 {
+		t.Errorf("default_node_pool = %+v", km.DefaultNodePool)
+	}
+}
@@ -0,0 +1,79 @@
+package resources
+
+import (
+	"context"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ list.ListResource              = &LocalNetworkListResource{}
+	_ list.ListResourceWithConfigure = &LocalNetworkListResource{}
+)
+
+// LocalNetworkListResource lists the local networks in a project.
+type LocalNetworkListResource struct {
+	listBase
+}
+
+func NewLocalNetworkListResource() list.ListResource {
+	return &LocalNetworkListResource{}
+}
+
+func (l *LocalNetworkListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_local_network"
+}
+
+func (l *LocalNetworkListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listScopeSchema("local networks")
+}
+
+func (l *LocalNetworkListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_local_network", "list")
+	var diags diag.Diagnostics
+	defer op.End(nil, &diags)
+
+	region, projectTag := l.scope(ctx, req.Config, &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	networks, err := l.c.GetLocalNetworks(ctx, &client.RequestOpts{Region: region, ProjectTag: projectTag})
+	if err != nil {
+		diags.AddError("Unable to List Local Networks", client.ErrorDetail(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	tflog.Debug(ctx, "Listed local networks", map[string]any{"count": len(networks), "region": region, "project_tag": projectTag})
+
+	items := make([]listItem, 0, len(networks))
+	for i := range networks {
+		network := &networks[i]
+		items = append(items, listItem{
+			displayName: network.Name,
+			identity: scopedIdentityModel{
+				ID:         types.Int64Value(network.ID),
+				Region:     types.StringValue(region),
+				ProjectTag: types.StringValue(projectTag),
+			},
+			resource: func(context.Context, *diag.Diagnostics) any {
+				return &LocalNetworkResourceModel{
+					ID:         types.Int64Value(network.ID),
+					Region:     types.StringValue(region),
+					ProjectTag: types.StringValue(projectTag),
+					Name:       types.StringValue(network.Name),
+					CIDR:       types.StringValue(network.CIDR),
+					Gateway:    types.StringValue(network.Gateway),
+				}
+			},
+		})
+	}
+	stream.Results = streamListItems(ctx, req, items)
+}
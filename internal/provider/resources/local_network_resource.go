@@ -2,11 +2,13 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"sync"
 
 	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,6 +24,7 @@ var (
 	_ resource.Resource                = &LocalNetworkResource{}
 	_ resource.ResourceWithConfigure   = &LocalNetworkResource{}
 	_ resource.ResourceWithImportState = &LocalNetworkResource{}
+	_ resource.ResourceWithIdentity    = &LocalNetworkResource{}
 )
 
 // localNetworkWriteMu serializes local-network create/delete API calls within this
@@ -106,6 +109,10 @@ func (r *LocalNetworkResource) Schema(ctx context.Context, req resource.SchemaRe
 	}
 }
 
+func (r *LocalNetworkResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = scopedIdentitySchema("local network")
+}
+
 func (r *LocalNetworkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -124,6 +131,11 @@ func (r *LocalNetworkResource) Configure(ctx context.Context, req resource.Confi
 }
 
 func (r *LocalNetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_local_network", "create")
+	defer op.End(&resp.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Create)
+	defer cancel()
+
 	var data LocalNetworkResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -156,7 +168,7 @@ func (r *LocalNetworkResource) Create(ctx context.Context, req resource.CreateRe
 		"gateway":     createReq.Gateway,
 	})
 
-	network, err := client.RetryOnBusy(ctx, client.RetryTimeoutShort, func() (*client.LocalNetwork, error) {
+	network, err := client.RetryOnBusy(ctx, r.client.Retry.BusyTimeoutOr(client.RetryTimeoutShort), func() (*client.LocalNetwork, error) {
 		localNetworkWriteMu.Lock()
 		defer localNetworkWriteMu.Unlock()
 		return r.client.CreateLocalNetwork(ctx, createReq)
@@ -165,7 +177,7 @@ func (r *LocalNetworkResource) Create(ctx context.Context, req resource.CreateRe
 		// Error 614: network with this name already exists — adopt it into state.
 		// This happens when a previous create succeeded on the server but Terraform
 		// lost track of the state (e.g., timeout, interrupted apply).
-		if client.IsAPIError(err, 614) {
+		if errors.Is(err, client.ErrLocalNetworkNameTaken) {
 			existing, adoptErr := r.findLocalNetworkByName(ctx, createReq.Name, createReq.Region, createReq.ProjectTag)
 			if adoptErr != nil {
 				resp.Diagnostics.AddError("Unable to Create Local Network",
@@ -193,7 +205,7 @@ func (r *LocalNetworkResource) Create(ctx context.Context, req resource.CreateRe
 			})
 			network = existing
 		} else {
-			resp.Diagnostics.AddError("Unable to Create Local Network", err.Error())
+			resp.Diagnostics.AddError("Unable to Create Local Network", client.ErrorDetail(err))
 			return
 		}
 	}
@@ -211,6 +223,7 @@ func (r *LocalNetworkResource) Create(ctx context.Context, req resource.CreateRe
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, data.ID, data.Region, data.ProjectTag)...)
 }
 
 // findLocalNetworkByName looks up an existing local network by name.
@@ -232,6 +245,11 @@ func (r *LocalNetworkResource) findLocalNetworkByName(ctx context.Context, name,
 }
 
 func (r *LocalNetworkResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_local_network", "read")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Read)
+	defer cancel()
+
 	var data LocalNetworkResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -262,7 +280,7 @@ func (r *LocalNetworkResource) Read(ctx context.Context, req resource.ReadReques
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Read Local Network", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Local Network", client.ErrorDetail(err))
 		return
 	}
 
@@ -276,9 +294,15 @@ func (r *LocalNetworkResource) Read(ctx context.Context, req resource.ReadReques
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, data.ID, data.Region, data.ProjectTag)...)
 }
 
 func (r *LocalNetworkResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_local_network", "update")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Update)
+	defer cancel()
+
 	var plan LocalNetworkResourceModel
 	var state LocalNetworkResourceModel
 
@@ -309,7 +333,7 @@ func (r *LocalNetworkResource) Update(ctx context.Context, req resource.UpdateRe
 
 	network, err := r.client.UpdateLocalNetwork(ctx, networkID, updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Update Local Network", err.Error())
+		resp.Diagnostics.AddError("Unable to Update Local Network", client.ErrorDetail(err))
 		return
 	}
 
@@ -324,9 +348,15 @@ func (r *LocalNetworkResource) Update(ctx context.Context, req resource.UpdateRe
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, plan.ID, plan.Region, plan.ProjectTag)...)
 }
 
 func (r *LocalNetworkResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_local_network", "delete")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Delete)
+	defer cancel()
+
 	var data LocalNetworkResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -350,7 +380,7 @@ func (r *LocalNetworkResource) Delete(ctx context.Context, req resource.DeleteRe
 		"project_tag": opts.ProjectTag,
 	})
 
-	err := client.RetryVoidOnBusy(ctx, client.RetryTimeoutShort, func() error {
+	err := client.RetryVoidOnBusy(ctx, r.client.Retry.BusyTimeoutOr(client.RetryTimeoutShort), func() error {
 		localNetworkWriteMu.Lock()
 		defer localNetworkWriteMu.Unlock()
 		return r.client.DeleteLocalNetwork(ctx, networkID, opts)
@@ -359,7 +389,7 @@ func (r *LocalNetworkResource) Delete(ctx context.Context, req resource.DeleteRe
 		if client.IsNotFound(err) {
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Delete Local Network", err.Error())
+		resp.Diagnostics.AddError("Unable to Delete Local Network", client.ErrorDetail(err))
 		return
 	}
 
@@ -367,6 +397,14 @@ func (r *LocalNetworkResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *LocalNetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_local_network", "import")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
+	if importByIdentity(req) {
+		importScopedIdentity(ctx, req, resp, r.client.Region, r.client.ProjectTag)
+		return
+	}
+
 	id, err := strconv.ParseInt(req.ID, 10, 64)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -2,11 +2,13 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,6 +24,8 @@ var (
 	_ resource.Resource                = &PublicIPAttachmentResource{}
 	_ resource.ResourceWithConfigure   = &PublicIPAttachmentResource{}
 	_ resource.ResourceWithImportState = &PublicIPAttachmentResource{}
+	_ resource.ResourceWithIdentity    = &PublicIPAttachmentResource{}
+	_ resource.ResourceWithMoveState   = &PublicIPAttachmentResource{}
 )
 
 type PublicIPAttachmentResource struct {
@@ -93,6 +97,13 @@ func (r *PublicIPAttachmentResource) Schema(ctx context.Context, req resource.Sc
 	}
 }
 
+func (r *PublicIPAttachmentResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = compositeIdentitySchema(map[string]string{
+		"vm_id":        "ID of the virtual machine.",
+		"public_ip_id": "ID of the public IP attached to it.",
+	})
+}
+
 func (r *PublicIPAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -111,6 +122,11 @@ func (r *PublicIPAttachmentResource) Configure(ctx context.Context, req resource
 }
 
 func (r *PublicIPAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_public_ip_attachment", "create")
+	defer op.End(&resp.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Create)
+	defer cancel()
+
 	var data PublicIPAttachmentResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -129,11 +145,11 @@ func (r *PublicIPAttachmentResource) Create(ctx context.Context, req resource.Cr
 		"public_ip_id": attachReq.PublicIPID,
 	})
 
-	vm, err := client.RetryOnBusy(ctx, client.RetryTimeoutShort, func() (*client.Vm, error) {
+	vm, err := client.RetryOnBusy(ctx, r.client.Retry.BusyTimeoutOr(client.RetryTimeoutShort), func() (*client.Vm, error) {
 		return r.client.AttachPublicIP(ctx, vmID, attachReq, opts)
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Attach Public IP", err.Error())
+		resp.Diagnostics.AddError("Unable to Attach Public IP", client.ErrorDetail(err))
 		return
 	}
 
@@ -156,9 +172,20 @@ func (r *PublicIPAttachmentResource) Create(ctx context.Context, req resource.Cr
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, publicIPAttachmentIdentityModel{
+		VmID:       data.VmID,
+		PublicIPID: data.PublicIPID,
+		Region:     types.StringValue(valueOrDefault(data.Region, r.client.Region)),
+		ProjectTag: types.StringValue(valueOrDefault(data.ProjectTag, r.client.ProjectTag)),
+	})...)
 }
 
 func (r *PublicIPAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_public_ip_attachment", "read")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Read)
+	defer cancel()
+
 	var data PublicIPAttachmentResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -184,7 +211,7 @@ func (r *PublicIPAttachmentResource) Read(ctx context.Context, req resource.Read
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Read VM for Public IP Attachment", err.Error())
+		resp.Diagnostics.AddError("Unable to Read VM for Public IP Attachment", client.ErrorDetail(err))
 		return
 	}
 
@@ -207,9 +234,18 @@ func (r *PublicIPAttachmentResource) Read(ctx context.Context, req resource.Read
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, publicIPAttachmentIdentityModel{
+		VmID:       data.VmID,
+		PublicIPID: data.PublicIPID,
+		Region:     types.StringValue(valueOrDefault(data.Region, r.client.Region)),
+		ProjectTag: types.StringValue(valueOrDefault(data.ProjectTag, r.client.ProjectTag)),
+	})...)
 }
 
 func (r *PublicIPAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	_, op := telemetry.StartOperation(ctx, "prodata_public_ip_attachment", "update")
+	defer op.End(&req.State, &resp.Diagnostics)
+
 	resp.Diagnostics.AddError(
 		"Update Not Supported",
 		"All attributes of prodata_public_ip_attachment require replacement. This is a bug in the provider.",
@@ -217,6 +253,11 @@ func (r *PublicIPAttachmentResource) Update(ctx context.Context, req resource.Up
 }
 
 func (r *PublicIPAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_public_ip_attachment", "delete")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Delete)
+	defer cancel()
+
 	var data PublicIPAttachmentResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -237,7 +278,7 @@ func (r *PublicIPAttachmentResource) Delete(ctx context.Context, req resource.De
 		if client.IsNotFound(err) {
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Read VM for Public IP detach", err.Error())
+		resp.Diagnostics.AddError("Unable to Read VM for Public IP detach", client.ErrorDetail(err))
 		return
 	}
 	if vm.PublicIPID != expectedPublicIPID {
@@ -258,7 +299,7 @@ func (r *PublicIPAttachmentResource) Delete(ctx context.Context, req resource.De
 		if client.IsNotFound(err) {
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Detach Public IP", err.Error())
+		resp.Diagnostics.AddError("Unable to Detach Public IP", client.ErrorDetail(err))
 		return
 	}
 
@@ -268,6 +309,14 @@ func (r *PublicIPAttachmentResource) Delete(ctx context.Context, req resource.De
 }
 
 func (r *PublicIPAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_public_ip_attachment", "import")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
+	if importByIdentity(req) {
+		importIdentity(ctx, req, resp, r.client.Region, r.client.ProjectTag, "vm_id", "public_ip_id")
+		return
+	}
+
 	parts := strings.SplitN(req.ID, ":", 2)
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError(
@@ -312,6 +361,70 @@ func (r *PublicIPAttachmentResource) ImportState(ctx context.Context, req resour
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_tag"), r.client.ProjectTag)...)
 }
 
+// MoveState lets a `moved` block turn a prodata_vm's inline public_ip_id into a
+// prodata_public_ip_attachment: the attachment is built from the VM's state, so
+// the IP stays attached and nothing is called on the panel. The refresh that
+// follows the move confirms the attachment as Read always does.
+func (r *PublicIPAttachmentResource) MoveState(context.Context) []resource.StateMover {
+	return []resource.StateMover{{StateMover: r.moveFromVm}}
+}
+
+func (r *PublicIPAttachmentResource) moveFromVm(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "prodata_vm" || !strings.HasSuffix(req.SourceProviderAddress, "/prodata-cloud/prodata") {
+		return
+	}
+	ctx, op := telemetry.StartOperation(ctx, "prodata_public_ip_attachment", "move")
+	defer op.End(&resp.TargetState, &resp.Diagnostics)
+
+	// Every prodata_vm schema version has these attributes, with these types.
+	var vm struct {
+		ID         int64   `json:"id"`
+		PublicIPID *int64  `json:"public_ip_id"`
+		PublicIP   *string `json:"public_ip"`
+		Region     *string `json:"region"`
+		ProjectTag *string `json:"project_tag"`
+	}
+	if req.SourceRawState == nil || json.Unmarshal(req.SourceRawState.JSON, &vm) != nil {
+		resp.Diagnostics.AddError("Unable to Move Resource State",
+			"The prodata_vm state could not be decoded. Please report this issue to the provider developers.")
+		return
+	}
+	if vm.PublicIPID == nil || *vm.PublicIPID == 0 {
+		resp.Diagnostics.AddError(
+			"VM Has No Public IP",
+			fmt.Sprintf("VM %d has no public IP attached, so there is no attachment to move its state to. "+
+				"Remove the moved block; moving the VM's state would stop Terraform managing the VM.", vm.ID),
+		)
+		return
+	}
+
+	data := PublicIPAttachmentResourceModel{
+		VmID:       types.Int64Value(vm.ID),
+		PublicIPID: types.Int64Value(*vm.PublicIPID),
+		PublicIP:   types.StringPointerValue(vm.PublicIP),
+		Region:     types.StringPointerValue(vm.Region),
+		ProjectTag: types.StringPointerValue(vm.ProjectTag),
+	}
+	tflog.Info(ctx, "Moving VM public IP into a public IP attachment", map[string]any{
+		"vm_id":        vm.ID,
+		"public_ip_id": *vm.PublicIPID,
+	})
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
+
+	// The resource is not configured for a move, so there are no provider defaults
+	// to resolve a missing scope with. A VM always records its resolved scope, but
+	// if it somehow did not, the identity is left for the refresh to record.
+	if data.Region.IsNull() || data.ProjectTag.IsNull() {
+		return
+	}
+	resp.Diagnostics.Append(setIdentity(ctx, resp.TargetIdentity, publicIPAttachmentIdentityModel{
+		VmID:       data.VmID,
+		PublicIPID: data.PublicIPID,
+		Region:     data.Region,
+		ProjectTag: data.ProjectTag,
+	})...)
+}
+
 func (r *PublicIPAttachmentResource) buildOpts(data *PublicIPAttachmentResourceModel) *client.RequestOpts {
 	opts := &client.RequestOpts{}
 	if !data.Region.IsNull() && !data.Region.IsUnknown() {
@@ -0,0 +1,81 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// moveState runs r's state movers the way the framework does for a `moved`
+// block whose source is a sourceType resource with the given raw state.
+func moveState(t *testing.T, r resource.Resource, sourceType, rawJSON string) resource.MoveStateResponse {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	var identityResp resource.IdentitySchemaResponse
+	r.(resource.ResourceWithIdentity).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identityResp)
+
+	req := resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/prodata-cloud/prodata",
+		SourceTypeName:        sourceType,
+		SourceSchemaVersion:   1,
+		SourceRawState:        &tfprotov6.RawState{JSON: []byte(rawJSON)},
+	}
+	for _, mover := range r.(resource.ResourceWithMoveState).MoveState(ctx) {
+		resp := resource.MoveStateResponse{
+			TargetState: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			},
+			TargetIdentity: &tfsdk.ResourceIdentity{
+				Schema: identityResp.IdentitySchema,
+				Raw:    tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), nil),
+			},
+		}
+		mover.StateMover(ctx, req, &resp)
+		if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+			return resp
+		}
+	}
+	return resource.MoveStateResponse{}
+}
+
+func TestPublicIPAttachmentMoveState(t *testing.T) {
+	ctx := context.Background()
+
+	resp := moveState(t, NewPublicIPAttachmentResource(), "prodata_vm",
+		`{"id":42,"name":"web","public_ip_id":7,"public_ip":"203.0.113.7","region":"UZ-3","project_tag":"team-a","cpu_cores":2}`)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+	var data PublicIPAttachmentResourceModel
+	if diags := resp.TargetState.Get(ctx, &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if data.VmID.ValueInt64() != 42 || data.PublicIPID.ValueInt64() != 7 || data.PublicIP.ValueString() != "203.0.113.7" ||
+		data.Region.ValueString() != "UZ-3" || data.ProjectTag.ValueString() != "team-a" {
+		t.Errorf("moved state = %+v", data)
+	}
+	var identity publicIPAttachmentIdentityModel
+	if diags := resp.TargetIdentity.Get(ctx, &identity); diags.HasError() || identity.VmID.ValueInt64() != 42 || identity.PublicIPID.ValueInt64() != 7 {
+		t.Errorf("moved identity = %+v, %v", identity, diags)
+	}
+
+	// A VM without a public IP has nothing to move; moving it anyway would lose the VM.
+	resp = moveState(t, NewPublicIPAttachmentResource(), "prodata_vm",
+		`{"id":42,"name":"web","public_ip_id":null,"region":"UZ-3","project_tag":"team-a"}`)
+	if !resp.Diagnostics.HasError() {
+		t.Error("moving a VM without a public IP should fail")
+	}
+
+	// Other source types are not handled, leaving the framework to report it.
+	resp = moveState(t, NewPublicIPAttachmentResource(), "prodata_volume", `{"id":42}`)
+	if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+		t.Errorf("a prodata_volume source should be ignored: %v", resp.Diagnostics)
+	}
+}
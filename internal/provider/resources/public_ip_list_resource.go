@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"context"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ list.ListResource              = &PublicIPListResource{}
+	_ list.ListResourceWithConfigure = &PublicIPListResource{}
+)
+
+// PublicIPListResource lists the public IPs in a project.
+type PublicIPListResource struct {
+	listBase
+}
+
+func NewPublicIPListResource() list.ListResource {
+	return &PublicIPListResource{}
+}
+
+func (l *PublicIPListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_public_ip"
+}
+
+func (l *PublicIPListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listScopeSchema("public IPs")
+}
+
+func (l *PublicIPListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_public_ip", "list")
+	var diags diag.Diagnostics
+	defer op.End(nil, &diags)
+
+	region, projectTag := l.scope(ctx, req.Config, &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	ips, err := l.c.GetPublicIPs(ctx, &client.RequestOpts{Region: region, ProjectTag: projectTag})
+	if err != nil {
+		diags.AddError("Unable to List Public IPs", client.ErrorDetail(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	tflog.Debug(ctx, "Listed public IPs", map[string]any{"count": len(ips), "region": region, "project_tag": projectTag})
+
+	items := make([]listItem, 0, len(ips))
+	for i := range ips {
+		ip := &ips[i]
+		items = append(items, listItem{
+			displayName: ip.Name,
+			identity: scopedIdentityModel{
+				ID:         types.Int64Value(ip.ID),
+				Region:     types.StringValue(region),
+				ProjectTag: types.StringValue(projectTag),
+			},
+			resource: func(context.Context, *diag.Diagnostics) any {
+				return &PublicIPResourceModel{
+					ID:         types.Int64Value(ip.ID),
+					Region:     types.StringValue(region),
+					ProjectTag: types.StringValue(projectTag),
+					Name:       types.StringValue(ip.Name),
+					IP:         types.StringValue(ip.IP),
+					Mask:       types.StringValue(ip.Mask),
+					Gateway:    types.StringValue(ip.Gateway),
+				}
+			},
+		})
+	}
+	stream.Results = streamListItems(ctx, req, items)
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,6 +22,7 @@ var (
 	_ resource.Resource                = &PublicIPResource{}
 	_ resource.ResourceWithConfigure   = &PublicIPResource{}
 	_ resource.ResourceWithImportState = &PublicIPResource{}
+	_ resource.ResourceWithIdentity    = &PublicIPResource{}
 )
 
 type PublicIPResource struct {
@@ -104,6 +106,10 @@ func (r *PublicIPResource) Schema(ctx context.Context, req resource.SchemaReques
 	}
 }
 
+func (r *PublicIPResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = scopedIdentitySchema("public IP")
+}
+
 func (r *PublicIPResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -122,6 +128,11 @@ func (r *PublicIPResource) Configure(ctx context.Context, req resource.Configure
 }
 
 func (r *PublicIPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_public_ip", "create")
+	defer op.End(&resp.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Create)
+	defer cancel()
+
 	var data PublicIPResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -150,11 +161,11 @@ func (r *PublicIPResource) Create(ctx context.Context, req resource.CreateReques
 		"project_tag": createReq.ProjectTag,
 	})
 
-	ip, err := client.RetryOnBusy(ctx, client.RetryTimeoutLong, func() (*client.PublicIP, error) {
+	ip, err := client.RetryOnBusy(ctx, r.client.Retry.BusyTimeoutOr(client.RetryTimeoutLong), func() (*client.PublicIP, error) {
 		return r.client.CreatePublicIP(ctx, createReq)
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Create Public IP", err.Error())
+		resp.Diagnostics.AddError("Unable to Create Public IP", client.ErrorDetail(err))
 		return
 	}
 
@@ -173,9 +184,15 @@ func (r *PublicIPResource) Create(ctx context.Context, req resource.CreateReques
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, data.ID, data.Region, data.ProjectTag)...)
 }
 
 func (r *PublicIPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_public_ip", "read")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Read)
+	defer cancel()
+
 	var data PublicIPResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -206,7 +223,7 @@ func (r *PublicIPResource) Read(ctx context.Context, req resource.ReadRequest, r
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Read Public IP", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Public IP", client.ErrorDetail(err))
 		return
 	}
 
@@ -222,9 +239,15 @@ func (r *PublicIPResource) Read(ctx context.Context, req resource.ReadRequest, r
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, data.ID, data.Region, data.ProjectTag)...)
 }
 
 func (r *PublicIPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_public_ip", "update")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Update)
+	defer cancel()
+
 	var plan PublicIPResourceModel
 	var state PublicIPResourceModel
 
@@ -255,7 +278,7 @@ func (r *PublicIPResource) Update(ctx context.Context, req resource.UpdateReques
 
 	ip, err := r.client.UpdatePublicIP(ctx, ipID, updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Update Public IP", err.Error())
+		resp.Diagnostics.AddError("Unable to Update Public IP", client.ErrorDetail(err))
 		return
 	}
 
@@ -271,9 +294,15 @@ func (r *PublicIPResource) Update(ctx context.Context, req resource.UpdateReques
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, plan.ID, plan.Region, plan.ProjectTag)...)
 }
 
 func (r *PublicIPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_public_ip", "delete")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Delete)
+	defer cancel()
+
 	var data PublicIPResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -302,7 +331,7 @@ func (r *PublicIPResource) Delete(ctx context.Context, req resource.DeleteReques
 		if client.IsNotFound(err) {
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Delete Public IP", err.Error())
+		resp.Diagnostics.AddError("Unable to Delete Public IP", client.ErrorDetail(err))
 		return
 	}
 
@@ -310,6 +339,14 @@ func (r *PublicIPResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 func (r *PublicIPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_public_ip", "import")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
+	if importByIdentity(req) {
+		importScopedIdentity(ctx, req, resp, r.client.Region, r.client.ProjectTag)
+		return
+	}
+
 	id, err := strconv.ParseInt(req.ID, 10, 64)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"context"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ list.ListResource              = &S3BucketListResource{}
+	_ list.ListResourceWithConfigure = &S3BucketListResource{}
+)
+
+// S3BucketListResource lists the S3 buckets in a project. Versioning and object
+// lock are read per bucket, and only when the full resource is asked for; acl is
+// trust-state and is left null, as after an import.
+type S3BucketListResource struct {
+	listBase
+}
+
+func NewS3BucketListResource() list.ListResource {
+	return &S3BucketListResource{}
+}
+
+func (l *S3BucketListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_bucket"
+}
+
+func (l *S3BucketListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listScopeSchema("S3 buckets")
+}
+
+func (l *S3BucketListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_s3_bucket", "list")
+	var diags diag.Diagnostics
+	defer op.End(nil, &diags)
+
+	region, projectTag := l.scope(ctx, req.Config, &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	opts := &client.RequestOpts{Region: region, ProjectTag: projectTag}
+	buckets, err := l.c.ListBuckets(ctx, 0, opts)
+	if err != nil {
+		diags.AddError("Unable to List Buckets", client.ErrorDetail(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	tflog.Debug(ctx, "Listed S3 buckets", map[string]any{"count": len(buckets), "region": region, "project_tag": projectTag})
+
+	r := &S3BucketResource{c: l.c}
+	items := make([]listItem, 0, len(buckets))
+	for i := range buckets {
+		b := &buckets[i]
+		items = append(items, listItem{
+			displayName: b.Name,
+			identity: bucketIdentityModel{
+				ID:         types.StringValue(b.Name),
+				Region:     types.StringValue(region),
+				ProjectTag: types.StringValue(projectTag),
+			},
+			resource: func(ctx context.Context, diags *diag.Diagnostics) any {
+				m := &S3BucketResourceModel{
+					ID:                 types.StringValue(b.Name),
+					Region:             types.StringValue(region),
+					ProjectTag:         types.StringValue(projectTag),
+					Name:               types.StringValue(b.Name),
+					DeletionProtection: types.BoolValue(false),
+				}
+				if err := r.refreshFromServer(ctx, m, b, opts); err != nil {
+					diags.AddError("Unable to Read Bucket configuration", err.Error())
+					return nil
+				}
+				return m
+			},
+		})
+	}
+	stream.Results = streamListItems(ctx, req, items)
+}
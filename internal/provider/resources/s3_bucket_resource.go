@@ -2,11 +2,14 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 
 	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -23,10 +26,12 @@ import (
 )
 
 var (
-	_ resource.Resource                = &S3BucketResource{}
-	_ resource.ResourceWithConfigure   = &S3BucketResource{}
-	_ resource.ResourceWithModifyPlan  = &S3BucketResource{}
-	_ resource.ResourceWithImportState = &S3BucketResource{}
+	_ resource.Resource                 = &S3BucketResource{}
+	_ resource.ResourceWithConfigure    = &S3BucketResource{}
+	_ resource.ResourceWithModifyPlan   = &S3BucketResource{}
+	_ resource.ResourceWithImportState  = &S3BucketResource{}
+	_ resource.ResourceWithIdentity     = &S3BucketResource{}
+	_ resource.ResourceWithUpgradeState = &S3BucketResource{}
 )
 
 type S3BucketResource struct {
@@ -34,14 +39,15 @@ type S3BucketResource struct {
 }
 
 type S3BucketResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Region            types.String `tfsdk:"region"`
-	ProjectTag        types.String `tfsdk:"project_tag"`
-	Name              types.String `tfsdk:"name"`
-	Acl               types.String `tfsdk:"acl"`
-	Versioning        types.Bool   `tfsdk:"versioning"`
-	ObjectLockEnabled types.Bool   `tfsdk:"object_lock_enabled"`
-	CreationDate      types.String `tfsdk:"creation_date"`
+	ID                 types.String `tfsdk:"id"`
+	Region             types.String `tfsdk:"region"`
+	ProjectTag         types.String `tfsdk:"project_tag"`
+	Name               types.String `tfsdk:"name"`
+	Acl                types.String `tfsdk:"acl"`
+	Versioning         types.Bool   `tfsdk:"versioning"`
+	ObjectLockEnabled  types.Bool   `tfsdk:"object_lock_enabled"`
+	CreationDate       types.String `tfsdk:"creation_date"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func NewS3BucketResource() resource.Resource {
@@ -54,6 +60,8 @@ func (r *S3BucketResource) Metadata(_ context.Context, req resource.MetadataRequ
 
 func (r *S3BucketResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1: versioning retyped from string to bool (0.12.0). See UpgradeState.
+		Version: 1,
 		MarkdownDescription: "Manages a ProData S3 (Ceph RGW) bucket. " +
 			"Buckets are scoped to a single project; cross-project name conflicts surface as a clear error. " +
 			"ACL is trust-state (no drift detection — cannot round-trip canned ACL through S3 grants); " +
@@ -131,10 +139,44 @@ func (r *S3BucketResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute("bucket"),
 		},
 	}
 }
 
+// UpgradeState migrates version 0 state. Before 0.12.0 versioning was the string
+// "enabled", "suspended" or "disabled"; only "enabled" is true, since false also
+// covers a suspended bucket. State written since then already holds a bool.
+func (r *S3BucketResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeRawState(ctx, req, resp, upgradeBucketVersioningV0)
+			},
+		},
+	}
+}
+
+func upgradeBucketVersioningV0(attrs stateAttrs) error {
+	var versioning string
+	if json.Unmarshal(attrs["versioning"], &versioning) != nil {
+		return nil // null, absent or already a bool
+	}
+	switch versioning {
+	case "enabled":
+		attrs["versioning"] = json.RawMessage("true")
+	case "suspended", "disabled", "":
+		attrs["versioning"] = json.RawMessage("false")
+	default:
+		return fmt.Errorf("unrecognized versioning %q in prior state; expected enabled, suspended or disabled", versioning)
+	}
+	return nil
+}
+
+func (r *S3BucketResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = bucketIdentitySchema()
+}
+
 func (r *S3BucketResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -151,6 +193,8 @@ func (r *S3BucketResource) Configure(_ context.Context, req resource.ConfigureRe
 }
 
 func (r *S3BucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer checkDeletionProtection(ctx, "prodata_s3_bucket", req, resp)
+
 	if req.Plan.Raw.IsNull() {
 		return
 	}
@@ -165,9 +209,31 @@ func (r *S3BucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPl
 			resp.Diagnostics.AddAttributeError(path.Root("object_lock_enabled"), "Invalid configuration", msg)
 		}
 	}
+
+	// Object lock is only set at create time (turning it on replaces the bucket),
+	// so only a plan that turns it on needs the panel to support it.
+	if !plan.ObjectLockEnabled.ValueBool() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var state S3BucketResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || state.ObjectLockEnabled.ValueBool() {
+			return
+		}
+	}
+	requireFeature(r.c, client.FeatureObjectLock, path.Root("object_lock_enabled"),
+		"Enabling object_lock_enabled",
+		"leave object_lock_enabled unset",
+		&resp.Diagnostics)
 }
 
 func (r *S3BucketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_s3_bucket", "create")
+	defer op.End(&resp.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.c.DefaultTimeouts.Create)
+	defer cancel()
+
 	var plan S3BucketResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
@@ -207,13 +273,13 @@ func (r *S3BucketResource) Create(ctx context.Context, req resource.CreateReques
 	})
 
 	err := r.c.CreateBucket(ctx, createReq, opts)
-	if err != nil && client.IsAPIError(err, 626) {
+	if err != nil && errors.Is(err, client.ErrBucketNameTaken) {
 		// 626 = name conflict. Verify ownership before adopting: same-project → adopt,
 		// other-project → loud error (never silent-drop someone else's bucket).
 		tflog.Info(ctx, "Bucket name conflict (626), checking ownership for adoption", map[string]any{"name": name})
 		existing, getErr := r.c.GetBucket(ctx, name, opts)
 		if getErr != nil {
-			if client.IsAPIError(getErr, 712) {
+			if errors.Is(getErr, client.ErrBucketOtherProject) {
 				resp.Diagnostics.AddError(
 					"Bucket name taken by another project",
 					fmt.Sprintf("A bucket named %q already exists but belongs to a different project. Choose a different name.", name),
@@ -259,7 +325,7 @@ func (r *S3BucketResource) Create(ctx context.Context, req resource.CreateReques
 		// directly instead of being deferred to a follow-up apply.
 		if aclEnum := aclToEnum(desiredACL); aclEnum != "" {
 			if err := r.c.PutBucketAcl(ctx, existing.Name, client.PutBucketAclRequest{Acl: aclEnum}, opts); err != nil {
-				resp.Diagnostics.AddError("Unable to apply ACL to adopted Bucket", err.Error())
+				resp.Diagnostics.AddError("Unable to apply ACL to adopted Bucket", client.ErrorDetail(err))
 				return
 			}
 		}
@@ -270,7 +336,7 @@ func (r *S3BucketResource) Create(ctx context.Context, req resource.CreateReques
 			}
 			if err := r.c.PutBucketVersioning(ctx, existing.Name,
 				client.PutBucketVersioningRequest{VersioningConfiguration: &client.VersioningConfiguration{Status: status}}, opts); err != nil {
-				resp.Diagnostics.AddError("Unable to apply versioning to adopted Bucket", err.Error())
+				resp.Diagnostics.AddError("Unable to apply versioning to adopted Bucket", client.ErrorDetail(err))
 				return
 			}
 			plan.Versioning = types.BoolValue(desiredVersioning)
@@ -281,16 +347,17 @@ func (r *S3BucketResource) Create(ctx context.Context, req resource.CreateReques
 		plan.Region = types.StringValue(region)
 		plan.ProjectTag = types.StringValue(projectTag)
 		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		resp.Diagnostics.Append(setBucketIdentity(ctx, resp.Identity, plan.ID, plan.Region, plan.ProjectTag)...)
 		return
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Create Bucket", err.Error())
+		resp.Diagnostics.AddError("Unable to Create Bucket", client.ErrorDetail(err))
 		return
 	}
 
 	fresh, getErr := r.c.GetBucket(ctx, name, opts)
 	if getErr != nil {
-		resp.Diagnostics.AddError("Unable to Read Bucket after Create", getErr.Error())
+		resp.Diagnostics.AddError("Unable to Read Bucket after Create", client.ErrorDetail(getErr))
 		return
 	}
 	if rfErr := r.refreshFromServer(ctx, &plan, fresh, opts); rfErr != nil {
@@ -302,9 +369,15 @@ func (r *S3BucketResource) Create(ctx context.Context, req resource.CreateReques
 	plan.Region = types.StringValue(region)
 	plan.ProjectTag = types.StringValue(projectTag)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setBucketIdentity(ctx, resp.Identity, plan.ID, plan.Region, plan.ProjectTag)...)
 }
 
 func (r *S3BucketResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_s3_bucket", "read")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.c.DefaultTimeouts.Read)
+	defer cancel()
+
 	var data S3BucketResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -333,7 +406,7 @@ func (r *S3BucketResource) Read(ctx context.Context, req resource.ReadRequest, r
 		}
 		// 712 is NOT IsNotFound — surface so caller sees state hasn't moved to
 		// another project silently.
-		resp.Diagnostics.AddError("Unable to Read Bucket", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Bucket", client.ErrorDetail(err))
 		return
 	}
 
@@ -343,10 +416,20 @@ func (r *S3BucketResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 	data.ID = types.StringValue(b.Name)
 	data.Name = types.StringValue(b.Name)
+	data.DeletionProtection = deletionProtectionOrDefault(data.DeletionProtection)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setBucketIdentity(ctx, resp.Identity, data.ID, data.Region, data.ProjectTag)...)
 }
 
 func (r *S3BucketResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_s3_bucket", "update")
+	defer op.End(&req.State, &resp.Diagnostics)
+	if updateDeletionProtectionOnly(req, resp) {
+		return
+	}
+	ctx, cancel := withDefaultTimeout(ctx, r.c.DefaultTimeouts.Update)
+	defer cancel()
+
 	var state, plan S3BucketResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -365,7 +448,7 @@ func (r *S3BucketResource) Update(ctx context.Context, req resource.UpdateReques
 
 	if !state.Acl.Equal(plan.Acl) {
 		if err := r.c.PutBucketAcl(ctx, name, client.PutBucketAclRequest{Acl: aclToEnum(plan.Acl.ValueString())}, opts); err != nil {
-			resp.Diagnostics.AddError("Unable to Update Bucket ACL", err.Error())
+			resp.Diagnostics.AddError("Unable to Update Bucket ACL", client.ErrorDetail(err))
 			return
 		}
 	}
@@ -378,14 +461,14 @@ func (r *S3BucketResource) Update(ctx context.Context, req resource.UpdateReques
 		}
 		if err := r.c.PutBucketVersioning(ctx, name,
 			client.PutBucketVersioningRequest{VersioningConfiguration: &client.VersioningConfiguration{Status: status}}, opts); err != nil {
-			resp.Diagnostics.AddError("Unable to Update Bucket Versioning", err.Error())
+			resp.Diagnostics.AddError("Unable to Update Bucket Versioning", client.ErrorDetail(err))
 			return
 		}
 	}
 
 	b, err := r.c.GetBucket(ctx, name, opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read Bucket after Update", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Bucket after Update", client.ErrorDetail(err))
 		return
 	}
 	if rfErr := r.refreshFromServer(ctx, &plan, b, opts); rfErr != nil {
@@ -397,9 +480,18 @@ func (r *S3BucketResource) Update(ctx context.Context, req resource.UpdateReques
 	plan.Region = state.Region
 	plan.ProjectTag = state.ProjectTag
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setBucketIdentity(ctx, resp.Identity, plan.ID, plan.Region, plan.ProjectTag)...)
 }
 
 func (r *S3BucketResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_s3_bucket", "delete")
+	defer op.End(&req.State, &resp.Diagnostics)
+	if refuseProtectedDelete(ctx, "prodata_s3_bucket", req.State, &resp.Diagnostics) {
+		return
+	}
+	ctx, cancel := withDefaultTimeout(ctx, r.c.DefaultTimeouts.Delete)
+	defer cancel()
+
 	var data S3BucketResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -422,7 +514,7 @@ func (r *S3BucketResource) Delete(ctx context.Context, req resource.DeleteReques
 		if client.IsNotFound(err) {
 			return
 		}
-		if client.IsAPIError(err, 738) {
+		if errors.Is(err, client.ErrBucketNotEmpty) {
 			resp.Diagnostics.AddError(
 				"Bucket Not Empty",
 				fmt.Sprintf("Bucket %q still contains objects and cannot be deleted. Empty it first — "+
@@ -431,12 +523,31 @@ func (r *S3BucketResource) Delete(ctx context.Context, req resource.DeleteReques
 			)
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Delete Bucket", err.Error())
+		resp.Diagnostics.AddError("Unable to Delete Bucket", client.ErrorDetail(err))
 		return
 	}
 }
 
 func (r *S3BucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_s3_bucket", "import")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
+	if importByIdentity(req) {
+		var identity bucketIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		identity.Region = types.StringValue(valueOrDefault(identity.Region, r.c.Region))
+		identity.ProjectTag = types.StringValue(valueOrDefault(identity.ProjectTag, r.c.ProjectTag))
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), identity.Region)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_tag"), identity.ProjectTag)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), identity.ID)...)
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+		return
+	}
+
 	region, name, projectTag, ok := parseImportID(req.ID)
 	if !ok {
 		resp.Diagnostics.AddError(
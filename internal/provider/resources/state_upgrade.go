@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// State versioning
+//
+// A resource whose schema changes incompatibly (an attribute removed, renamed or
+// retyped) bumps its schema Version and adds an upgrader keyed by every prior
+// version, each migrating straight to the current schema: Terraform calls exactly
+// one upgrader per resource, with the version the state was written at.
+//
+// The first versioned resources started at 1 because earlier breaking changes
+// shipped without a bump, so a version 0 state may come from either side of them.
+// Their version 0 upgraders therefore rewrite the raw JSON instead of decoding it
+// with a PriorSchema, which can only describe one shape.
+
+// stateAttrs is a resource state object as raw JSON, by attribute name.
+type stateAttrs map[string]json.RawMessage
+
+// upgradeRawState is the body of a StateUpgrader with no PriorSchema. fix, if not
+// nil, rewrites the prior state's top-level attributes in place; anything left
+// that the current schema does not define, at any depth, is then dropped.
+func upgradeRawState(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse, fix func(stateAttrs) error) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State",
+			"The prior state is not in JSON form. Please report this issue to the provider developers.")
+		return
+	}
+	var attrs stateAttrs
+	if err := json.Unmarshal(req.RawState.JSON, &attrs); err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State",
+			fmt.Sprintf("Could not decode the prior state: %s", err))
+		return
+	}
+	if fix != nil {
+		if err := fix(attrs); err != nil {
+			resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+			return
+		}
+	}
+	raw, err := json.Marshal(attrs)
+	if err == nil {
+		raw, err = pruneStateJSON(raw, resp.State.Schema.Type().TerraformType(ctx))
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Resource State",
+			fmt.Sprintf("Could not encode the upgraded state: %s", err))
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: raw}
+}
+
+// pruneStateJSON drops the object attributes in raw that typ does not define,
+// recursing through nested objects and collections. Values of any other type
+// are returned as they are.
+func pruneStateJSON(raw json.RawMessage, typ tftypes.Type) (json.RawMessage, error) {
+	if string(raw) == "null" {
+		return raw, nil
+	}
+	switch t := typ.(type) {
+	case tftypes.Object:
+		var attrs stateAttrs
+		if err := json.Unmarshal(raw, &attrs); err != nil {
+			return nil, err
+		}
+		for name, v := range attrs {
+			attrType, ok := t.AttributeTypes[name]
+			if !ok {
+				delete(attrs, name)
+				continue
+			}
+			pruned, err := pruneStateJSON(v, attrType)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			attrs[name] = pruned
+		}
+		return json.Marshal(attrs)
+	case tftypes.List:
+		return pruneStateElems(raw, t.ElementType)
+	case tftypes.Set:
+		return pruneStateElems(raw, t.ElementType)
+	case tftypes.Map:
+		var elems map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return nil, err
+		}
+		for k := range elems {
+			pruned, err := pruneStateJSON(elems[k], t.ElementType)
+			if err != nil {
+				return nil, err
+			}
+			elems[k] = pruned
+		}
+		return json.Marshal(elems)
+	}
+	return raw, nil
+}
+
+// pruneStateElems is pruneStateJSON for the elements of a list or set.
+func pruneStateElems(raw json.RawMessage, elemType tftypes.Type) (json.RawMessage, error) {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(raw, &elems); err != nil {
+		return nil, err
+	}
+	for i := range elems {
+		pruned, err := pruneStateJSON(elems[i], elemType)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = pruned
+	}
+	return json.Marshal(elems)
+}
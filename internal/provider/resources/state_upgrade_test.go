@@ -0,0 +1,113 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// upgradeState runs r's upgrader for a state written at version from, decoding
+// the result strictly against the current schema as the framework does.
+func upgradeState(t *testing.T, r resource.Resource, from int64, rawJSON string) (tfsdk.State, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Schema.Version <= from {
+		t.Fatalf("schema version %d does not upgrade from %d", schemaResp.Schema.Version, from)
+	}
+	upgrader, ok := r.(resource.ResourceWithUpgradeState).UpgradeState(ctx)[from]
+	if !ok {
+		t.Fatalf("no upgrader from version %d", from)
+	}
+
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(rawJSON)}}
+	resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		return tfsdk.State{}, resp.Diagnostics
+	}
+	raw, err := resp.DynamicValue.Unmarshal(schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		t.Fatalf("upgraded state does not match the current schema: %s", err)
+	}
+	return tfsdk.State{Schema: schemaResp.Schema, Raw: raw}, nil
+}
+
+func TestVmUpgradeState_v0(t *testing.T) {
+	ctx := context.Background()
+	// Before 0.20.0 (with user_data_hash) and since (without): both are version 0.
+	for _, raw := range []string{
+		`{"id":42,"name":"web","region":"UZ-5","project_tag":"default","cpu_cores":2,"user_data_hash":"9f86d0"}`,
+		`{"id":42,"name":"web","region":"UZ-5","project_tag":"default","cpu_cores":2}`,
+	} {
+		state, diags := upgradeState(t, NewVmResource(), 0, raw)
+		if diags.HasError() {
+			t.Fatalf("%s: %v", raw, diags)
+		}
+		var m VmResourceModel
+		if diags := state.Get(ctx, &m); diags.HasError() {
+			t.Fatal(diags)
+		}
+		if m.ID.ValueInt64() != 42 || m.Name.ValueString() != "web" || m.CPUCores.ValueInt64() != 2 {
+			t.Errorf("%s: upgraded to %+v", raw, m)
+		}
+	}
+}
+
+func TestS3BucketUpgradeState_v0(t *testing.T) {
+	ctx := context.Background()
+	for raw, want := range map[string]bool{
+		`{"id":"logs","name":"logs","versioning":"enabled"}`:   true,
+		`{"id":"logs","name":"logs","versioning":"suspended"}`: false,
+		`{"id":"logs","name":"logs","versioning":"disabled"}`:  false,
+		`{"id":"logs","name":"logs","versioning":true}`:        true,
+		`{"id":"logs","name":"logs","versioning":false}`:       false,
+	} {
+		state, diags := upgradeState(t, NewS3BucketResource(), 0, raw)
+		if diags.HasError() {
+			t.Fatalf("%s: %v", raw, diags)
+		}
+		var m S3BucketResourceModel
+		if diags := state.Get(ctx, &m); diags.HasError() {
+			t.Fatal(diags)
+		}
+		if m.Versioning.ValueBool() != want || m.Name.ValueString() != "logs" {
+			t.Errorf("%s: versioning %s, want %t", raw, m.Versioning, want)
+		}
+	}
+
+	if _, diags := upgradeState(t, NewS3BucketResource(), 0, `{"id":"logs","versioning":"sometimes"}`); !diags.HasError() {
+		t.Error("an unrecognized versioning value should fail rather than guess")
+	}
+}
+
+func TestK8sClusterUpgradeState_v0(t *testing.T) {
+	ctx := context.Background()
+	// A pre-0.23.0 cluster: node_subnet set, master_flavor_id required, no
+	// control_plane_size. The unknown default_node_pool attribute checks that
+	// nested attributes are pruned too.
+	raw := `{
+		"id": 7, "name": "prod", "region": "UZ-5", "project_tag": "default",
+		"node_subnet": 24, "master_flavor_id": 11,
+		"default_node_pool": {"name": "default", "node_count": 3, "legacy_flavor": "m1"}
+	}`
+	state, diags := upgradeState(t, NewK8sClusterResource(), 0, raw)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	var m K8sClusterModel
+	if diags := state.Get(ctx, &m); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if m.ID.ValueInt64() != 7 || m.MasterFlavorID.ValueInt64() != 11 || !m.ControlPlaneSize.IsNull() {
+		t.Errorf("upgraded to id %s, master_flavor_id %s, control_plane_size %s", m.ID, m.MasterFlavorID, m.ControlPlaneSize)
+	}
+	if m.DefaultNodePool == nil || m.DefaultNodePool.NodeCount.ValueInt64() != 3 {
+		t.Errorf("default_node_pool = %+v", m.DefaultNodePool)
+	}
+}
@@ -0,0 +1,26 @@
+package resources
+
+import (
+	"context"
+	"time"
+)
+
+// timeoutOr returns d, the provider's default_timeouts value for an operation,
+// or the resource's own default when d is unset. A resource's timeouts block
+// still outranks both.
+func timeoutOr(d, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// withDefaultTimeout bounds ctx by d, the provider's default_timeouts value for
+// an operation the resource has no timeouts attribute for. An unset d leaves
+// ctx without a deadline, as before.
+func withDefaultTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
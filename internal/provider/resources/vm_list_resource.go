@@ -0,0 +1,81 @@
+package resources
+
+import (
+	"context"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ list.ListResource              = &VmListResource{}
+	_ list.ListResourceWithConfigure = &VmListResource{}
+)
+
+// VmListResource lists the VMs in a project. Listed VMs carry what Read would
+// refresh; the credentials and user_data are never reported by the panel and
+// are left null, as after an import.
+type VmListResource struct {
+	listBase
+}
+
+func NewVmListResource() list.ListResource {
+	return &VmListResource{}
+}
+
+func (l *VmListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm"
+}
+
+func (l *VmListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listScopeSchema("virtual machines")
+}
+
+func (l *VmListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_vm", "list")
+	var diags diag.Diagnostics
+	defer op.End(nil, &diags)
+
+	region, projectTag := l.scope(ctx, req.Config, &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	vms, err := l.c.GetVms(ctx, &client.RequestOpts{Region: region, ProjectTag: projectTag})
+	if err != nil {
+		diags.AddError("Unable to List Virtual Machines", client.ErrorDetail(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	tflog.Debug(ctx, "Listed virtual machines", map[string]any{"count": len(vms), "region": region, "project_tag": projectTag})
+
+	items := make([]listItem, 0, len(vms))
+	for i := range vms {
+		vm := &vms[i]
+		items = append(items, listItem{
+			displayName: vm.Name,
+			identity: scopedIdentityModel{
+				ID:         types.Int64Value(vm.ID),
+				Region:     types.StringValue(region),
+				ProjectTag: types.StringValue(projectTag),
+			},
+			resource: func(context.Context, *diag.Diagnostics) any {
+				m := &VmResourceModel{
+					ID:                 types.Int64Value(vm.ID),
+					Region:             types.StringValue(region),
+					ProjectTag:         types.StringValue(projectTag),
+					DeletionProtection: types.BoolValue(false),
+				}
+				applyVmServerState(m, vm)
+				return m
+			},
+		})
+	}
+	stream.Results = streamListItems(ctx, req, items)
+}
@@ -2,16 +2,19 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"time"
 
 	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
 	"terraform-provider-prodata/internal/tfutil"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -25,10 +28,14 @@ import (
 )
 
 var (
-	_ resource.Resource                = &VmResource{}
-	_ resource.ResourceWithConfigure   = &VmResource{}
-	_ resource.ResourceWithModifyPlan  = &VmResource{}
-	_ resource.ResourceWithImportState = &VmResource{}
+	_ resource.Resource                 = &VmResource{}
+	_ resource.ResourceWithConfigure    = &VmResource{}
+	_ resource.ResourceWithModifyPlan   = &VmResource{}
+	_ resource.ResourceWithImportState  = &VmResource{}
+	_ resource.ResourceWithIdentity     = &VmResource{}
+	_ resource.ResourceWithUpgradeState = &VmResource{}
+
+	_ resource.ResourceWithConfigValidators = &VmResource{}
 )
 
 type VmResource struct {
@@ -55,11 +62,19 @@ type VmResourceModel struct {
 	Password       types.String `tfsdk:"password"`
 	SSHPublicKey   types.String `tfsdk:"ssh_public_key"`
 	Description    types.String `tfsdk:"description"`
+	// PasswordWO and SSHPublicKeyWO are write-only alternatives to password and
+	// ssh_public_key: read from config at create, never stored in state. A password
+	// change is signalled by PasswordWOVersion; an SSH key change is detected like
+	// user_data, by a sha256 in private state.
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	SSHPublicKeyWO    types.String `tfsdk:"ssh_public_key_wo"`
 	// UserData is write-only: read from config at create, never stored in state. Change
 	// detection is provider-computed (sha256 in private state), so there is no hash field.
-	UserData types.String   `tfsdk:"user_data"`
-	Status   types.String   `tfsdk:"status"`
-	Timeouts timeouts.Value `tfsdk:"timeouts"`
+	UserData           types.String   `tfsdk:"user_data"`
+	Status             types.String   `tfsdk:"status"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 func NewVmResource() resource.Resource {
@@ -72,6 +87,8 @@ func (r *VmResource) Metadata(ctx context.Context, req resource.MetadataRequest,
 
 func (r *VmResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1: user_data_hash removed (0.20.0). See UpgradeState.
+		Version:             1,
 		MarkdownDescription: "Manages a ProData virtual machine.",
 
 		Attributes: map[string]schema.Attribute{
@@ -202,14 +219,48 @@ func (r *VmResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 				Optional:            true,
 				Sensitive:           true,
 				PlanModifiers: []planmodifier.String{
-					WriteOnceString(),
+					writeOnceCredential("password_wo"),
 				},
 			},
 			"ssh_public_key": schema.StringAttribute{
 				MarkdownDescription: "SSH public key for authentication (optional). Write-only: not read back from API.",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
-					WriteOnceString(),
+					writeOnceCredential("ssh_public_key_wo"),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				MarkdownDescription: "The password for the virtual machine, as a **write-only** alternative to " +
+					"`password`: it is never stored in Terraform state nor shown in a plan (this requires " +
+					"Terraform >= 1.11). Set `password_wo_version` with it, and change the version to apply a " +
+					"new password. The API accepts a password only at create, so a new version **replaces** " +
+					"the VM. Conflicts with `password`.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `password_wo`. Terraform cannot see a write-only value change, " +
+					"so change this number whenever `password_wo` changes; doing so replaces the VM. Required " +
+					"with `password_wo`. A value set for the first time after import is adopted without replacement.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Int64{
+					WriteOnceInt64(),
+				},
+			},
+			"ssh_public_key_wo": schema.StringAttribute{
+				MarkdownDescription: "SSH public key for authentication, as a **write-only** alternative to " +
+					"`ssh_public_key`: it is never stored in Terraform state (this requires Terraform >= 1.11). " +
+					"The provider detects changes by hashing the key (sha256) and **replaces** the VM when it " +
+					"changes, as the API accepts a key only at create. Removing it from configuration does not " +
+					"replace the VM. Conflicts with `ssh_public_key`.",
+				Optional:  true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
 				},
 			},
 			"description": schema.StringAttribute{
@@ -242,6 +293,7 @@ func (r *VmResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute("virtual machine"),
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
 			}),
@@ -250,6 +302,8 @@ func (r *VmResource) Schema(ctx context.Context, req resource.SchemaRequest, res
 }
 
 func (r *VmResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer checkDeletionProtection(ctx, "prodata_vm", req, resp)
+
 	// Destroying — nothing to plan.
 	if req.Plan.Raw.IsNull() {
 		return
@@ -305,12 +359,38 @@ func (r *VmResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequ
 		)
 	}
 
+	// ssh_public_key_wo follows the same scheme as user_data, against its own baseline.
+	sshState := classifyUserData(configData.SSHPublicKeyWO)
+	var sshHexNow string
+	if sshState == userDataPresent {
+		sshHexNow = userDataHashHex(configData.SSHPublicKeyWO.ValueString())
+	}
+	sshBlob, diags := req.Private.GetKey(ctx, sshPublicKeyWOHashPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sshStoredHash, sshStoredOK := unmarshalUserDataHash(sshBlob)
+	sshKeyReplace := userDataReplaceNeeded(sshStoredHash, sshStoredOK, sshState, sshHexNow)
+	if sshKeyReplace && !stateData.SSHPublicKey.IsNull() &&
+		stateData.SSHPublicKey.ValueString() == configData.SSHPublicKeyWO.ValueString() {
+		// The key the VM was created with, moved from ssh_public_key: adopt it as the baseline.
+		sshKeyReplace = false
+		if hashBlob, mErr := marshalUserDataHash(sshHexNow); mErr == nil {
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, sshPublicKeyWOHashPrivateKey, hashBlob)...)
+		}
+	}
+	if sshKeyReplace {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("ssh_public_key_wo"))
+	}
+
 	// Determine whether ANY attribute forces replacement, to warn about the
 	// create_before_destroy + same-name uniqueness constraint. The image/network/etc.
 	// replacements are driven by their own RequiresReplace plan modifiers; we mirror the
 	// readable ones here (write-only password/ssh are null in state post-import, so are
-	// excluded) plus the user_data signal computed above.
-	requiresReplace := userDataReplace ||
+	// excluded) plus the user_data and ssh_public_key_wo signals computed above.
+	requiresReplace := userDataReplace || sshKeyReplace ||
+		(!stateData.PasswordWOVersion.IsNull() && !stateData.PasswordWOVersion.Equal(planData.PasswordWOVersion)) ||
 		!stateData.ImageID.Equal(planData.ImageID) ||
 		!stateData.LocalNetworkID.Equal(planData.LocalNetworkID) ||
 		!stateData.PrivateIP.Equal(planData.PrivateIP) ||
@@ -333,6 +413,34 @@ func (r *VmResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequ
 	}
 }
 
+// ConfigValidators keeps each credential to one of its attribute forms, and the
+// write-only password paired with the version that signals its changes.
+func (r *VmResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("password"), path.MatchRoot("password_wo")),
+		resourcevalidator.Conflicting(path.MatchRoot("ssh_public_key"), path.MatchRoot("ssh_public_key_wo")),
+		resourcevalidator.RequiredTogether(path.MatchRoot("password_wo"), path.MatchRoot("password_wo_version")),
+	}
+}
+
+// UpgradeState migrates version 0 state, written before and after 0.20.0
+// removed user_data_hash, by dropping the attribute if it is there. The hash
+// baseline it held cannot be carried into private state from an upgrader; as the
+// 0.20.0 notes say, it is re-established on the next create or replace.
+func (r *VmResource) UpgradeState(context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgradeRawState(ctx, req, resp, nil)
+			},
+		},
+	}
+}
+
+func (r *VmResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = scopedIdentitySchema("virtual machine")
+}
+
 func (r *VmResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -351,7 +459,6 @@ func (r *VmResource) Configure(ctx context.Context, req resource.ConfigureReques
 }
 
 const (
-	vmPollInterval = 5 * time.Second
 	// vmDefaultCreateTime is a CEILING, not a fixed wait: the provider polls and returns
 	// as soon as the VM is ready, so the common Linux path (~10-12m) is unaffected by a
 	// higher value. The 30m default covers the longer in-guest cloud-init wait on Windows
@@ -361,7 +468,7 @@ const (
 )
 
 // waitForVmReady polls the VM until it reaches a terminal state (RUNNING, STOPPED, or ERROR).
-// Tolerates up to 3 consecutive transient polling errors. The overall deadline is carried by
+// Tolerates transient polling errors like every VM wait. The overall deadline is carried by
 // ctx (the caller wraps it with context.WithTimeout using the timeouts{} create value), so a
 // VM whose first boot runs a long cloud-init is bounded by that single timeout.
 //
@@ -369,76 +476,57 @@ const (
 // cloud-init failed still reports RUNNING — so reaching RUNNING here does not prove the
 // user_data script succeeded.
 func (r *VmResource) waitForVmReady(ctx context.Context, vmID int64, opts *client.RequestOpts, expectCPU, expectRAM, expectDisk int64) (*client.Vm, error) {
-	const (
-		maxConsecutiveErrs = 3
-		// maxSettleAttempts bounds how long we wait, after the VM is already RUNNING/
-		// STOPPED, for the readback cpu/ram/disk to match the request before returning.
-		// It eliminates the brief post-create transient where the endpoint reports an
-		// intermediate value (which a racing refresh would surface as a spurious diff).
-		// Non-convergence is never fatal — we proceed once this budget is spent.
-		maxSettleAttempts = 6
-	)
-
-	consecutiveErrs := 0
+	// maxSettleAttempts bounds how long we wait, after the VM is already RUNNING/
+	// STOPPED, for the readback cpu/ram/disk to match the request before returning.
+	// It eliminates the brief post-create transient where the endpoint reports an
+	// intermediate value (which a racing refresh would surface as a spurious diff).
+	// Non-convergence is never fatal — we proceed once this budget is spent.
+	const maxSettleAttempts = 6
 	settleAttempts := 0
 
-	for {
-		if err := ctx.Err(); err != nil {
-			return nil, fmt.Errorf("timed out waiting for VM %d to become ready: %w", vmID, err)
-		}
-
-		vm, err := r.client.GetVmStatus(ctx, vmID, opts)
-		if err != nil {
-			consecutiveErrs++
-			tflog.Warn(ctx, "Transient error polling VM status", map[string]any{
-				"id":                 vmID,
-				"error":              err.Error(),
-				"consecutive_errors": consecutiveErrs,
-			})
-			if consecutiveErrs >= maxConsecutiveErrs {
-				return nil, fmt.Errorf("polling VM %d status: %w (after %d consecutive failures)", vmID, err, consecutiveErrs)
+	w := &client.Waiter[*client.Vm]{
+		Name:    fmt.Sprintf("VM %d", vmID),
+		Op:      "vm_ready",
+		ID:      vmID,
+		Refresh: r.client.VmStatusRefresh(vmID, opts),
+		Target:  []string{"RUNNING", "STOPPED"},
+		Failure: []string{"ERROR"},
+		// Settle: wait (bounded) for the readback to match the requested sizing so
+		// the first refresh doesn't catch a transient intermediate value. Skip the
+		// check when no expectation was given; never fail on non-convergence.
+		Ready: func(vm *client.Vm, _ string) bool {
+			if (expectCPU == 0 && expectRAM == 0 && expectDisk == 0) ||
+				(vm.CPUCores == expectCPU && vm.RAM == expectRAM && vm.DiskSize >= expectDisk) {
+				return true
 			}
-		} else {
-			consecutiveErrs = 0
-
-			tflog.Debug(ctx, "Polling VM status", map[string]any{
-				"id":     vmID,
-				"status": vm.Status,
-			})
-
-			switch vm.Status {
-			case "RUNNING", "STOPPED":
-				// Settle: wait (bounded) for the readback to match the requested sizing so
-				// the first refresh doesn't catch a transient intermediate value. Skip the
-				// check when no expectation was given; never fail on non-convergence.
-				if (expectCPU == 0 && expectRAM == 0 && expectDisk == 0) ||
-					(vm.CPUCores == expectCPU && vm.RAM == expectRAM && vm.DiskSize >= expectDisk) {
-					return vm, nil
-				}
-				settleAttempts++
-				if settleAttempts >= maxSettleAttempts {
-					tflog.Warn(ctx, "VM reached terminal status but cpu/ram/disk did not settle to the requested values within the settle window; proceeding", map[string]any{
-						"id":       vmID,
-						"want_cpu": expectCPU, "got_cpu": vm.CPUCores,
-						"want_ram": expectRAM, "got_ram": vm.RAM,
-						"want_disk": expectDisk, "got_disk": vm.DiskSize,
-					})
-					return vm, nil
-				}
-			case "ERROR":
-				return vm, fmt.Errorf("VM creation failed (id=%d, status=ERROR)", vmID)
+			settleAttempts++
+			if settleAttempts < maxSettleAttempts {
+				return false
 			}
-		}
-
-		select {
-		case <-ctx.Done():
-			return nil, fmt.Errorf("timed out waiting for VM %d to become ready: %w", vmID, ctx.Err())
-		case <-time.After(vmPollInterval):
-		}
+			tflog.Warn(ctx, "VM reached terminal status but cpu/ram/disk did not settle to the requested values within the settle window; proceeding", map[string]any{
+				"id":       vmID,
+				"want_cpu": expectCPU, "got_cpu": vm.CPUCores,
+				"want_ram": expectRAM, "got_ram": vm.RAM,
+				"want_disk": expectDisk, "got_disk": vm.DiskSize,
+			})
+			return true
+		},
+		MaxConsecutiveErrors: r.client.Retry.PollErrorToleranceOr(client.VmWaitMaxConsecutiveErrors),
+		MinTimeout:           client.VmWaitMinTimeout,
+		MaxPollInterval:      client.VmWaitMaxPollInterval,
 	}
+	vm, err := w.Wait(ctx)
+	var stateErr *client.UnexpectedStateError
+	if errors.As(err, &stateErr) {
+		return vm, fmt.Errorf("VM creation failed (id=%d, status=%s)", vmID, stateErr.State)
+	}
+	return vm, err
 }
 
 func (r *VmResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_vm", "create")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
 	var data VmResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -446,11 +534,24 @@ func (r *VmResource) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
+	// The write-only attributes (user_data, password_wo, ssh_public_key_wo) are null in
+	// the plan, so read them from the config. They are never written back to state
+	// (enforced before State.Set below).
+	var configData VmResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Validate password is provided (Required for creation, Optional for import)
-	if data.Password.IsNull() || data.Password.ValueString() == "" {
+	password := data.Password
+	if !configData.PasswordWO.IsNull() && !configData.PasswordWO.IsUnknown() {
+		password = configData.PasswordWO
+	}
+	if password.IsNull() || password.ValueString() == "" {
 		resp.Diagnostics.AddError(
 			"Password Required",
-			"The password attribute is required when creating a new virtual machine.",
+			"The password or password_wo attribute is required when creating a new virtual machine.",
 		)
 		return
 	}
@@ -458,7 +559,7 @@ func (r *VmResource) Create(ctx context.Context, req resource.CreateRequest, res
 	// Bound the entire create — the async create call plus the subsequent polling that
 	// waits out the backend's in-guest cloud-init wait — by the create timeout (default
 	// 30m, overridable via the timeouts{} block).
-	createTimeout, diags := data.Timeouts.Create(ctx, vmDefaultCreateTime)
+	createTimeout, diags := data.Timeouts.Create(ctx, timeoutOr(r.client.DefaultTimeouts.Create, vmDefaultCreateTime))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -486,7 +587,7 @@ func (r *VmResource) Create(ctx context.Context, req resource.CreateRequest, res
 		DiskSize:       data.DiskSize.ValueInt64(),
 		DiskType:       data.DiskType.ValueString(),
 		LocalNetworkID: data.LocalNetworkID.ValueInt64(),
-		Password:       data.Password.ValueString(),
+		Password:       password.ValueString(),
 	}
 
 	if !data.PrivateIP.IsNull() && !data.PrivateIP.IsUnknown() {
@@ -503,19 +604,15 @@ func (r *VmResource) Create(ctx context.Context, req resource.CreateRequest, res
 		sshKey := data.SSHPublicKey.ValueString()
 		createReq.SSHPublicKey = &sshKey
 	}
+	if !configData.SSHPublicKeyWO.IsNull() && !configData.SSHPublicKeyWO.IsUnknown() {
+		createReq.SSHPublicKey = configData.SSHPublicKeyWO.ValueStringPointer()
+	}
 
 	if !data.Description.IsNull() && !data.Description.IsUnknown() {
 		desc := data.Description.ValueString()
 		createReq.Description = &desc
 	}
 
-	// user_data is write-only: it is null in the plan, so read it from the config and
-	// forward it. It is never written back to state (enforced before State.Set below).
-	var configData VmResourceModel
-	resp.Diagnostics.Append(req.Config.Get(ctx, &configData)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
 	if !configData.UserData.IsNull() && !configData.UserData.IsUnknown() {
 		createReq.UserData = configData.UserData.ValueStringPointer()
 	}
@@ -534,7 +631,7 @@ func (r *VmResource) Create(ctx context.Context, req resource.CreateRequest, res
 	})
 
 	createVm := func() (*client.Vm, error) {
-		return client.RetryOnBusy(ctx, client.RetryTimeoutLong, func() (*client.Vm, error) {
+		return client.RetryOnBusy(ctx, r.client.Retry.BusyTimeoutOr(client.RetryTimeoutLong), func() (*client.Vm, error) {
 			return r.client.CreateVm(ctx, createReq)
 		})
 	}
@@ -543,7 +640,7 @@ func (r *VmResource) Create(ctx context.Context, req resource.CreateRequest, res
 
 	// Error 666: name conflict — likely a create_before_destroy replacement.
 	// Rename the existing VM, then retry.
-	if err != nil && client.IsAPIError(err, 666) {
+	if err != nil && errors.Is(err, client.ErrVmNameTaken) {
 		tflog.Info(ctx, "VM name conflict detected, attempting to rename existing VM", map[string]any{
 			"name": createReq.Name,
 		})
@@ -597,7 +694,7 @@ func (r *VmResource) Create(ctx context.Context, req resource.CreateRequest, res
 		}
 	}
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Create Virtual Machine", err.Error())
+		resp.Diagnostics.AddError("Unable to Create Virtual Machine", client.ErrorDetail(err))
 		return
 	}
 
@@ -658,12 +755,15 @@ func (r *VmResource) Create(ctx context.Context, req resource.CreateRequest, res
 		data.Description = types.StringValue(resultVm.Description)
 	}
 
-	// user_data is write-only — guarantee the raw payload never reaches state, on the
-	// success path and the error path below.
+	// user_data, password_wo and ssh_public_key_wo are write-only — guarantee the raw
+	// values never reach state, on the success path and the error path below.
 	data.UserData = types.StringNull()
+	data.PasswordWO = types.StringNull()
+	data.SSHPublicKeyWO = types.StringNull()
 
 	// Save state BEFORE returning error — prevents desync on retry
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, data.ID, data.Region, data.ProjectTag)...)
 
 	// Seed the user_data change-detection baseline (sha256 of the write-only payload) in
 	// private state, on the same save as state — including the error-but-created path below
@@ -681,6 +781,14 @@ func (r *VmResource) Create(ctx context.Context, req resource.CreateRequest, res
 	if hashBlob, mErr := marshalUserDataHash(userDataHashHex(userDataPayload)); mErr == nil {
 		resp.Diagnostics.Append(resp.Private.SetKey(ctx, userDataHashPrivateKey, hashBlob)...)
 	}
+	// Same for ssh_public_key_wo, with sha256("") for a VM created without it.
+	sshKeyPayload := ""
+	if !configData.SSHPublicKeyWO.IsNull() && !configData.SSHPublicKeyWO.IsUnknown() {
+		sshKeyPayload = configData.SSHPublicKeyWO.ValueString()
+	}
+	if hashBlob, mErr := marshalUserDataHash(userDataHashHex(sshKeyPayload)); mErr == nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, sshPublicKeyWOHashPrivateKey, hashBlob)...)
+	}
 
 	if waitErr != nil {
 		resp.Diagnostics.AddError(
@@ -698,6 +806,11 @@ func (r *VmResource) Create(ctx context.Context, req resource.CreateRequest, res
 }
 
 func (r *VmResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_vm", "read")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Read)
+	defer cancel()
+
 	var data VmResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -732,62 +845,21 @@ func (r *VmResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Read Virtual Machine", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Virtual Machine", client.ErrorDetail(err))
 		return
 	}
 
-	data.Guid = tfutil.StringOrNull(vm.Guid)
-	data.Name = types.StringValue(vm.Name)
-	data.Status = types.StringValue(vm.Status)
-	data.CPUCores = types.Int64Value(vm.CPUCores)
-	data.RAM = types.Int64Value(vm.RAM)
-	data.DiskSize = types.Int64Value(vm.DiskSize)
-	data.DiskType = types.StringValue(vm.DiskType)
-	data.PrivateIP = types.StringValue(vm.PrivateIP)
-	data.LocalNetworkID = types.Int64Value(vm.LocalNetworkID)
-
-	// Populate image fields from API (supports import + drift detection)
-	if vm.ImageID != 0 {
-		data.ImageID = types.Int64Value(vm.ImageID)
-	}
-	if vm.ImageName != "" {
-		data.ImageName = types.StringValue(vm.ImageName)
-	} else {
-		data.ImageName = types.StringNull()
-	}
-	if vm.ImageSlug != "" {
-		data.ImageSlug = types.StringValue(vm.ImageSlug)
-	} else {
-		data.ImageSlug = types.StringNull()
-	}
-
-	if vm.PublicIP != "" {
-		data.PublicIP = types.StringValue(vm.PublicIP)
-	} else {
-		data.PublicIP = types.StringNull()
-	}
-
-	if vm.Description != "" {
-		data.Description = types.StringValue(vm.Description)
-	} else if !data.Description.IsNull() {
-		data.Description = types.StringNull()
-	}
+	applyVmServerState(&data, vm)
 
 	// Restore write-only attributes (never returned by API)
 	data.Password = password
 	data.SSHPublicKey = sshPublicKey
 	// user_data is write-only — kept null in state. The change-detection baseline lives in
-	// private state (untouched here; Read has no config to recompute it).
+	// private state (untouched here; Read has no config to recompute it). The same holds
+	// for password_wo and ssh_public_key_wo.
 	data.UserData = types.StringNull()
-
-	// public_ip_id: always reflect what the API reports so import works correctly.
-	// Computed+UseStateForUnknown ensures that if the user omits it from config,
-	// Terraform keeps the state value without showing a diff.
-	if vm.PublicIPID != 0 {
-		data.PublicIPID = types.Int64Value(vm.PublicIPID)
-	} else {
-		data.PublicIPID = types.Int64Null()
-	}
+	data.PasswordWO = types.StringNull()
+	data.SSHPublicKeyWO = types.StringNull()
 
 	tflog.Debug(ctx, "Read virtual machine", map[string]any{
 		"id":     vmID,
@@ -795,10 +867,53 @@ func (r *VmResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 		"status": vm.Status,
 	})
 
+	data.DeletionProtection = deletionProtectionOrDefault(data.DeletionProtection)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, data.ID, data.Region, data.ProjectTag)...)
+}
+
+// applyVmServerState copies everything the panel reports about vm onto m. It is
+// the whole of Read's refresh, and what the prodata_vm list resource returns;
+// the credentials and user_data are never reported and are left untouched.
+func applyVmServerState(m *VmResourceModel, vm *client.Vm) {
+	m.Guid = tfutil.StringOrNull(vm.Guid)
+	m.Name = types.StringValue(vm.Name)
+	m.Status = types.StringValue(vm.Status)
+	m.CPUCores = types.Int64Value(vm.CPUCores)
+	m.RAM = types.Int64Value(vm.RAM)
+	m.DiskSize = types.Int64Value(vm.DiskSize)
+	m.DiskType = types.StringValue(vm.DiskType)
+	m.PrivateIP = types.StringValue(vm.PrivateIP)
+	m.LocalNetworkID = types.Int64Value(vm.LocalNetworkID)
+
+	// Populate image fields from API (supports import + drift detection)
+	if vm.ImageID != 0 {
+		m.ImageID = types.Int64Value(vm.ImageID)
+	}
+	m.ImageName = tfutil.StringOrNull(vm.ImageName)
+	m.ImageSlug = tfutil.StringOrNull(vm.ImageSlug)
+	m.PublicIP = tfutil.StringOrNull(vm.PublicIP)
+	m.Description = tfutil.StringOrNull(vm.Description)
+
+	// public_ip_id: always reflect what the API reports so import works correctly.
+	// Computed+UseStateForUnknown ensures that if the user omits it from config,
+	// Terraform keeps the state value without showing a diff.
+	if vm.PublicIPID != 0 {
+		m.PublicIPID = types.Int64Value(vm.PublicIPID)
+	} else {
+		m.PublicIPID = types.Int64Null()
+	}
 }
 
 func (r *VmResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_vm", "update")
+	defer op.End(&req.State, &resp.Diagnostics)
+	if updateDeletionProtectionOnly(req, resp) {
+		return
+	}
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Update)
+	defer cancel()
+
 	var state, plan VmResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
@@ -829,7 +944,7 @@ func (r *VmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 
 		err := r.client.RenameVm(ctx, vmID, client.RenameVmRequest{Name: newName}, opts)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to Rename Virtual Machine", err.Error())
+			resp.Diagnostics.AddError("Unable to Rename Virtual Machine", client.ErrorDetail(err))
 			return
 		}
 	}
@@ -855,7 +970,7 @@ func (r *VmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		// Stop once before all updates
 		needsRestart, err := r.stopIfRunning(ctx, vmID, opts)
 		if err != nil {
-			resp.Diagnostics.AddError("Unable to Stop VM for update", err.Error())
+			resp.Diagnostics.AddError("Unable to Stop VM for update", client.ErrorDetail(err))
 			return
 		}
 
@@ -879,7 +994,7 @@ func (r *VmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 					tflog.Warn(ctx, "Resource update failed, attempting to restart VM", map[string]any{"id": vmID})
 					_ = r.client.StartVm(ctx, vmID, opts)
 				}
-				resp.Diagnostics.AddError("Unable to Update VM Resources", err.Error())
+				resp.Diagnostics.AddError("Unable to Update VM Resources", client.ErrorDetail(err))
 				return
 			}
 
@@ -915,7 +1030,7 @@ func (r *VmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 					tflog.Warn(ctx, "Disk update failed, attempting to restart VM", map[string]any{"id": vmID})
 					_ = r.client.StartVm(ctx, vmID, opts)
 				}
-				resp.Diagnostics.AddError("Unable to Update VM Disk", err.Error())
+				resp.Diagnostics.AddError("Unable to Update VM Disk", client.ErrorDetail(err))
 				return
 			}
 
@@ -942,7 +1057,7 @@ func (r *VmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	// partially-applied state persisted, instead of failing the read and losing state.
 	vm, err := r.client.GetVmStatus(ctx, state.ID.ValueInt64(), opts)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Read Virtual Machine after update", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Virtual Machine after update", client.ErrorDetail(err))
 		return
 	}
 
@@ -996,9 +1111,18 @@ func (r *VmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, plan.ID, plan.Region, plan.ProjectTag)...)
 }
 
 func (r *VmResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_vm", "import")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
+	if importByIdentity(req) {
+		importScopedIdentity(ctx, req, resp, r.client.Region, r.client.ProjectTag)
+		return
+	}
+
 	id, err := strconv.ParseInt(req.ID, 10, 64)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -1017,6 +1141,14 @@ func (r *VmResource) ImportState(ctx context.Context, req resource.ImportStateRe
 }
 
 func (r *VmResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_vm", "delete")
+	defer op.End(&req.State, &resp.Diagnostics)
+	if refuseProtectedDelete(ctx, "prodata_vm", req.State, &resp.Diagnostics) {
+		return
+	}
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Delete)
+	defer cancel()
+
 	var data VmResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -1041,7 +1173,7 @@ func (r *VmResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 		if client.IsNotFound(err) {
 			return // already gone
 		}
-		resp.Diagnostics.AddError("Unable to Delete Virtual Machine", err.Error())
+		resp.Diagnostics.AddError("Unable to Delete Virtual Machine", client.ErrorDetail(err))
 		return
 	}
 
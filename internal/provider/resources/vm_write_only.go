@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sshPublicKeyWOHashPrivateKey is the resource private-state key holding the sha256 (hex) of
+// the write-only ssh_public_key_wo applied at create. It uses the user_data helpers, which
+// are agnostic of the payload they hash.
+//
+// password_wo deliberately has no such baseline: an unsalted digest of a password in state
+// would be open to offline guessing, which is what keeping it out of state avoids. Its
+// changes are signalled by password_wo_version instead.
+const sshPublicKeyWOHashPrivateKey = "ssh_public_key_wo_sha256"
+
+// writeOnceCredential is WriteOnceString for password and ssh_public_key, whose value may
+// move to the write-only attribute woAttr. Moving the credential the VM was created with is
+// a state-only update: the stored value turns null, but the VM keeps it. Moving a different
+// value still replaces the VM, as the API accepts credentials only at create; so does a
+// write-only value not yet known at plan time.
+//
+// This has to be a plan modifier: ModifyPlan cannot take back a replacement that an
+// attribute's plan modifiers asked for.
+func writeOnceCredential(woAttr string) planmodifier.String {
+	return writeOnceCredentialModifier{woAttr: woAttr}
+}
+
+type writeOnceCredentialModifier struct {
+	woAttr string
+}
+
+func (m writeOnceCredentialModifier) Description(ctx context.Context) string {
+	return WriteOnceString().Description(ctx) + " Moving the same value to " + m.woAttr + " does not trigger replacement."
+}
+
+func (m writeOnceCredentialModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m writeOnceCredentialModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	WriteOnceString().PlanModifyString(ctx, req, resp)
+	if !resp.RequiresReplace || !req.ConfigValue.IsNull() {
+		return
+	}
+	var wo types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(m.woAttr), &wo)...)
+	if wo.IsUnknown() || wo.IsNull() {
+		return
+	}
+	if wo.ValueString() == req.StateValue.ValueString() {
+		resp.RequiresReplace = false
+	}
+}
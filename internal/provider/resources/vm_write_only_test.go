@@ -0,0 +1,74 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// vmConfig is a prodata_vm config with every attribute null except attrs.
+func vmConfig(t *testing.T, attrs map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	NewVmResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	typ := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	vals := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		vals[name] = tftypes.NewValue(attrType, nil)
+	}
+	for name, v := range attrs {
+		vals[name] = v
+	}
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(typ, vals)}
+}
+
+func TestWriteOnceCredential(t *testing.T) {
+	ctx := context.Background()
+	str := func(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+	unknown := tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+
+	cases := []struct {
+		name   string
+		config map[string]tftypes.Value
+		want   bool
+	}{
+		{"password unchanged", map[string]tftypes.Value{"password": str("s3cret")}, false},
+		{"password changed", map[string]tftypes.Value{"password": str("other")}, true},
+		{"moved to password_wo unchanged", map[string]tftypes.Value{"password_wo": str("s3cret")}, false},
+		{"moved to a different password_wo", map[string]tftypes.Value{"password_wo": str("other")}, true},
+		{"moved to a password_wo not yet known", map[string]tftypes.Value{"password_wo": unknown}, true},
+		{"dropped without password_wo", nil, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := vmConfig(t, tc.config)
+			var configValue types.String
+			if diags := config.GetAttribute(ctx, path.Root("password"), &configValue); diags.HasError() {
+				t.Fatal(diags)
+			}
+			req := planmodifier.StringRequest{
+				Path:        path.Root("password"),
+				Config:      config,
+				ConfigValue: configValue,
+				PlanValue:   configValue,
+				State:       tfsdk.State{Raw: vmConfig(t, nil).Raw},
+				StateValue:  types.StringValue("s3cret"),
+			}
+			var resp planmodifier.StringResponse
+			writeOnceCredential("password_wo").PlanModifyString(ctx, req, &resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if resp.RequiresReplace != tc.want {
+				t.Errorf("RequiresReplace = %t, want %t", resp.RequiresReplace, tc.want)
+			}
+		})
+	}
+}
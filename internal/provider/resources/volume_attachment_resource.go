@@ -2,12 +2,14 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -23,6 +25,7 @@ var (
 	_ resource.Resource                = &VolumeAttachmentResource{}
 	_ resource.ResourceWithConfigure   = &VolumeAttachmentResource{}
 	_ resource.ResourceWithImportState = &VolumeAttachmentResource{}
+	_ resource.ResourceWithIdentity    = &VolumeAttachmentResource{}
 )
 
 type VolumeAttachmentResource struct {
@@ -95,6 +98,13 @@ func (r *VolumeAttachmentResource) Schema(ctx context.Context, req resource.Sche
 	}
 }
 
+func (r *VolumeAttachmentResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = compositeIdentitySchema(map[string]string{
+		"vm_id":     "ID of the virtual machine.",
+		"volume_id": "ID of the volume attached to it.",
+	})
+}
+
 func (r *VolumeAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -113,6 +123,11 @@ func (r *VolumeAttachmentResource) Configure(ctx context.Context, req resource.C
 }
 
 func (r *VolumeAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_volume_attachment", "create")
+	defer op.End(&resp.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Create)
+	defer cancel()
+
 	var data VolumeAttachmentResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -131,11 +146,11 @@ func (r *VolumeAttachmentResource) Create(ctx context.Context, req resource.Crea
 		"volume_id": attachReq.VolumeID,
 	})
 
-	volume, err := client.RetryOnBusy(ctx, client.RetryTimeoutLong, func() (*client.Volume, error) {
+	volume, err := client.RetryOnBusy(ctx, r.client.Retry.BusyTimeoutOr(client.RetryTimeoutLong), func() (*client.Volume, error) {
 		return r.client.AttachVolume(ctx, vmID, attachReq, opts)
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Attach Volume", err.Error())
+		resp.Diagnostics.AddError("Unable to Attach Volume", client.ErrorDetail(err))
 		return
 	}
 
@@ -158,9 +173,20 @@ func (r *VolumeAttachmentResource) Create(ctx context.Context, req resource.Crea
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, volumeAttachmentIdentityModel{
+		VmID:       data.VmID,
+		VolumeID:   data.VolumeID,
+		Region:     types.StringValue(valueOrDefault(data.Region, r.client.Region)),
+		ProjectTag: types.StringValue(valueOrDefault(data.ProjectTag, r.client.ProjectTag)),
+	})...)
 }
 
 func (r *VolumeAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_volume_attachment", "read")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Read)
+	defer cancel()
+
 	var data VolumeAttachmentResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -194,7 +220,7 @@ func (r *VolumeAttachmentResource) Read(ctx context.Context, req resource.ReadRe
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Read Volume Attachment", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Volume Attachment", client.ErrorDetail(err))
 		return
 	}
 
@@ -247,9 +273,18 @@ func (r *VolumeAttachmentResource) Read(ctx context.Context, req resource.ReadRe
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setIdentity(ctx, resp.Identity, volumeAttachmentIdentityModel{
+		VmID:       data.VmID,
+		VolumeID:   data.VolumeID,
+		Region:     types.StringValue(valueOrDefault(data.Region, r.client.Region)),
+		ProjectTag: types.StringValue(valueOrDefault(data.ProjectTag, r.client.ProjectTag)),
+	})...)
 }
 
 func (r *VolumeAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	_, op := telemetry.StartOperation(ctx, "prodata_volume_attachment", "update")
+	defer op.End(&req.State, &resp.Diagnostics)
+
 	resp.Diagnostics.AddError(
 		"Update Not Supported",
 		"All attributes of prodata_volume_attachment require replacement. This is a bug in the provider.",
@@ -257,6 +292,11 @@ func (r *VolumeAttachmentResource) Update(ctx context.Context, req resource.Upda
 }
 
 func (r *VolumeAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_volume_attachment", "delete")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Delete)
+	defer cancel()
+
 	var data VolumeAttachmentResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -295,7 +335,7 @@ func (r *VolumeAttachmentResource) Delete(ctx context.Context, req resource.Dele
 				tflog.Info(ctx, "VM not found, volume attachment already gone", map[string]any{"vm_id": vmID})
 				return
 			}
-			resp.Diagnostics.AddError("Unable to Read VM status", err.Error())
+			resp.Diagnostics.AddError("Unable to Read VM status", client.ErrorDetail(err))
 			return
 		}
 
@@ -309,14 +349,14 @@ func (r *VolumeAttachmentResource) Delete(ctx context.Context, req resource.Dele
 				}
 				// 627 = VM locked by another operation (concurrent detach), retry
 				// 711 = VM became running between our check and the API call, retry
-				if client.IsAPIError(err, 627) || client.IsAPIError(err, 711) {
+				if errors.Is(err, client.ErrUnhandled) || errors.Is(err, client.ErrVmMustBeStopped) {
 					tflog.Info(ctx, "Detach blocked by concurrent operation, retrying", map[string]any{
 						"vm_id": vmID, "error": err.Error(),
 					})
 					sleepWithContext(ctx)
 					continue
 				}
-				resp.Diagnostics.AddError("Unable to Detach Volume", err.Error())
+				resp.Diagnostics.AddError("Unable to Detach Volume", client.ErrorDetail(err))
 				return
 			}
 			goto detached
@@ -325,12 +365,12 @@ func (r *VolumeAttachmentResource) Delete(ctx context.Context, req resource.Dele
 			tflog.Info(ctx, "VM is running, stopping before volume detach", map[string]any{"vm_id": vmID})
 			if err := r.client.StopVm(ctx, vmID, opts); err != nil {
 				// 627 = VM already being stopped/operated on by concurrent detach
-				if client.IsAPIError(err, 627) {
+				if errors.Is(err, client.ErrUnhandled) {
 					tflog.Info(ctx, "VM is busy (stop rejected), will re-check status", map[string]any{"vm_id": vmID})
 					sleepWithContext(ctx)
 					continue
 				}
-				resp.Diagnostics.AddError("Unable to Stop VM for volume detach", err.Error())
+				resp.Diagnostics.AddError("Unable to Stop VM for volume detach", client.ErrorDetail(err))
 				return
 			}
 			sleepWithContext(ctx)
@@ -349,7 +389,7 @@ func (r *VolumeAttachmentResource) Delete(ctx context.Context, req resource.Dele
 				if client.IsNotFound(err) {
 					goto detached
 				}
-				resp.Diagnostics.AddError("Unable to Detach Volume", err.Error())
+				resp.Diagnostics.AddError("Unable to Detach Volume", client.ErrorDetail(err))
 				return
 			}
 			goto detached
@@ -386,6 +426,16 @@ func (r *VolumeAttachmentResource) buildOpts(data *VolumeAttachmentResourceModel
 }
 
 func (r *VolumeAttachmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_volume_attachment", "import")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
+	// attached_volume_id is left unset: Read resolves it from volume_id, as it
+	// does after any import.
+	if importByIdentity(req) {
+		importIdentity(ctx, req, resp, r.client.Region, r.client.ProjectTag, "vm_id", "volume_id")
+		return
+	}
+
 	parts := strings.SplitN(req.ID, ":", 2)
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError(
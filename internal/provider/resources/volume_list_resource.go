@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"context"
+
+	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ list.ListResource              = &VolumeListResource{}
+	_ list.ListResourceWithConfigure = &VolumeListResource{}
+)
+
+// VolumeListResource lists the volumes in a project.
+type VolumeListResource struct {
+	listBase
+}
+
+func NewVolumeListResource() list.ListResource {
+	return &VolumeListResource{}
+}
+
+func (l *VolumeListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_volume"
+}
+
+func (l *VolumeListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listScopeSchema("volumes")
+}
+
+func (l *VolumeListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_volume", "list")
+	var diags diag.Diagnostics
+	defer op.End(nil, &diags)
+
+	region, projectTag := l.scope(ctx, req.Config, &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	volumes, err := l.c.GetVolumes(ctx, &client.RequestOpts{Region: region, ProjectTag: projectTag})
+	if err != nil {
+		diags.AddError("Unable to List Volumes", client.ErrorDetail(err))
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	tflog.Debug(ctx, "Listed volumes", map[string]any{"count": len(volumes), "region": region, "project_tag": projectTag})
+
+	items := make([]listItem, 0, len(volumes))
+	for i := range volumes {
+		volume := &volumes[i]
+		items = append(items, listItem{
+			displayName: volume.Name,
+			identity: scopedIdentityModel{
+				ID:         types.Int64Value(volume.ID),
+				Region:     types.StringValue(region),
+				ProjectTag: types.StringValue(projectTag),
+			},
+			resource: func(context.Context, *diag.Diagnostics) any {
+				return &VolumeResourceModel{
+					ID:                 types.Int64Value(volume.ID),
+					Region:             types.StringValue(region),
+					ProjectTag:         types.StringValue(projectTag),
+					Name:               types.StringValue(volume.Name),
+					Type:               types.StringValue(volume.Type),
+					Size:               types.Int64Value(volume.Size),
+					DeletionProtection: types.BoolValue(false),
+				}
+			},
+		})
+	}
+	stream.Results = streamListItems(ctx, req, items)
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"terraform-provider-prodata/internal/client"
+	"terraform-provider-prodata/internal/telemetry"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -21,6 +22,8 @@ var (
 	_ resource.Resource                = &VolumeResource{}
 	_ resource.ResourceWithConfigure   = &VolumeResource{}
 	_ resource.ResourceWithImportState = &VolumeResource{}
+	_ resource.ResourceWithIdentity    = &VolumeResource{}
+	_ resource.ResourceWithModifyPlan  = &VolumeResource{}
 )
 
 type VolumeResource struct {
@@ -28,12 +31,13 @@ type VolumeResource struct {
 }
 
 type VolumeResourceModel struct {
-	ID         types.Int64  `tfsdk:"id"`
-	Region     types.String `tfsdk:"region"`
-	ProjectTag types.String `tfsdk:"project_tag"`
-	Name       types.String `tfsdk:"name"`
-	Type       types.String `tfsdk:"type"`
-	Size       types.Int64  `tfsdk:"size"`
+	ID                 types.Int64  `tfsdk:"id"`
+	Region             types.String `tfsdk:"region"`
+	ProjectTag         types.String `tfsdk:"project_tag"`
+	Name               types.String `tfsdk:"name"`
+	Type               types.String `tfsdk:"type"`
+	Size               types.Int64  `tfsdk:"size"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func NewVolumeResource() resource.Resource {
@@ -92,10 +96,21 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					int64planmodifier.RequiresReplace(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute("volume"),
 		},
 	}
 }
 
+func (r *VolumeResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = scopedIdentitySchema("volume")
+}
+
+// ModifyPlan only enforces deletion_protection: a volume change that requires
+// replacement comes from the schema's plan modifiers.
+func (r *VolumeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkDeletionProtection(ctx, "prodata_volume", req, resp)
+}
+
 func (r *VolumeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -114,6 +129,11 @@ func (r *VolumeResource) Configure(ctx context.Context, req resource.ConfigureRe
 }
 
 func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_volume", "create")
+	defer op.End(&resp.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Create)
+	defer cancel()
+
 	var data VolumeResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -146,11 +166,11 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 		"size":        createReq.Size,
 	})
 
-	volume, err := client.RetryOnBusy(ctx, client.RetryTimeoutShort, func() (*client.Volume, error) {
+	volume, err := client.RetryOnBusy(ctx, r.client.Retry.BusyTimeoutOr(client.RetryTimeoutShort), func() (*client.Volume, error) {
 		return r.client.CreateVolume(ctx, createReq)
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Create Volume", err.Error())
+		resp.Diagnostics.AddError("Unable to Create Volume", client.ErrorDetail(err))
 		return
 	}
 
@@ -167,9 +187,15 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, data.ID, data.Region, data.ProjectTag)...)
 }
 
 func (r *VolumeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_volume", "read")
+	defer op.End(&req.State, &resp.Diagnostics)
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Read)
+	defer cancel()
+
 	var data VolumeResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -200,7 +226,7 @@ func (r *VolumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Read Volume", err.Error())
+		resp.Diagnostics.AddError("Unable to Read Volume", client.ErrorDetail(err))
 		return
 	}
 
@@ -233,10 +259,20 @@ func (r *VolumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 		"name": volume.Name,
 	})
 
+	data.DeletionProtection = deletionProtectionOrDefault(data.DeletionProtection)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, data.ID, data.Region, data.ProjectTag)...)
 }
 
 func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_volume", "update")
+	defer op.End(&req.State, &resp.Diagnostics)
+	if updateDeletionProtectionOnly(req, resp) {
+		return
+	}
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Update)
+	defer cancel()
+
 	var plan VolumeResourceModel
 	var state VolumeResourceModel
 
@@ -267,7 +303,7 @@ func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	volume, err := r.client.UpdateVolume(ctx, volumeID, updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Unable to Update Volume", err.Error())
+		resp.Diagnostics.AddError("Unable to Update Volume", client.ErrorDetail(err))
 		return
 	}
 
@@ -282,9 +318,18 @@ func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(setScopedIdentity(ctx, resp.Identity, plan.ID, plan.Region, plan.ProjectTag)...)
 }
 
 func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_volume", "delete")
+	defer op.End(&req.State, &resp.Diagnostics)
+	if refuseProtectedDelete(ctx, "prodata_volume", req.State, &resp.Diagnostics) {
+		return
+	}
+	ctx, cancel := withDefaultTimeout(ctx, r.client.DefaultTimeouts.Delete)
+	defer cancel()
+
 	var data VolumeResourceModel
 
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -313,7 +358,7 @@ func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		if client.IsNotFound(err) {
 			return
 		}
-		resp.Diagnostics.AddError("Unable to Delete Volume", err.Error())
+		resp.Diagnostics.AddError("Unable to Delete Volume", client.ErrorDetail(err))
 		return
 	}
 
@@ -321,6 +366,14 @@ func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *VolumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, op := telemetry.StartOperation(ctx, "prodata_volume", "import")
+	defer op.End(&resp.State, &resp.Diagnostics)
+
+	if importByIdentity(req) {
+		importScopedIdentity(ctx, req, resp, r.client.Region, r.client.ProjectTag)
+		return
+	}
+
 	id, err := strconv.ParseInt(req.ID, 10, 64)
 	if err != nil {
 		resp.Diagnostics.AddError(
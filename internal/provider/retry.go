@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"terraform-provider-prodata/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// RetryModel is the provider's retry block.
+type RetryModel struct {
+	MaxAttempts        types.Int64  `tfsdk:"max_attempts"`
+	BaseBackoff        types.String `tfsdk:"base_backoff"`
+	MaxBackoff         types.String `tfsdk:"max_backoff"`
+	BusyTimeout        types.String `tfsdk:"busy_timeout"`
+	PollErrorTolerance types.Int64  `tfsdk:"poll_error_tolerance"`
+
+	CircuitBreakerThreshold types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown  types.String `tfsdk:"circuit_breaker_cooldown"`
+}
+
+// DefaultTimeoutsModel is the provider's default_timeouts block.
+type DefaultTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// retrySettings are the resolved retry attributes, as configured.
+type retrySettings struct {
+	MaxAttempts        string
+	BaseBackoff        string
+	MaxBackoff         string
+	BusyTimeout        string
+	PollErrorTolerance string
+
+	CircuitBreakerThreshold string
+	CircuitBreakerCooldown  string
+}
+
+// clientRetry turns the settings into a client.RetryPolicy. On error it also
+// returns the attribute at fault.
+func (s retrySettings) clientRetry() (p client.RetryPolicy, attribute string, err error) {
+	if p.MaxAttempts, err = parseCount(s.MaxAttempts); err != nil {
+		return p, "max_attempts", err
+	}
+	if p.BaseBackoff, err = parseDuration(s.BaseBackoff); err != nil {
+		return p, "base_backoff", err
+	}
+	if p.MaxBackoff, err = parseDuration(s.MaxBackoff); err != nil {
+		return p, "max_backoff", err
+	}
+	if p.BaseBackoff > 0 && p.MaxBackoff > 0 && p.BaseBackoff > p.MaxBackoff {
+		return p, "max_backoff", fmt.Errorf("%s is shorter than base_backoff (%s)", p.MaxBackoff, p.BaseBackoff)
+	}
+	if p.BusyTimeout, err = parseDuration(s.BusyTimeout); err != nil {
+		return p, "busy_timeout", err
+	}
+	if p.PollErrorTolerance, err = parseCount(s.PollErrorTolerance); err != nil {
+		return p, "poll_error_tolerance", err
+	}
+	if p.BreakerThreshold, err = parseCount(s.CircuitBreakerThreshold); err != nil {
+		return p, "circuit_breaker_threshold", err
+	}
+	if p.BreakerCooldown, err = parseDuration(s.CircuitBreakerCooldown); err != nil {
+		return p, "circuit_breaker_cooldown", err
+	}
+	return p, "", nil
+}
+
+// timeoutSettings are the resolved default_timeouts attributes, as configured.
+type timeoutSettings struct {
+	Create string
+	Read   string
+	Update string
+	Delete string
+}
+
+// clientTimeouts turns the settings into client.Timeouts. On error it also
+// returns the attribute at fault.
+func (s timeoutSettings) clientTimeouts() (t client.Timeouts, attribute string, err error) {
+	if t.Create, err = parseDuration(s.Create); err != nil {
+		return t, "create", err
+	}
+	if t.Read, err = parseDuration(s.Read); err != nil {
+		return t, "read", err
+	}
+	if t.Update, err = parseDuration(s.Update); err != nil {
+		return t, "update", err
+	}
+	if t.Delete, err = parseDuration(s.Delete); err != nil {
+		return t, "delete", err
+	}
+	return t, "", nil
+}
+
+// parseDuration parses a positive Go duration such as "30s" or "5m". An empty
+// value is 0, meaning unset.
+func parseDuration(v string) (time.Duration, error) {
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%q is not a positive duration such as 30s or 5m", v)
+	}
+	return d, nil
+}
+
+// parseCount parses a count of at least 1. An empty value is 0, meaning unset.
+func parseCount(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a whole number of at least 1", v)
+	}
+	return n, nil
+}
+
+// int64String returns a configured integer as a string, or "" when it is null
+// or unknown, so it resolves like the string settings.
+func int64String(v types.Int64) string {
+	if v.IsNull() || v.IsUnknown() {
+		return ""
+	}
+	return strconv.FormatInt(v.ValueInt64(), 10)
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"terraform-provider-prodata/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRetrySettings_ClientRetry(t *testing.T) {
+	p, _, err := retrySettings{
+		MaxAttempts: "5", BaseBackoff: "1s", MaxBackoff: "20s", BusyTimeout: "10m", PollErrorTolerance: "6",
+		CircuitBreakerThreshold: "4", CircuitBreakerCooldown: "1m",
+	}.clientRetry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.MaxAttempts != 5 || p.BaseBackoff != time.Second || p.MaxBackoff != 20*time.Second ||
+		p.BusyTimeout != 10*time.Minute || p.PollErrorTolerance != 6 ||
+		p.BreakerThreshold != 4 || p.BreakerCooldown != time.Minute {
+		t.Errorf("policy = %+v", p)
+	}
+
+	if p, _, err := (retrySettings{}).clientRetry(); err != nil || p != (client.RetryPolicy{}) {
+		t.Errorf("empty settings: policy %+v, err %v; want the zero policy", p, err)
+	}
+	for _, tc := range []struct {
+		s    retrySettings
+		attr string
+	}{
+		{retrySettings{MaxAttempts: "0"}, "max_attempts"},
+		{retrySettings{BaseBackoff: "soon"}, "base_backoff"},
+		{retrySettings{BaseBackoff: "10s", MaxBackoff: "5s"}, "max_backoff"},
+		{retrySettings{BusyTimeout: "-1m"}, "busy_timeout"},
+		{retrySettings{PollErrorTolerance: "x"}, "poll_error_tolerance"},
+		{retrySettings{CircuitBreakerThreshold: "-2"}, "circuit_breaker_threshold"},
+		{retrySettings{CircuitBreakerCooldown: "0s"}, "circuit_breaker_cooldown"},
+	} {
+		if _, attr, err := tc.s.clientRetry(); err == nil || attr != tc.attr {
+			t.Errorf("%+v: attr %q, err %v; want a %s error", tc.s, attr, err, tc.attr)
+		}
+	}
+}
+
+func TestTimeoutSettings_ClientTimeouts(t *testing.T) {
+	to, _, err := timeoutSettings{Create: "45m", Delete: "90s"}.clientTimeouts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if to.Create != 45*time.Minute || to.Read != 0 || to.Update != 0 || to.Delete != 90*time.Second {
+		t.Errorf("timeouts = %+v", to)
+	}
+	if _, attr, err := (timeoutSettings{Read: "1 hour"}).clientTimeouts(); err == nil || attr != "read" {
+		t.Errorf("invalid read: attr %q, err %v; want a read error", attr, err)
+	}
+}
+
+// TestUnitProvider_retry rejects an invalid retry duration, and applies with a
+// valid retry block and default_timeouts.
+func TestUnitProvider_retry(t *testing.T) {
+	s := testFakePanel(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testFakeProviderConfigWith(s, "  retry {\n    base_backoff = \"soon\"\n  }") + testAccPublicIPConfig("ip"),
+				ExpectError: regexp.MustCompile(`base_backoff: "soon" is not a positive duration`),
+			},
+			{
+				Config: testFakeProviderConfigWith(s, "  retry {\n    max_attempts = 2\n    busy_timeout = \"30s\"\n  }\n"+
+					"  default_timeouts {\n    create = \"5m\"\n  }") + testAccPublicIPConfig("ip"),
+			},
+		},
+	})
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"terraform-provider-prodata/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// TestUnitS3Bucket_lifecycle runs the prodata_s3_bucket create/update/import cycle
+// against the fake panel, then checks that destroying a non-empty bucket fails
+// (738) and succeeds once it has been emptied.
+func TestUnitS3Bucket_lifecycle(t *testing.T) {
+	s := testFakePanel(t)
+	resourceName := "prodata_s3_bucket.test"
+	name := "tfunit-bucket"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(s) + testAccS3BucketConfig(name, "private", false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("versioning"), knownvalue.Bool(false)),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("object_lock_enabled"), knownvalue.Bool(false)),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{ // Enable versioning in place.
+				Config: testFakeProviderConfig(s) + testAccS3BucketConfigWithData(name, "private", true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("versioning"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("data.prodata_s3_bucket.test", tfjsonpath.New("versioning"), knownvalue.Bool(true)),
+				},
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"acl"},
+				ImportStateIdFunc:       s3BucketImportID(resourceName),
+			},
+			{ // Put objects in the bucket, then try to remove it from config.
+				Config: testFakeProviderConfig(s) + testAccS3BucketConfig(name, "private", true),
+				Check: func(*terraform.State) error {
+					s.SetBucketObjectCount(name, 2)
+					return nil
+				},
+			},
+			{
+				Config:      testFakeProviderConfig(s),
+				ExpectError: regexp.MustCompile(`(?i)not empty`),
+			},
+			{
+				Config: testFakeProviderConfig(s),
+				PreConfig: func() {
+					s.SetBucketObjectCount(name, 0)
+				},
+			},
+		},
+	})
+}
+
+// TestUnitS3Bucket_objectLockNeedsPanelSupport: object lock on a panel that does
+// not report it fails the plan; on a panel that predates the capabilities
+// endpoint it is allowed, as before.
+func TestUnitS3Bucket_objectLockNeedsPanelSupport(t *testing.T) {
+	config := `
+resource "prodata_s3_bucket" "test" {
+  name                = "tfunit-locked"
+  versioning          = true
+  object_lock_enabled = true
+}
+`
+	s := testFakePanel(t)
+	s.SetCapabilities(client.FeatureNodeIPRangeAllocation, client.FeatureCCMLoadBalancer)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testFakeProviderConfig(s) + config,
+				ExpectError: regexp.MustCompile(`(?s)Feature not supported by this panel.*storage\.object_lock`),
+			},
+		},
+	})
+
+	older := testFakePanel(t)
+	older.DisableCapabilities()
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(older) + config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("prodata_s3_bucket.test", tfjsonpath.New("object_lock_enabled"), knownvalue.Bool(true)),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strings"
+
+	"terraform-provider-prodata/internal/client"
+)
+
+// tlsMinVersions maps the tls_min_version values to crypto/tls versions.
+var tlsMinVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// transportSettings are the resolved connection attributes, as configured:
+// the PEM values may still be file paths.
+type transportSettings struct {
+	CABundle          string
+	ClientCertificate string
+	ClientKey         string
+	ProxyURL          string
+	TLSMinVersion     string
+}
+
+// clientTransport turns the settings into a client.TransportConfig, reading the
+// PEM values that name files. On error it also returns the attribute at fault.
+func (s transportSettings) clientTransport() (tc client.TransportConfig, attribute string, err error) {
+	if tc.CABundle, err = pemOrFile(s.CABundle); err != nil {
+		return tc, "ca_bundle", err
+	}
+	if tc.ClientCertificate, err = pemOrFile(s.ClientCertificate); err != nil {
+		return tc, "client_certificate", err
+	}
+	if tc.ClientKey, err = pemOrFile(s.ClientKey); err != nil {
+		return tc, "client_key", err
+	}
+	if s.TLSMinVersion != "" {
+		v, ok := tlsMinVersions[s.TLSMinVersion]
+		if !ok {
+			return tc, "tls_min_version", fmt.Errorf("%q is not one of 1.2, 1.3", s.TLSMinVersion)
+		}
+		tc.MinTLSVersion = v
+	}
+	tc.ProxyURL = s.ProxyURL
+	return tc, "", nil
+}
+
+// pemOrFile returns v itself when it holds PEM data, else the contents of the
+// file it names. An empty value is no data.
+func pemOrFile(v string) ([]byte, error) {
+	if v == "" {
+		return nil, nil
+	}
+	if strings.Contains(v, "-----BEGIN ") {
+		return []byte(v), nil
+	}
+	data, err := os.ReadFile(v)
+	if err != nil {
+		return nil, fmt.Errorf("not PEM data, and not a readable file: %w", err)
+	}
+	return data, nil
+}
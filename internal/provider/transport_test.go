@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testPEM = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+func TestTransportSettings_ClientTransport(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(file, []byte(testPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tc, _, err := transportSettings{
+		CABundle: file, ClientCertificate: testPEM, ProxyURL: "http://proxy:3128", TLSMinVersion: "1.3",
+	}.clientTransport()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(tc.CABundle) != testPEM || string(tc.ClientCertificate) != testPEM {
+		t.Errorf("PEM values = %q / %q, want the file contents and the inline PEM", tc.CABundle, tc.ClientCertificate)
+	}
+	if tc.ProxyURL != "http://proxy:3128" || tc.MinTLSVersion != tls.VersionTLS13 {
+		t.Errorf("proxy/version = %q / %x", tc.ProxyURL, tc.MinTLSVersion)
+	}
+
+	if _, attr, err := (transportSettings{ClientKey: filepath.Join(t.TempDir(), "missing")}).clientTransport(); err == nil || attr != "client_key" {
+		t.Errorf("missing key file: attr %q, err %v; want a client_key error", attr, err)
+	}
+	if _, attr, err := (transportSettings{TLSMinVersion: "1.1"}).clientTransport(); err == nil || attr != "tls_min_version" {
+		t.Errorf("TLS 1.1: attr %q, err %v; want a tls_min_version error", attr, err)
+	}
+}
+
+// TestUnitProvider_transport rejects an unreadable ca_bundle and an unsupported
+// tls_min_version, and still applies through the custom transport once the
+// settings are valid.
+func TestUnitProvider_transport(t *testing.T) {
+	s := testFakePanel(t)
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testFakeProviderConfigWith(s, `  ca_bundle = "/nonexistent/ca.pem"`) + testAccPublicIPConfig("ip"),
+				ExpectError: regexp.MustCompile(`ca_bundle: not PEM data`),
+			},
+			{
+				Config:      testFakeProviderConfigWith(s, `  tls_min_version = "1.1"`) + testAccPublicIPConfig("ip"),
+				ExpectError: regexp.MustCompile(`tls_min_version`),
+			},
+			{
+				Config: testFakeProviderConfigWith(s, `  tls_min_version = "1.3"`) + testAccPublicIPConfig("ip"),
+			},
+		},
+	})
+}
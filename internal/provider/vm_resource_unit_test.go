@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestUnitVm_lifecycle runs the prodata_vm create/resize/rename/import/destroy cycle
+// against the fake panel. The resize goes through the stop, resize, start path.
+func TestUnitVm_lifecycle(t *testing.T) {
+	s := testFakePanel(t)
+	resourceName := "prodata_vm.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(s) + testUnitVmConfig("web", 1, 2),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("status"), knownvalue.StringExact("RUNNING")),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("private_ip"), knownvalue.StringExact("10.40.0.2")),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("image_slug"), knownvalue.StringExact("ubuntu-22.04")),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("guid"), knownvalue.NotNull()),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{ // Resize and rename in place.
+				Config: testFakeProviderConfig(s) + testUnitVmConfig("vm-renamed", 2, 4),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply:             []plancheck.PlanCheck{plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate)},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("cpu_cores"), knownvalue.Int64Exact(2)),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("ram"), knownvalue.Int64Exact(4)),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("status"), knownvalue.StringExact("RUNNING")),
+				},
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "timeouts"},
+			},
+		},
+	})
+}
+
+// testUnitVmWriteOnlyConfig is testUnitVmConfig with password_wo and password_wo_version,
+// plus ssh_public_key_wo unless key is empty, in place of password.
+func testUnitVmWriteOnlyConfig(password string, version int, key string) string {
+	credentials := fmt.Sprintf(`
+  password_wo         = %q
+  password_wo_version = %d`, password, version)
+	if key != "" {
+		credentials += fmt.Sprintf(`
+  ssh_public_key_wo   = %q`, key)
+	}
+	return strings.Replace(testUnitVmConfig("web", 1, 2), testUnitVmPassword, credentials, 1)
+}
+
+// testUnitVmPassword is the password line of testUnitVmConfig.
+const testUnitVmPassword = `  password         = "FakePanel123"`
+
+// TestUnitVm_writeOnlyCredentials: a VM moves from password and ssh_public_key to
+// the write-only password_wo and ssh_public_key_wo with the same values without replacement;
+// bumping password_wo_version or changing ssh_public_key_wo then replaces it, and neither
+// value reaches state.
+func TestUnitVm_writeOnlyCredentials(t *testing.T) {
+	s := testFakePanel(t)
+	resourceName := "prodata_vm.test"
+	base := strings.Replace(testUnitVmConfig("web", 1, 2), testUnitVmPassword,
+		testUnitVmPassword+"\n  ssh_public_key   = \"ssh-ed25519 AAAAkey1\"", 1)
+	writeOnly := func(version int, key string) string {
+		password := "FakePanel123"
+		if version > 1 {
+			password = fmt.Sprintf("FakePanel123-v%d", version)
+		}
+		return testUnitVmWriteOnlyConfig(password, version, key)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(s) + base,
+			},
+			{ // Move to the write-only attributes: the VM keeps its credentials.
+				Config: testFakeProviderConfig(s) + writeOnly(1, "ssh-ed25519 AAAAkey1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply:             []plancheck.PlanCheck{plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate)},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("password"), knownvalue.Null()),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("password_wo"), knownvalue.Null()),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("ssh_public_key_wo"), knownvalue.Null()),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("password_wo_version"), knownvalue.Int64Exact(1)),
+				},
+			},
+			{ // A new password version replaces the VM.
+				Config: testFakeProviderConfig(s) + writeOnly(2, "ssh-ed25519 AAAAkey1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply:             []plancheck.PlanCheck{plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace)},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{ // So does a new SSH key.
+				Config: testFakeProviderConfig(s) + writeOnly(2, "ssh-ed25519 AAAAkey2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply:             []plancheck.PlanCheck{plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace)},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+// TestUnitVm_writeOnlyPasswordChanged: moving to a password_wo other than the
+// password the VM was created with replaces the VM, which is the only way to apply it.
+func TestUnitVm_writeOnlyPasswordChanged(t *testing.T) {
+	s := testFakePanel(t)
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(s) + testUnitVmConfig("web", 1, 2),
+			},
+			{
+				Config: testFakeProviderConfig(s) + testUnitVmWriteOnlyConfig("FakePanel456", 1, ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply:             []plancheck.PlanCheck{plancheck.ExpectResourceAction("prodata_vm.test", plancheck.ResourceActionReplace)},
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+		},
+	})
+}
+
+// TestUnitVm_attachments runs the volume and public IP attachment resources against
+// the fake panel, including the stop-detach-start path on destroy.
+func TestUnitVm_attachments(t *testing.T) {
+	s := testFakePanel(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(s) + testAccVolumeAttachmentConfig("vol-att", "1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				ResourceName:      "prodata_volume_attachment.test",
+				ImportState:       true,
+				ImportStateIdFunc: volumeAttachmentImportID("prodata_volume_attachment.test"),
+				ImportStateVerify: true,
+				// No synthetic "id"; correlate on the computed VmDisk id.
+				ImportStateVerifyIdentifierAttribute: "attached_volume_id",
+			},
+			{
+				Config: testFakeProviderConfig(s) + testAccPublicIPAttachmentConfig("ip-att", "1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{
+				ResourceName:      "prodata_public_ip_attachment.test",
+				ImportState:       true,
+				ImportStateIdFunc: publicIPAttachmentImportID("prodata_public_ip_attachment.test"),
+				ImportStateVerify: true,
+				// No synthetic "id"; correlate on public_ip_id.
+				ImportStateVerifyIdentifierAttribute: "public_ip_id",
+				ImportStateVerifyIgnore:              []string{"public_ip"},
+			},
+		},
+	})
+}
+
+func testUnitVmConfig(name string, cpu, ram int) string {
+	return fmt.Sprintf(`
+resource "prodata_local_network" "test" {
+  name    = "vm-net"
+  cidr    = "10.40.0.0/24"
+  gateway = "10.40.0.1"
+}
+
+resource "prodata_vm" "test" {
+  name             = %[1]q
+  image_id         = 1
+  cpu_cores        = %[2]d
+  ram              = %[3]d
+  disk_size        = 20
+  disk_type        = "SSD"
+  local_network_id = prodata_local_network.test.id
+  password         = "FakePanel123"
+}
+`, name, cpu, ram)
+}
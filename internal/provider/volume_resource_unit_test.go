@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+// TestUnitVolume_lifecycle runs the prodata_volume create/rename/import/destroy
+// cycle against the fake panel, including the soft-delete drift check: the fake,
+// like the real panel, keeps serving deleted volumes by id.
+func TestUnitVolume_lifecycle(t *testing.T) {
+	s := testFakePanel(t)
+	c := testFakeClient(t, s)
+	resourceName := "prodata_volume.test"
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(st *terraform.State) error {
+			volumes, err := c.GetVolumes(context.Background(), nil)
+			if err != nil {
+				return err
+			}
+			if len(volumes) != 0 {
+				return fmt.Errorf("volumes still listed after destroy: %+v", volumes)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testFakeProviderConfig(s) + testAccVolumeConfig("data", "HDD", 10),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("name"), knownvalue.StringExact("data")),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("region"), knownvalue.StringExact("UZ-5")),
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("project_tag"), knownvalue.StringExact("fake-project")),
+				},
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PostApplyPostRefresh: []plancheck.PlanCheck{plancheck.ExpectEmptyPlan()},
+				},
+			},
+			{ // Rename in place.
+				Config: testFakeProviderConfig(s) + testAccVolumeConfig("data-renamed", "HDD", 10),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate)},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(resourceName, tfjsonpath.New("name"), knownvalue.StringExact("data-renamed")),
+				},
+			},
+			{ // A size change forces replacement.
+				Config: testFakeProviderConfig(s) + testAccVolumeConfig("data-renamed", "HDD", 20),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionDestroyBeforeCreate)},
+				},
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{ // Out-of-band delete is detected as drift despite the by-id GET succeeding.
+				Config: testFakeProviderConfig(s) + testAccVolumeConfig("data-renamed", "HDD", 20),
+				Check: func(st *terraform.State) error {
+					id, err := strconv.ParseInt(st.RootModule().Resources[resourceName].Primary.ID, 10, 64)
+					if err != nil {
+						return err
+					}
+					return c.DeleteVolume(context.Background(), id, nil)
+				},
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,196 @@
+// Package telemetry holds the provider's OpenTelemetry tracing: span helpers for
+// resource operations, panel requests and status polls, and the OTLP exporter
+// set up by main when an OTLP endpoint is configured.
+//
+// Spans always go through the global tracer provider. Until Setup installs an
+// exporting one it is the OpenTelemetry no-op provider, so instrumented code
+// costs next to nothing when tracing is off.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of every span the provider emits.
+const tracerName = "terraform-provider-prodata"
+
+// endpointEnvVars enable tracing: the standard OTLP exporter endpoint variables.
+// The exporter reads them (and the other OTEL_EXPORTER_OTLP_* settings) itself.
+var endpointEnvVars = []string{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"}
+
+// Setup installs a tracer provider that batches spans to an OTLP/HTTP endpoint
+// when one of endpointEnvVars is set, and does nothing otherwise. The returned
+// shutdown flushes pending spans; call it before the process exits.
+func Setup(ctx context.Context, version string) (shutdown func(context.Context) error, err error) {
+	enabled := false
+	for _, v := range endpointEnvVars {
+		if os.Getenv(v) != "" {
+			enabled = true
+		}
+	}
+	if !enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exp, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP trace exporter: %w", err)
+	}
+	// Service attributes first, so that OTEL_SERVICE_NAME and
+	// OTEL_RESOURCE_ATTRIBUTES can override them.
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(tracerName), semconv.ServiceVersion(version)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil && !errors.Is(err, resource.ErrPartialResource) {
+		return nil, fmt.Errorf("build trace resource: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exp), sdktrace.WithResource(res))
+	otel.SetTracerProvider(tp)
+	// Panel requests carry a W3C traceparent, so a panel that is traced too can
+	// join its spans to the provider's.
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return tp.Shutdown, nil
+}
+
+// Tracer returns the provider's tracer from the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// End finishes span, marking it failed when err is non-nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// ---- resource operations ----
+
+// Attribute keys of resource operation spans.
+const (
+	AttrResourceType = attribute.Key("prodata.resource.type")
+	AttrResourceID   = attribute.Key("prodata.resource.id")
+	AttrOperation    = attribute.Key("prodata.operation")
+)
+
+// Operation is the span of one resource CRUD or import call.
+type Operation struct {
+	span trace.Span
+}
+
+// StartOperation starts the span of a resource operation, named like
+// "prodata_vm.create". Finish it with a deferred End:
+//
+//	ctx, op := telemetry.StartOperation(ctx, "prodata_vm", "create")
+//	defer op.End(&resp.State, &resp.Diagnostics)
+func StartOperation(ctx context.Context, resourceType, operation string) (context.Context, *Operation) {
+	ctx, span := Tracer().Start(ctx, resourceType+"."+operation,
+		trace.WithAttributes(AttrResourceType.String(resourceType), AttrOperation.String(operation)))
+	return ctx, &Operation{span: span}
+}
+
+// End records the resource id found in state (the "id" attribute, when set) and
+// the operation's error diagnostics, then ends the span. Both are read when End
+// runs, so pass the response's state and diagnostics by pointer in a defer.
+func (o *Operation) End(state *tfsdk.State, diags *diag.Diagnostics) {
+	if id := stateID(state); id != "" {
+		o.span.SetAttributes(AttrResourceID.String(id))
+	}
+	if diags != nil && diags.HasError() {
+		for _, d := range diags.Errors() {
+			o.span.AddEvent("error", trace.WithAttributes(attribute.String("summary", d.Summary())))
+		}
+		o.span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+	}
+	o.span.End()
+}
+
+// stateID returns the "id" attribute of state as a string, or "" when state
+// has no known id (a failed create, a removed resource).
+func stateID(state *tfsdk.State) string {
+	if state == nil || state.Raw.IsNull() || !state.Raw.IsKnown() {
+		return ""
+	}
+	v, err := state.Raw.ApplyTerraform5AttributePathStep(tftypes.AttributeName("id"))
+	if err != nil {
+		return ""
+	}
+	id, ok := v.(tftypes.Value)
+	if !ok || id.IsNull() || !id.IsKnown() {
+		return ""
+	}
+	switch {
+	case id.Type().Is(tftypes.String):
+		var s string
+		if id.As(&s) == nil {
+			return s
+		}
+	case id.Type().Is(tftypes.Number):
+		var n big.Float
+		if id.As(&n) == nil {
+			return n.Text('f', -1)
+		}
+	}
+	return ""
+}
+
+// ---- status polls ----
+
+// Attribute keys of poll spans.
+const (
+	AttrPollIterations = attribute.Key("prodata.poll.iterations")
+	AttrPollStatus     = attribute.Key("prodata.poll.status")
+	AttrPollTarget     = attribute.Key("prodata.poll.target")
+)
+
+// Poll is the span of a wait loop polling an object until it settles.
+type Poll struct {
+	span       trace.Span
+	iterations int
+	started    time.Time
+}
+
+// StartPoll starts the span of a wait loop, named "prodata.wait.<what>".
+func StartPoll(ctx context.Context, what string, attrs ...attribute.KeyValue) (context.Context, *Poll) {
+	ctx, span := Tracer().Start(ctx, "prodata.wait."+what, trace.WithAttributes(attrs...))
+	return ctx, &Poll{span: span, started: time.Now()}
+}
+
+// Iteration records one poll and the status it observed ("" after a failed
+// poll).
+func (p *Poll) Iteration(status string) {
+	p.iterations++
+	p.span.AddEvent("poll", trace.WithAttributes(
+		attribute.Int("iteration", p.iterations),
+		AttrPollStatus.String(status),
+		attribute.Int64("elapsed_ms", time.Since(p.started).Milliseconds()),
+	))
+}
+
+// End records the number of polls and ends the span, failed when err is non-nil.
+func (p *Poll) End(err error) {
+	p.span.SetAttributes(AttrPollIterations.Int(p.iterations))
+	End(p.span, err)
+}
@@ -0,0 +1,156 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a recording tracer provider for the duration of the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return rec
+}
+
+func attrValue(s sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range s.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func stateWithID(id tftypes.Value) *tfsdk.State {
+	typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": id.Type()}}
+	return &tfsdk.State{Raw: tftypes.NewValue(typ, map[string]tftypes.Value{"id": id})}
+}
+
+func TestStateID(t *testing.T) {
+	cases := map[string]struct {
+		state *tfsdk.State
+		want  string
+	}{
+		"number":  {stateWithID(tftypes.NewValue(tftypes.Number, 42)), "42"},
+		"string":  {stateWithID(tftypes.NewValue(tftypes.String, "12:34")), "12:34"},
+		"null id": {stateWithID(tftypes.NewValue(tftypes.String, nil)), ""},
+		"removed": {&tfsdk.State{Raw: tftypes.NewValue(tftypes.Object{}, nil)}, ""},
+		"nil":     {nil, ""},
+	}
+	for name, tc := range cases {
+		if got := stateID(tc.state); got != tc.want {
+			t.Errorf("%s: stateID = %q, want %q", name, got, tc.want)
+		}
+	}
+}
+
+func TestOperation_RecordsIDAndErrors(t *testing.T) {
+	rec := recordSpans(t)
+	_, op := StartOperation(context.Background(), "prodata_vm", "create")
+	var diags diag.Diagnostics
+	diags.AddError("Unable to Create VM", "boom")
+	op.End(stateWithID(tftypes.NewValue(tftypes.Number, 7)), &diags)
+
+	spans := rec.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	s := spans[0]
+	if s.Name() != "prodata_vm.create" {
+		t.Errorf("name = %q", s.Name())
+	}
+	if v, _ := attrValue(s, AttrResourceID); v.AsString() != "7" {
+		t.Errorf("resource id = %q, want 7", v.AsString())
+	}
+	if s.Status().Code != codes.Error || s.Status().Description != "Unable to Create VM" {
+		t.Errorf("status = %+v, want the error summary", s.Status())
+	}
+}
+
+func TestPoll_CountsIterations(t *testing.T) {
+	rec := recordSpans(t)
+	_, p := StartPoll(context.Background(), "cluster_ready", AttrResourceID.Int64(5))
+	p.Iteration("PROCESSING")
+	p.Iteration("")
+	p.Iteration("SUCCESS")
+	p.End(errors.New("terminal status FAIL"))
+
+	s := rec.Ended()[0]
+	polls := 0
+	for _, e := range s.Events() {
+		if e.Name == "poll" {
+			polls++
+		}
+	}
+	if s.Name() != "prodata.wait.cluster_ready" || polls != 3 {
+		t.Errorf("span %q with %d poll events, want prodata.wait.cluster_ready with 3", s.Name(), polls)
+	}
+	if v, _ := attrValue(s, AttrPollIterations); v.AsInt64() != 3 {
+		t.Errorf("iterations = %d, want 3", v.AsInt64())
+	}
+	if s.Status().Code != codes.Error {
+		t.Errorf("status = %+v, want an error", s.Status())
+	}
+}
+
+func TestSetup_DisabledWithoutEndpoint(t *testing.T) {
+	for _, v := range endpointEnvVars {
+		t.Setenv(v, "")
+	}
+	prev := otel.GetTracerProvider()
+	shutdown, err := Setup(context.Background(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if otel.GetTracerProvider() != prev {
+		t.Error("Setup replaced the tracer provider without an OTLP endpoint")
+	}
+}
+
+func TestSetup_ExportsToEndpoint(t *testing.T) {
+	var exported atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			exported.Add(1)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", srv.URL)
+	prev, prevProp := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+		otel.SetTextMapPropagator(prevProp)
+	})
+
+	shutdown, err := Setup(context.Background(), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := Tracer().Start(context.Background(), "prodata_vm.read")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+	if exported.Load() == 0 {
+		t.Error("no spans were exported to the OTLP endpoint")
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"terraform-provider-prodata/internal/provider"
+	"terraform-provider-prodata/internal/telemetry"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
@@ -23,7 +25,23 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
+
+	// Traces are exported only when an OTLP endpoint is configured
+	// (OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT).
+	shutdownTracing, err := telemetry.Setup(ctx, version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+
+	// Flush the spans of this run before the process exits.
+	flushCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	if serr := shutdownTracing(flushCtx); serr != nil {
+		log.Printf("[WARN] flushing traces: %s", serr)
+	}
+	cancel()
 
 	if err != nil {
 		log.Fatal(err.Error())